@@ -0,0 +1,116 @@
+// Command admin runs maintenance tasks against the matchup-service database.
+//
+// Usage:
+//
+//	admin rebuild-ratings               replay every decided match to rebuild player ratings
+//	admin check-shot-chains [-repair]   check every match's shots, optionally repairing them
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/repository"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/services"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/configs"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/db"
+	sharedRepo "github.com/CourtIQ/courtiq-backend/shared/pkg/repository"
+)
+
+// commands maps each subcommand to what it runs
+var commands = map[string]func(ctx context.Context, service *services.MatchUpService, args []string) error{
+	"rebuild-ratings":   rebuildRatings,
+	"check-shot-chains": checkShotChains,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+	}
+
+	config := configs.LoadConfig()
+	configs.SetupLogging(config)
+
+	ctx := context.Background()
+	mongodb, err := db.NewMongoDB(ctx, config.MongoDBURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	repoFactory := sharedRepo.NewRepositoryFactory(mongodb)
+	matchUpService := services.NewMatchUpService(
+		repository.NewMatchupsRepository(repoFactory),
+		repository.NewShotsRepository(repoFactory),
+		repository.NewFormatPresetsRepository(repoFactory),
+		repository.NewGuestClaimsRepository(repoFactory),
+		repository.NewRatingsRepository(repoFactory),
+		repository.NewTournamentsRepository(repoFactory),
+		repoFactory,
+	)
+
+	if err := command(ctx, matchUpService, os.Args[2:]); err != nil {
+		log.Fatalf("%s failed: %v", os.Args[1], err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: admin <command>")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  rebuild-ratings               replay every decided match to rebuild player ratings")
+	fmt.Fprintln(os.Stderr, "  check-shot-chains [-repair]   check every match's shots, optionally repairing them")
+	os.Exit(2)
+}
+
+// rebuildRatings discards the rating history and rebuilds it from scratch
+func rebuildRatings(ctx context.Context, service *services.MatchUpService, args []string) error {
+	rated, err := service.RebuildRatings(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Rebuilt ratings from %d matches", rated)
+	return nil
+}
+
+// checkShotChains reports the matches whose shots are inconsistent, and
+// repairs them with -repair
+func checkShotChains(ctx context.Context, service *services.MatchUpService, args []string) error {
+	flags := flag.NewFlagSet("check-shot-chains", flag.ExitOnError)
+	repair := flags.Bool("repair", false, "rewrite the shots and matchups to fix the problems found")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	reports, err := service.CheckShotChains(ctx, *repair)
+	if err != nil {
+		return err
+	}
+
+	inconsistent, repaired := 0, 0
+	for _, report := range reports {
+		if len(report.Problems) == 0 {
+			continue
+		}
+		inconsistent++
+		for _, problem := range report.Problems {
+			log.Printf("matchup %s: %s", report.MatchUpID.Hex(), problem)
+		}
+		if report.Repaired {
+			repaired++
+		} else if *repair {
+			log.Printf("matchup %s: not repaired, its shots cannot be scored again", report.MatchUpID.Hex())
+		}
+	}
+	log.Printf("Checked %d matches: %d inconsistent, %d repaired", len(reports), inconsistent, repaired)
+	if repaired > 0 {
+		log.Printf("Run rebuild-ratings if any repaired match changed its winner")
+	}
+	return nil
+}
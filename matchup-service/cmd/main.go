@@ -27,12 +27,25 @@ func main() {
 
 	repoFactory := sharedRepo.NewRepositoryFactory(mongodb)
 
+	// The service still works without its indexes, just more slowly and
+	// without the database backing up shot idempotency keys
+	if err := repository.EnsureShotIndexes(context.Background(), mongodb); err != nil {
+		log.Printf("Continuing without shot indexes: %v", err)
+	}
+	if err := repository.EnsureRatingIndexes(context.Background(), mongodb); err != nil {
+		log.Printf("Continuing without rating indexes: %v", err)
+	}
+
 	// Create repositories
 	matchUpRepo := repository.NewMatchupsRepository(repoFactory)
 	pointsRepo := repository.NewShotsRepository(repoFactory)
+	formatPresetsRepo := repository.NewFormatPresetsRepository(repoFactory)
+	guestClaimsRepo := repository.NewGuestClaimsRepository(repoFactory)
+	ratingsRepo := repository.NewRatingsRepository(repoFactory)
+	tournamentsRepo := repository.NewTournamentsRepository(repoFactory)
 
 	// Create matchup service
-	matchUpService := services.NewMatchUpService(matchUpRepo, pointsRepo)
+	matchUpService := services.NewMatchUpService(matchUpRepo, pointsRepo, formatPresetsRepo, guestClaimsRepo, ratingsRepo, tournamentsRepo, repoFactory)
 
 	// Initialize resolver
 	resolver := &resolvers.Resolver{
@@ -55,6 +68,10 @@ func main() {
 		log.Fatalf("Failed to create server: %v", err)
 	}
 
+	// Matches and their shots are only shown to viewers their visibility allows
+	gqlServer.RegisterDirectives(server.GetDefaultDirectives())
+	gqlServer.RegisterResourceLoader(matchUpService.LoadAccessResource)
+
 	// Start server
 	log.Printf("%s running in %s mode on port %d", config.ServiceName, config.Environment, config.Port)
 	if err := gqlServer.Serve(); err != nil {
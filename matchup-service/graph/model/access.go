@@ -0,0 +1,91 @@
+package model
+
+import (
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/access"
+)
+
+var (
+	_ access.Resource    = (*MatchUp)(nil)
+	_ access.Resource    = (*Tournament)(nil)
+	_ access.ResourceRef = (*MatchUpShot)(nil)
+	_ access.ResourceRef = (*OrderOfPlayEntry)(nil)
+)
+
+// AccessOwner returns the match owner, whose relationships decide who else
+// can see the match
+func (m *MatchUp) AccessOwner() string {
+	return m.Owner.Hex()
+}
+
+// AccessLevel returns the match's visibility. Matches stored before it could
+// be chosen are private.
+func (m *MatchUp) AccessLevel() access.AccessLevel {
+	if m.Visibility == "" {
+		return access.AccessLevelPrivate
+	}
+	return access.AccessLevel(m.Visibility)
+}
+
+// AccessMember reports whether a user plays in or tracks the match, which
+// lets them see it whatever its visibility
+func (m *MatchUp) AccessMember(userID string) bool {
+	for _, participant := range m.Participants {
+		if participant.ID.Hex() == userID {
+			return true
+		}
+	}
+	for _, tracker := range m.Trackers {
+		if tracker.Hex() == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessEntity identifies the match for entity-specific access checks
+func (m *MatchUp) AccessEntity() (string, string) {
+	return m.ID.Hex(), "MATCH"
+}
+
+// AccessOwner returns the organiser, whose relationships decide who else
+// can see the tournament
+func (t *Tournament) AccessOwner() string {
+	return t.Owner.Hex()
+}
+
+// AccessLevel returns the visibility the tournament's matches are created
+// with. Tournaments stored before it could be chosen are private.
+func (t *Tournament) AccessLevel() access.AccessLevel {
+	if t.Visibility == "" {
+		return access.AccessLevelPrivate
+	}
+	return access.AccessLevel(t.Visibility)
+}
+
+// AccessMember reports whether a user is entered in the tournament, which
+// lets them see its draws whatever its visibility
+func (t *Tournament) AccessMember(userID string) bool {
+	for _, entry := range t.Entries {
+		for _, player := range entry.Players {
+			if player.ID.Hex() == userID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AccessEntity identifies the tournament for entity-specific access checks
+func (t *Tournament) AccessEntity() (string, string) {
+	return t.ID.Hex(), "TOURNAMENT"
+}
+
+// AccessResourceID returns the match whose visibility applies to the shot
+func (s *MatchUpShot) AccessResourceID() string {
+	return s.MatchUpID.Hex()
+}
+
+// AccessResourceID returns the match whose visibility applies to the entry
+func (e *OrderOfPlayEntry) AccessResourceID() string {
+	return e.MatchUp.ID.Hex()
+}
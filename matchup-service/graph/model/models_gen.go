@@ -8,12 +8,46 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/schema/scalars"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Input for recording something other than a shot that changes the score or
+// the serve, such as a let or a penalty.
+type AddMatchEventInput struct {
+	// The match ID this event belongs to.
+	MatchUpID primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
+	// What happened.
+	EventType MatchEventType `json:"eventType" bson:"eventType"`
+	// The player the event is charged to. Required for penalties and defaults,
+	// whose point, game or match goes to the other side. For a let it is the
+	// server, which is worked out when omitted.
+	PlayerID *primitive.ObjectID `json:"playerId,omitempty" bson:"playerId,omitempty"`
+	// The violation a penalty was given for, if known. Not allowed for a let.
+	Violation *ViolationType `json:"violation,omitempty" bson:"violation,omitempty"`
+}
+
+// Input for recording a point without its rally, when a match is tracked at
+// BEGINNER. The point is served by whoever's turn it is.
+type AddPointInput struct {
+	// The match ID this point belongs to.
+	MatchUpID primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
+	// The team that won the point.
+	PointWinner TeamSide `json:"pointWinner" bson:"pointWinner"`
+	// How the point was decided, if known. ACE must be won and DOUBLE_FAULT
+	// lost by the serving side.
+	PointWinReason *PointWinReason `json:"pointWinReason,omitempty" bson:"pointWinReason,omitempty"`
+	// The player who hit the winner or made the error. Only used together with
+	// pointWinReason. Aces and double faults are always the server's; in singles
+	// the player is worked out from the reason, in doubles it must be given.
+	PlayerID *primitive.ObjectID `json:"playerId,omitempty" bson:"playerId,omitempty"`
+}
+
 // Input for adding a new shot to a tennis match.
 // The backend will handle score calculation and state updates.
+// Details beyond shotType and shotOutcome may be required by the match's
+// trackingStyle.
 type AddShotInput struct {
 	// The match ID this shot belongs to.
 	MatchUpID primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
@@ -34,30 +68,305 @@ type AddShotInput struct {
 	// Only applicable when shotType is SERVE.
 	ServeNumber *ServeNumber `json:"serveNumber,omitempty" bson:"serveNumber,omitempty"`
 	// Which service box the serve was directed to.
-	// Only applicable when shotType is SERVE.
+	// Only applicable when shotType is SERVE. Worked out from the score when
+	// omitted; if provided it must match the box the point is due to be served into.
 	ServiceBoxSide *ServiceBoxSide `json:"serviceBoxSide,omitempty" bson:"serviceBoxSide,omitempty"`
 	// The outcome of this specific shot.
 	ShotOutcome ShotOutcome `json:"shotOutcome" bson:"shotOutcome"`
-	// If this shot won the point, specifies how.
-	// Only applicable when shotOutcome is WON_POINT.
+	// Specifies how the point was decided when this shot ended it.
+	// ACE or WINNER when shotOutcome is WON_POINT, FORCED_ERROR or
+	// UNFORCED_ERROR when shotOutcome is ERROR. Double faults are
+	// detected automatically.
 	PointWinReason *PointWinReason `json:"pointWinReason,omitempty" bson:"pointWinReason,omitempty"`
+	// Where the ball bounced, if recorded.
+	BounceLocation *CourtPositionInput `json:"bounceLocation,omitempty" bson:"bounceLocation,omitempty"`
+	// Where the hitter stood when they hit the shot, if recorded.
+	HitterPosition *CourtPositionInput `json:"hitterPosition,omitempty" bson:"hitterPosition,omitempty"`
+	// Client-generated idempotency key, unique within the match. A shot sent
+	// again with a key the match already has is not added a second time; the
+	// shot recorded the first time is returned instead. Required by syncShots.
+	ClientShotID *string `json:"clientShotId,omitempty" bson:"clientShotId,omitempty"`
+}
+
+// Enters a player, or a doubles team, in a tournament.
+type AddTournamentEntryInput struct {
+	TournamentID primitive.ObjectID `json:"tournamentId" bson:"tournamentId"`
+	// One registered user for singles, two for doubles.
+	Players []*TournamentPlayerInput `json:"players" bson:"players"`
+	// The entry's seed, unique within the tournament. Leave out for unseeded entries.
+	Seed *int `json:"seed,omitempty" bson:"seed,omitempty"`
+}
+
+// Decides a draw slot whose match was cancelled or abandoned, sending one side
+// through as if the other had withdrawn.
+type AwardDrawWalkoverInput struct {
+	TournamentID primitive.ObjectID `json:"tournamentId" bson:"tournamentId"`
+	DrawID       primitive.ObjectID `json:"drawId" bson:"drawId"`
+	// The slot to decide, e.g. "MAIN-2-1".
+	SlotID string `json:"slotId" bson:"slotId"`
+	// The side that goes through.
+	Winner TeamSide `json:"winner" bson:"winner"`
+}
+
+// A player's lifetime statistics, added up over their decided matches:
+// completed ones and ones that ended in a retirement.
+type CareerStatistics struct {
+	// Decided matches the player took part in.
+	MatchesPlayed int `json:"matchesPlayed" bson:"matchesPlayed"`
+	MatchesWon    int `json:"matchesWon" bson:"matchesWon"`
+	MatchesLost   int `json:"matchesLost" bson:"matchesLost"`
+	// The win/loss record for each match type played.
+	Records   []*MatchUpTypeRecord `json:"records" bson:"records"`
+	SetsWon   int                  `json:"setsWon" bson:"setsWon"`
+	SetsLost  int                  `json:"setsLost" bson:"setsLost"`
+	GamesWon  int                  `json:"gamesWon" bson:"gamesWon"`
+	GamesLost int                  `json:"gamesLost" bson:"gamesLost"`
+	// Sets decided by a tiebreak that the player's side won.
+	TiebreaksWon int `json:"tiebreaksWon" bson:"tiebreaksWon"`
+	// Sets decided by a tiebreak that the player's side lost.
+	TiebreaksLost int `json:"tiebreaksLost" bson:"tiebreaksLost"`
+	// Aces served by the player.
+	Aces int `json:"aces" bson:"aces"`
+	// Double faults served by the player.
+	DoubleFaults int `json:"doubleFaults" bson:"doubleFaults"`
+	// Percentage (0-100) of the player's service points that were aces,
+	// counting only points that recorded how they ended.
+	// Null if no such point has been served.
+	AceRate *float64 `json:"aceRate,omitempty" bson:"aceRate,omitempty"`
+	// Percentage (0-100) of the player's service points that were double faults,
+	// counting only points that recorded how they ended.
+	// Null if no such point has been served.
+	DoubleFaultRate *float64 `json:"doubleFaultRate,omitempty" bson:"doubleFaultRate,omitempty"`
+	// Break points the player's side earned on the opponent's serve.
+	BreakPointOpportunities int `json:"breakPointOpportunities" bson:"breakPointOpportunities"`
+	// Break point opportunities the player's side won.
+	BreakPointsConverted int `json:"breakPointsConverted" bson:"breakPointsConverted"`
+	// Percentage (0-100) of break point opportunities converted.
+	// Null if the player's side never had a break point.
+	BreakPointConversion *float64 `json:"breakPointConversion,omitempty" bson:"breakPointConversion,omitempty"`
+}
+
+// Narrows down the matches careerStats adds up. Every field is optional and
+// all provided fields must match.
+type CareerStatsFilterInput struct {
+	// Only matches that started at or after this time.
+	StartTimeFrom *time.Time `json:"startTimeFrom,omitempty" bson:"startTimeFrom,omitempty"`
+	// Only matches that started at or before this time.
+	StartTimeTo *time.Time `json:"startTimeTo,omitempty" bson:"startTimeTo,omitempty"`
+	// Only matches against this user.
+	OpponentID *primitive.ObjectID `json:"opponentId,omitempty" bson:"opponentId,omitempty"`
+}
+
+// A point on the court, normalized to the doubles court as seen from the end of
+// the player who hit the shot: x runs from 0 at their left sideline to 1 at
+// their right sideline, and y from 0 at their baseline to 1 at the opponent's
+// baseline, so the net is at y = 0.5. Positions outside the court lie outside
+// 0-1, down to -0.5 and up to 1.5.
+type CourtPosition struct {
+	X float64 `json:"x" bson:"x"`
+	Y float64 `json:"y" bson:"y"`
+}
+
+// A point on the court, normalized as described on CourtPosition.
+// Both coordinates must be between -0.5 and 1.5.
+type CourtPositionInput struct {
+	X float64 `json:"x" bson:"x"`
+	Y float64 `json:"y" bson:"y"`
+}
+
+// Creates a draw from a tournament's entries. Matches are created for every
+// slot whose entries are known, and byes are settled straight away.
+type CreateDrawInput struct {
+	TournamentID primitive.ObjectID `json:"tournamentId" bson:"tournamentId"`
+	Name         string             `json:"name" bson:"name"`
+	DrawType     DrawType           `json:"drawType" bson:"drawType"`
+	// The entries to place. Leave out to place every entry of the tournament.
+	EntryIds []primitive.ObjectID `json:"entryIds,omitempty" bson:"entryIds,omitempty"`
+}
+
+// Saves a MatchUpFormat under a name so it can be reused for new matches.
+type CreateMatchUpFormatPresetInput struct {
+	// Short display name for the preset.
+	Name string `json:"name" bson:"name"`
+	// Optional longer explanation of the format.
+	Description *string `json:"description,omitempty" bson:"description,omitempty"`
+	// The format to save.
+	MatchUpFormat *MatchUpFormatInput `json:"matchUpFormat" bson:"matchUpFormat"`
+}
+
+// Creates a tournament. Entries and draws are added afterwards.
+type CreateTournamentInput struct {
+	Name        string      `json:"name" bson:"name"`
+	MatchUpType MatchUpType `json:"matchUpType" bson:"matchUpType"`
+	// The rules every match is played under. Exactly one of matchUpFormat and
+	// matchUpFormatPresetId must be given.
+	MatchUpFormat *MatchUpFormatInput `json:"matchUpFormat,omitempty" bson:"matchUpFormat,omitempty"`
+	// A saved or built-in format preset, copied onto the tournament.
+	MatchUpFormatPresetID *primitive.ObjectID   `json:"matchUpFormatPresetId,omitempty" bson:"matchUpFormatPresetId,omitempty"`
+	TrackingStyle         *MatchUpTrackingStyle `json:"trackingStyle,omitempty" bson:"trackingStyle,omitempty"`
+	// Who besides their players and trackers can see the tournament's matches.
+	Visibility *MatchUpVisibility `json:"visibility,omitempty" bson:"visibility,omitempty"`
+}
+
+// A draw sheet: the slots of one competition within a tournament, each a match
+// between two entries, and how entries move between them.
+type Draw struct {
+	ID       primitive.ObjectID `json:"id" bson:"_id"`
+	Name     string             `json:"name" bson:"name"`
+	DrawType DrawType           `json:"drawType" bson:"drawType"`
+	// The entries placed in the draw, in seeding order.
+	EntryIds []primitive.ObjectID `json:"entryIds" bson:"entryIds"`
+	// Every slot of the draw, bracket by bracket and round by round.
+	Slots []*DrawSlot `json:"slots" bson:"slots"`
+	// The ranking of a round robin draw, best first. Empty for knockout draws.
+	Standings []*DrawStanding `json:"standings" bson:"standings"`
+	CreatedAt time.Time       `json:"createdAt" bson:"createdAt"`
+}
+
+// A match position in a draw.
+type DrawSlot struct {
+	// Identifies the slot within its draw, e.g. "MAIN-2-1" for the first match
+	// of the main bracket's second round.
+	ID        string          `json:"id" bson:"_id"`
+	Structure DrawStructure   `json:"structure" bson:"structure"`
+	Round     int             `json:"round" bson:"round"`
+	Position  int             `json:"position" bson:"position"`
+	Sides     []*DrawSlotSide `json:"sides" bson:"sides"`
+	Status    DrawSlotStatus  `json:"status" bson:"status"`
+	// The match played for the slot, created once both entries are known.
+	MatchUpID *primitive.ObjectID `json:"matchUpId,omitempty" bson:"matchUpId,omitempty"`
+	// The entry that came through the slot.
+	Winner *primitive.ObjectID `json:"winner,omitempty" bson:"winner,omitempty"`
+	// Where the winner moves on to. Null for a bracket's final and in round robins.
+	WinnerTo *DrawSlotLink `json:"winnerTo,omitempty" bson:"winnerTo,omitempty"`
+	// Where the loser moves on to in compass and consolation draws.
+	LoserTo *DrawSlotLink `json:"loserTo,omitempty" bson:"loserTo,omitempty"`
+}
+
+// A side of another slot in the same draw.
+type DrawSlotLink struct {
+	SlotID   string   `json:"slotId" bson:"slotId"`
+	TeamSide TeamSide `json:"teamSide" bson:"teamSide"`
+}
+
+// One side of a draw slot: an entry, a bye, or still to be decided.
+type DrawSlotSide struct {
+	TeamSide TeamSide            `json:"teamSide" bson:"teamSide"`
+	EntryID  *primitive.ObjectID `json:"entryId,omitempty" bson:"entryId,omitempty"`
+	Bye      bool                `json:"bye" bson:"bye"`
+}
+
+// An entry's record in a round robin draw.
+type DrawStanding struct {
+	EntryID primitive.ObjectID `json:"entryId" bson:"entryId"`
+	Played  int                `json:"played" bson:"played"`
+	Won     int                `json:"won" bson:"won"`
+	Lost    int                `json:"lost" bson:"lost"`
+}
+
+// An invitation from a match owner to a user to take over a guest participant.
+// Accepting it replaces the guest's ID with the user's in every match the owner
+// recorded with that guest, so the history and statistics follow the user.
+type GuestClaim struct {
+	// Unique identifier, passed to acceptGuestClaim and the other claim mutations.
+	ID primitive.ObjectID `json:"id" bson:"_id"`
+	// The match owner who sent the invitation.
+	Owner primitive.ObjectID `json:"owner" bson:"owner"`
+	// The user invited to claim the guest.
+	Claimant primitive.ObjectID `json:"claimant" bson:"claimant"`
+	// The guest participant's ID.
+	GuestID primitive.ObjectID `json:"guestId" bson:"guestId"`
+	// The guest's displayed name when the invitation was sent.
+	DisplayName string `json:"displayName" bson:"displayName"`
+	// The match the invitation was sent from.
+	MatchUpID primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
+	Status    GuestClaimStatus   `json:"status" bson:"status"`
+	// The matches moved to the claimant when the claim was accepted.
+	ClaimedMatchUpIds []primitive.ObjectID `json:"claimedMatchUpIds" bson:"claimedMatchUpIds"`
+	CreatedAt         time.Time            `json:"createdAt" bson:"createdAt"`
+	UpdatedAt         time.Time            `json:"updatedAt" bson:"updatedAt"`
+}
+
+// The record between two players over the matches they played on opposite sides,
+// in singles and doubles. Only completed matches and ones that ended in a
+// retirement count. Sets, games and scorelines are from userA's point of view.
+type HeadToHead struct {
+	UserA         primitive.ObjectID `json:"userA" bson:"userA"`
+	UserB         primitive.ObjectID `json:"userB" bson:"userB"`
+	MatchesPlayed int                `json:"matchesPlayed" bson:"matchesPlayed"`
+	UserAWins     int                `json:"userAWins" bson:"userAWins"`
+	UserBWins     int                `json:"userBWins" bson:"userBWins"`
+	UserASetsWon  int                `json:"userASetsWon" bson:"userASetsWon"`
+	UserBSetsWon  int                `json:"userBSetsWon" bson:"userBSetsWon"`
+	UserAGamesWon int                `json:"userAGamesWon" bson:"userAGamesWon"`
+	UserBGamesWon int                `json:"userBGamesWon" bson:"userBGamesWon"`
+	// The score of the most recent match, like "6-4 3-6 7-6(5)".
+	// Null if the players have never met.
+	LastScoreline *string `json:"lastScoreline,omitempty" bson:"lastScoreline,omitempty"`
+	// When the most recent match ended. Null if the players have never met.
+	LastPlayedAt *time.Time `json:"lastPlayedAt,omitempty" bson:"lastPlayedAt,omitempty"`
+	// The matches between the players, most recently started first. Matches the
+	// current user is not allowed to see are left out of the list and the totals.
+	MatchUps []*MatchUp `json:"matchUps" bson:"matchUps"`
+}
+
+// One square of the grid a heatmap is counted on. The court is split into
+// eighths along both its width and its length, and the grid carries on past
+// the lines to cover positions outside the court.
+type HeatmapZone struct {
+	XMin float64 `json:"xMin" bson:"xMin"`
+	XMax float64 `json:"xMax" bson:"xMax"`
+	YMin float64 `json:"yMin" bson:"yMin"`
+	YMax float64 `json:"yMax" bson:"yMax"`
+	// Number of positions in the zone.
+	Count int `json:"count" bson:"count"`
+	// The zone's share (0-1) of the positions in the heatmap.
+	Share float64 `json:"share" bson:"share"`
+}
+
+// Rebuilds a match from an exported file. The file's points are replayed
+// through the scoring rules, so the imported match ends with the same score,
+// serving rotation and statistics as if it had been tracked live.
+type ImportMatchUpInput struct {
+	// The format the content is written in.
+	Format MatchUpExportFormat `json:"format" bson:"format"`
+	// The file content.
+	Content string `json:"content" bson:"content"`
+	// The match type, format and participants to replay the file into.
+	// Required for CHARTING and CSV files; a JSON file carries its own setup,
+	// which is used when this is omitted. Hitters in CSV and JSON files are
+	// matched to participants by ID, then by display name.
+	MatchUp *InitiateMatchUpInput `json:"matchUp,omitempty" bson:"matchUp,omitempty"`
 }
 
 // Used to create a new tennis match with the specified type, format, and participants.
 // If 'visibility' is not provided, it defaults to 'PRIVATE'.
+// Registered participants other than the owner are invited to the match, and
+// it can't start until they accept.
 type InitiateMatchUpInput struct {
 	// The type of match, e.g., SINGLES or DOUBLES.
 	MatchUpType MatchUpType `json:"matchUpType" bson:"matchUpType"`
 	// The format and rules for this match (sets, tiebreak details, etc.).
-	MatchUpFormat *MatchUpFormatInput `json:"matchUpFormat" bson:"matchUpFormat"`
+	// Exactly one of matchUpFormat and matchUpFormatPresetId must be given.
+	MatchUpFormat *MatchUpFormatInput `json:"matchUpFormat,omitempty" bson:"matchUpFormat,omitempty"`
+	// A saved or built-in format preset to play the match under, instead of a
+	// full matchUpFormat. The preset's format is copied onto the match.
+	MatchUpFormatPresetID *primitive.ObjectID `json:"matchUpFormatPresetId,omitempty" bson:"matchUpFormatPresetId,omitempty"`
 	// The players or teams participating in the match.
 	Participants []*ParticipantInput `json:"participants" bson:"participants"`
 	// A person who might not be a participant in a match but is helping to track it.
 	MatchUpTracker primitive.ObjectID `json:"matchUpTracker" bson:"matchUpTracker"`
 	// The participant (by ObjectID) who will serve first.
 	InitialServer primitive.ObjectID `json:"initialServer" bson:"initialServer"`
-	// The style of tracking make used to record match data.
+	// The style of tracking used to record match data. Decides which shot
+	// details are required and whether points can be recorded without shots.
 	TrackingStyle *MatchUpTrackingStyle `json:"trackingStyle,omitempty" bson:"trackingStyle,omitempty"`
+	// Who besides the owner, participants and trackers can see the match.
+	Visibility *MatchUpVisibility `json:"visibility,omitempty" bson:"visibility,omitempty"`
+	// When the match is due to start.
+	ScheduledStartTime *time.Time `json:"scheduledStartTime,omitempty" bson:"scheduledStartTime,omitempty"`
+	// The court from search-service the match will be played on.
+	CourtID *primitive.ObjectID `json:"courtId,omitempty" bson:"courtId,omitempty"`
 }
 
 // Provides structured geographical details about a user's location.
@@ -70,6 +379,56 @@ type Location struct {
 	Longitude *float64 `json:"longitude,omitempty" bson:"longitude,omitempty"`
 }
 
+// A match's win probability point by point, for reviewing how it swung.
+// Probabilities come from a Markov model of the match's format in which every
+// point is won by the server with a fixed probability: each side's share of
+// points won on serve over the whole match, pulled towards 60% while few points
+// have been played.
+type MatchMomentum struct {
+	MatchUpID primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
+	// Probability (0-1) that TEAM_A wins the match, before the first point.
+	InitialWinProbability float64 `json:"initialWinProbability" bson:"initialWinProbability"`
+	// The probability (0-1) the model gives TEAM_A of winning a point on its serve.
+	TeamAServePointWinRate float64 `json:"teamAServePointWinRate" bson:"teamAServePointWinRate"`
+	// The probability (0-1) the model gives TEAM_B of winning a point on its serve.
+	TeamBServePointWinRate float64 `json:"teamBServePointWinRate" bson:"teamBServePointWinRate"`
+	// One entry per completed point, in the order played.
+	Points []*MomentumPoint `json:"points" bson:"points"`
+}
+
+// The full state of a match right after one of its shots, for replaying it.
+// It is worked out again by scoring the match's shots from the first one.
+type MatchStateAt struct {
+	MatchUpID primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
+	ShotID    primitive.ObjectID `json:"shotId" bson:"shotId"`
+	// Position of the shot in the match, counting from 1.
+	ShotNumber int `json:"shotNumber" bson:"shotNumber"`
+	// Score after the shot.
+	Score *MatchUpScore `json:"score" bson:"score"`
+	// The player serving the next point.
+	CurrentServer primitive.ObjectID `json:"currentServer" bson:"currentServer"`
+	// The serving order in force after the shot.
+	ServingOrder []primitive.ObjectID `json:"servingOrder" bson:"servingOrder"`
+	// Service box the next point is served into.
+	CurrentServiceBoxSide ServiceBoxSide `json:"currentServiceBoxSide" bson:"currentServiceBoxSide"`
+	// The end of the court each side plays the next point from.
+	CourtSides []*TeamCourtSide `json:"courtSides" bson:"courtSides"`
+	// Where the shot was played within the match structure.
+	PointContext *PointContext `json:"pointContext" bson:"pointContext"`
+	// What is at stake on the point being played, or the next one if the shot
+	// ended a point.
+	PendingImportance PointImportance `json:"pendingImportance" bson:"pendingImportance"`
+	PointCompleted    bool            `json:"pointCompleted" bson:"pointCompleted"`
+	GameCompleted     bool            `json:"gameCompleted" bson:"gameCompleted"`
+	SetCompleted      bool            `json:"setCompleted" bson:"setCompleted"`
+	MatchCompleted    bool            `json:"matchCompleted" bson:"matchCompleted"`
+	// If the shot ended a point, which team won it.
+	PointWinner *TeamSide `json:"pointWinner,omitempty" bson:"pointWinner,omitempty"`
+	// True when the recomputed state agrees with the matchStateAfterShot stored
+	// for the shot. False means the stored shots need repairing.
+	MatchesStoredState bool `json:"matchesStoredState" bson:"matchesStoredState"`
+}
+
 // Snapshot of the match state after a shot was played.
 type MatchStateSnapshot struct {
 	// Current score state after this shot.
@@ -84,6 +443,12 @@ type MatchStateSnapshot struct {
 	MatchCompleted bool `json:"matchCompleted" bson:"matchCompleted"`
 	// If the point was completed, which team won it.
 	PointWinner *TeamSide `json:"pointWinner,omitempty" bson:"pointWinner,omitempty"`
+	// The player serving the next point after this shot.
+	// Used to restore the match state when shots are undone or redone.
+	CurrentServer primitive.ObjectID `json:"currentServer" bson:"currentServer"`
+	// The serving order in force after this shot.
+	// Used together with currentServer to restore the rotation on undo or redo.
+	ServingOrder []primitive.ObjectID `json:"servingOrder" bson:"servingOrder"`
 }
 
 // Aggregated statistics for an entire match.
@@ -97,30 +462,105 @@ type MatchStatistics struct {
 	// Total duration of the match in milliseconds.
 	// Requires startTime and endTime on MatchUp.
 	DurationMillis *int `json:"durationMillis,omitempty" bson:"durationMillis,omitempty"`
+	// How the match was tracked. Counts that need details a lower style doesn't
+	// record, such as aces or winners, are null when no point recorded them.
+	TrackingStyle MatchUpTrackingStyle `json:"trackingStyle" bson:"trackingStyle"`
 	// Statistics aggregated per team side.
 	TeamStats []*TeamStatistics `json:"teamStats" bson:"teamStats"`
 }
 
+// A completed point or game, for a replay scrubber. Pass shotId to
+// matchStateAt to jump to it.
+type MatchTimelineEntry struct {
+	Type MatchTimelineEntryType `json:"type" bson:"type"`
+	// The shot that completed the point or game.
+	ShotID primitive.ObjectID `json:"shotId" bson:"shotId"`
+	// Position of that shot in the match, counting from 1.
+	ShotNumber int `json:"shotNumber" bson:"shotNumber"`
+	SetNumber  int `json:"setNumber" bson:"setNumber"`
+	GameNumber int `json:"gameNumber" bson:"gameNumber"`
+	// The point's number within its game. For a game, how many points it took.
+	PointNumber int `json:"pointNumber" bson:"pointNumber"`
+	// The team that won the point or game.
+	Winner TeamSide `json:"winner" bson:"winner"`
+	// The team serving the point or game.
+	ServerSide TeamSide `json:"serverSide" bson:"serverSide"`
+	// What was at stake on the point, or the last point of the game.
+	Importance PointImportance `json:"importance" bson:"importance"`
+	// Score afterwards as plain text with TEAM_A first, e.g. "6-4 3-2 30-15".
+	Score string `json:"score" bson:"score"`
+}
+
 type MatchUp struct {
-	ID                 primitive.ObjectID  `json:"id" bson:"_id"`
-	Owner              primitive.ObjectID  `json:"owner" bson:"owner"`
-	MatchUpFormat      *MatchUpFormat      `json:"matchUpFormat" bson:"matchUpFormat"`
-	MatchUpTracker     primitive.ObjectID  `json:"matchUpTracker" bson:"matchUpTracker"`
-	MatchUpType        MatchUpType         `json:"matchUpType" bson:"matchUpType"`
-	MatchUpStatus      MatchUpStatus       `json:"matchUpStatus" bson:"matchUpStatus"`
-	Participants       []*Participant      `json:"participants" bson:"participants"`
-	InitialServer      primitive.ObjectID  `json:"initialServer" bson:"initialServer"`
-	CurrentServer      primitive.ObjectID  `json:"currentServer" bson:"currentServer"`
-	CurrentScore       *MatchUpScore       `json:"currentScore" bson:"currentScore"`
-	FirstShot          *primitive.ObjectID `json:"firstShot,omitempty" bson:"firstShot,omitempty"`
-	LastShot           *primitive.ObjectID `json:"lastShot,omitempty" bson:"lastShot,omitempty"`
-	Winner             *TeamSide           `json:"winner,omitempty" bson:"winner,omitempty"`
-	Loser              *TeamSide           `json:"loser,omitempty" bson:"loser,omitempty"`
-	ScheduledStartTime *time.Time          `json:"scheduledStartTime,omitempty" bson:"scheduledStartTime,omitempty"`
-	StartTime          *time.Time          `json:"startTime,omitempty" bson:"startTime,omitempty"`
-	EndTime            *time.Time          `json:"endTime,omitempty" bson:"endTime,omitempty"`
-	CreatedAt          time.Time           `json:"createdAt" bson:"createdAt"`
-	LastUpdated        time.Time           `json:"lastUpdated" bson:"lastUpdated"`
+	ID                    primitive.ObjectID     `json:"id" bson:"_id"`
+	Owner                 primitive.ObjectID     `json:"owner" bson:"owner"`
+	MatchUpFormat         *MatchUpFormat         `json:"matchUpFormat" bson:"matchUpFormat"`
+	MatchUpTracker        primitive.ObjectID     `json:"matchUpTracker" bson:"matchUpTracker"`
+	Trackers              []primitive.ObjectID   `json:"trackers" bson:"trackers"`
+	Visibility            MatchUpVisibility      `json:"visibility" bson:"visibility"`
+	MatchUpType           MatchUpType            `json:"matchUpType" bson:"matchUpType"`
+	MatchUpStatus         MatchUpStatus          `json:"matchUpStatus" bson:"matchUpStatus"`
+	TrackingStyle         MatchUpTrackingStyle   `json:"trackingStyle" bson:"trackingStyle"`
+	Participants          []*Participant         `json:"participants" bson:"participants"`
+	InitialServer         primitive.ObjectID     `json:"initialServer" bson:"initialServer"`
+	CurrentServer         primitive.ObjectID     `json:"currentServer" bson:"currentServer"`
+	ServingOrder          []primitive.ObjectID   `json:"servingOrder" bson:"servingOrder"`
+	CurrentServiceBoxSide ServiceBoxSide         `json:"currentServiceBoxSide" bson:"currentServiceBoxSide"`
+	CourtSides            []*TeamCourtSide       `json:"courtSides" bson:"courtSides"`
+	NextPointImportance   PointImportance        `json:"nextPointImportance" bson:"nextPointImportance"`
+	CurrentScore          *MatchUpScore          `json:"currentScore" bson:"currentScore"`
+	FirstShot             *primitive.ObjectID    `json:"firstShot,omitempty" bson:"firstShot,omitempty"`
+	LastShot              *primitive.ObjectID    `json:"lastShot,omitempty" bson:"lastShot,omitempty"`
+	Winner                *TeamSide              `json:"winner,omitempty" bson:"winner,omitempty"`
+	Loser                 *TeamSide              `json:"loser,omitempty" bson:"loser,omitempty"`
+	RetiringSide          *TeamSide              `json:"retiringSide,omitempty" bson:"retiringSide,omitempty"`
+	StatusHistory         []*MatchUpStatusChange `json:"statusHistory" bson:"statusHistory"`
+	ScheduledStartTime    *time.Time             `json:"scheduledStartTime,omitempty" bson:"scheduledStartTime,omitempty"`
+	Court                 *TennisCourt           `json:"court,omitempty" bson:"court,omitempty"`
+	Tournament            *MatchUpDrawSlot       `json:"tournament,omitempty" bson:"tournament,omitempty"`
+	StartTime             *time.Time             `json:"startTime,omitempty" bson:"startTime,omitempty"`
+	EndTime               *time.Time             `json:"endTime,omitempty" bson:"endTime,omitempty"`
+	Version               int                    `json:"version" bson:"version"`
+	CreatedAt             time.Time              `json:"createdAt" bson:"createdAt"`
+	LastUpdated           time.Time              `json:"lastUpdated" bson:"lastUpdated"`
+}
+
+// The draw slot a tournament match is played for.
+type MatchUpDrawSlot struct {
+	TournamentID primitive.ObjectID `json:"tournamentId" bson:"tournamentId"`
+	DrawID       primitive.ObjectID `json:"drawId" bson:"drawId"`
+	SlotID       string             `json:"slotId" bson:"slotId"`
+}
+
+// A match written out to a file format.
+type MatchUpExport struct {
+	// The format the content is written in.
+	Format MatchUpExportFormat `json:"format" bson:"format"`
+	// A suggested file name, e.g. "matchup-<id>.csv".
+	FileName string `json:"fileName" bson:"fileName"`
+	// The MIME type of the content.
+	ContentType string `json:"contentType" bson:"contentType"`
+	// The exported file content.
+	Content string `json:"content" bson:"content"`
+}
+
+// Narrows down the matches returned by myMatchUps. Every field is optional and
+// all provided fields must match.
+type MatchUpFilterInput struct {
+	// Only matches in one of these statuses.
+	Status []MatchUpStatus `json:"status,omitempty" bson:"status,omitempty"`
+	// Only matches this user also took part in, on either side.
+	ParticipantID *primitive.ObjectID `json:"participantId,omitempty" bson:"participantId,omitempty"`
+	// Only matches where this user played on the other side from the caller.
+	OpponentID *primitive.ObjectID `json:"opponentId,omitempty" bson:"opponentId,omitempty"`
+	// Only matches that started at or after this time.
+	StartTimeFrom *time.Time `json:"startTimeFrom,omitempty" bson:"startTimeFrom,omitempty"`
+	// Only matches that started at or before this time.
+	StartTimeTo *time.Time `json:"startTimeTo,omitempty" bson:"startTimeTo,omitempty"`
+	// Only singles or only doubles matches.
+	MatchUpType *MatchUpType `json:"matchUpType,omitempty" bson:"matchUpType,omitempty"`
+	// Only matches the caller won or lost.
+	Outcome *MatchUpOutcome `json:"outcome,omitempty" bson:"outcome,omitempty"`
 }
 
 // Overall "ruleset" of a tennis match:
@@ -149,6 +589,25 @@ type MatchUpFormatInput struct {
 	FinalSetFormat *SetFormatInput `json:"finalSetFormat,omitempty" bson:"finalSetFormat,omitempty"`
 }
 
+// A named, reusable MatchUpFormat. Built-in presets cover the common formats
+// and are available to everyone; other presets belong to the user who saved them.
+type MatchUpFormatPreset struct {
+	// Unique identifier, passed as matchUpFormatPresetId when starting a match.
+	ID primitive.ObjectID `json:"id" bson:"_id"`
+	// Short display name, e.g. "Fast4".
+	Name string `json:"name" bson:"name"`
+	// Optional longer explanation of the format.
+	Description *string `json:"description,omitempty" bson:"description,omitempty"`
+	// The user who saved the preset. Null for built-in presets.
+	Owner *primitive.ObjectID `json:"owner,omitempty" bson:"owner,omitempty"`
+	// True for the standard formats shipped with the service.
+	IsBuiltIn bool `json:"isBuiltIn" bson:"isBuiltIn"`
+	// The rules a match started from this preset is played under.
+	MatchUpFormat *MatchUpFormat `json:"matchUpFormat" bson:"matchUpFormat"`
+	// When the preset was saved.
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
+
 // The main container for a match's real-time or final scoring data.
 // - 'sets' contains an array of completed or in-progress sets.
 // - 'isMatchComplete' indicates whether the match is officially decided.
@@ -170,6 +629,8 @@ type MatchUpShot struct {
 	ID primitive.ObjectID `json:"id" bson:"_id"`
 	// Reference to the match this shot belongs to.
 	MatchUpID primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
+	// The client-generated idempotency key the shot was recorded with, if any.
+	ClientShotID *string `json:"clientShotId,omitempty" bson:"clientShotId,omitempty"`
 	// Reference to the previous shot in the sequence (null if first shot).
 	PrevShotID *primitive.ObjectID `json:"prevShotId,omitempty" bson:"prevShotId,omitempty"`
 	// Reference to the next shot in the sequence (null if most recent shot).
@@ -190,17 +651,29 @@ type MatchUpShot struct {
 	// Only applicable when shotType is SERVE.
 	ServeStyle *ServeStyle `json:"serveStyle,omitempty" bson:"serveStyle,omitempty"`
 	// Whether this is a first or second serve attempt.
-	// Only applicable when shotType is SERVE.
+	// Only applicable when shotType is SERVE, or MATCH_EVENT for a let, where it
+	// is the serve being replayed.
 	ServeNumber *ServeNumber `json:"serveNumber,omitempty" bson:"serveNumber,omitempty"`
 	// Which service box the serve was directed to.
 	// Only applicable when shotType is SERVE.
 	ServiceBoxSide *ServiceBoxSide `json:"serviceBoxSide,omitempty" bson:"serviceBoxSide,omitempty"`
-	// The outcome of this specific shot.
+	// The outcome of this specific shot. A match event that decides a point is
+	// an ERROR by the player it is charged to; a let is CONTINUED_RALLY.
 	ShotOutcome ShotOutcome `json:"shotOutcome" bson:"shotOutcome"`
-	// If this shot won the point, specifies how.
-	// Only applicable when shotOutcome is WON_POINT.
+	// If this shot ended the point, specifies how it was decided.
+	// Set for WON_POINT and ERROR outcomes, including double faults.
 	PointWinReason *PointWinReason `json:"pointWinReason,omitempty" bson:"pointWinReason,omitempty"`
-	// Special significance of this point, if any.
+	// What happened, when shotType is MATCH_EVENT.
+	MatchEvent *MatchEventType `json:"matchEvent,omitempty" bson:"matchEvent,omitempty"`
+	// The violation a penalty was given for, if recorded.
+	// Only applicable when shotType is MATCH_EVENT.
+	Violation *ViolationType `json:"violation,omitempty" bson:"violation,omitempty"`
+	// Where the ball bounced, if recorded.
+	BounceLocation *CourtPosition `json:"bounceLocation,omitempty" bson:"bounceLocation,omitempty"`
+	// Where the hitter stood when they hit the shot, if recorded.
+	HitterPosition *CourtPosition `json:"hitterPosition,omitempty" bson:"hitterPosition,omitempty"`
+	// Special significance of the point this shot was played in, worked out
+	// from the score before the point.
 	PointImportance PointImportance `json:"pointImportance" bson:"pointImportance"`
 	// Current point context within the match structure.
 	PointContext *PointContext `json:"pointContext" bson:"pointContext"`
@@ -210,9 +683,54 @@ type MatchUpShot struct {
 	Timestamp time.Time `json:"timestamp" bson:"timestamp"`
 }
 
+// An audit entry recording a single lifecycle transition of a match.
+type MatchUpStatusChange struct {
+	// The status before the change.
+	FromStatus MatchUpStatus `json:"fromStatus" bson:"fromStatus"`
+	// The status after the change.
+	ToStatus MatchUpStatus `json:"toStatus" bson:"toStatus"`
+	// The user who performed the change.
+	ChangedBy primitive.ObjectID `json:"changedBy" bson:"changedBy"`
+	// When the change happened.
+	ChangedAt time.Time `json:"changedAt" bson:"changedAt"`
+	// Optional reason given for the change.
+	Reason *string `json:"reason,omitempty" bson:"reason,omitempty"`
+}
+
+// The win/loss record for one match type.
+type MatchUpTypeRecord struct {
+	MatchUpType MatchUpType `json:"matchUpType" bson:"matchUpType"`
+	Played      int         `json:"played" bson:"played"`
+	Won         int         `json:"won" bson:"won"`
+	Lost        int         `json:"lost" bson:"lost"`
+}
+
+// The win probability after one point.
+type MomentumPoint struct {
+	// The shot that ended the point.
+	ShotID primitive.ObjectID `json:"shotId" bson:"shotId"`
+	// 1-based position of the point in the match.
+	PointNumber int      `json:"pointNumber" bson:"pointNumber"`
+	PointWinner TeamSide `json:"pointWinner" bson:"pointWinner"`
+	// Probability (0-1) that TEAM_A wins the match after this point.
+	WinProbability float64 `json:"winProbability" bson:"winProbability"`
+	// How much the point moved TEAM_A's win probability. Positive when it helped TEAM_A.
+	Swing float64 `json:"swing" bson:"swing"`
+	// True for the few points that moved the win probability the most.
+	IsBiggestSwing bool `json:"isBiggestSwing" bson:"isBiggestSwing"`
+}
+
 type Mutation struct {
 }
 
+// A match still to be finished in a tournament, for the order of play.
+type OrderOfPlayEntry struct {
+	DrawID   primitive.ObjectID `json:"drawId" bson:"drawId"`
+	DrawName string             `json:"drawName" bson:"drawName"`
+	Slot     *DrawSlot          `json:"slot" bson:"slot"`
+	MatchUp  *MatchUp           `json:"matchUp" bson:"matchUp"`
+}
+
 // Represents an individual participant in a tennis match (could be a singles player
 // or one of the doubles players). Each participant is associated with one 'team side'
 // (e.g., Team A or Team B).
@@ -225,6 +743,11 @@ type Participant struct {
 	TeamSide TeamSide `json:"teamSide" bson:"teamSide"`
 	// Optional boolen to store if a participant is a guest or not.
 	IsGuest bool `json:"isGuest" bson:"isGuest"`
+	// Whether a registered participant agreed to play. Null for guests and for
+	// the match owner, who are never invited.
+	InvitationStatus *InvitationStatus `json:"invitationStatus,omitempty" bson:"invitationStatus,omitempty"`
+	// When the participant accepted or declined the invitation.
+	InvitationRespondedAt *time.Time `json:"invitationRespondedAt,omitempty" bson:"invitationRespondedAt,omitempty"`
 }
 
 // Represents the information needed to create or link a participant
@@ -246,19 +769,38 @@ type ParticipantInput struct {
 type PlayerStatistics struct {
 	// The ID of the player these stats belong to.
 	PlayerID primitive.ObjectID `json:"playerId" bson:"playerId"`
-	// Total points won where this player was the hitter of the winning shot or opponent errored.
-	// Note: This might require careful definition based on PointWinReason.
+	// Team side this player played on.
+	TeamSide TeamSide `json:"teamSide" bson:"teamSide"`
+	// Total points won where this player hit the winning shot, or the opponent
+	// errored on the shot straight after this player's shot. Double faults are
+	// not credited to an individual receiver.
 	PointsWon int `json:"pointsWon" bson:"pointsWon"`
 	// Number of aces served by this player.
-	Aces int `json:"aces" bson:"aces"`
+	// Null if points were played but none recorded how they ended.
+	Aces *int `json:"aces,omitempty" bson:"aces,omitempty"`
 	// Number of double faults committed by this player.
-	DoubleFaults int `json:"doubleFaults" bson:"doubleFaults"`
+	// Null if points were played but none recorded how they ended.
+	DoubleFaults *int `json:"doubleFaults,omitempty" bson:"doubleFaults,omitempty"`
 	// Number of winning shots hit by this player (excluding aces).
-	Winners int `json:"winners" bson:"winners"`
+	// Null if points were played but none recorded how they ended.
+	Winners *int `json:"winners,omitempty" bson:"winners,omitempty"`
 	// Number of unforced errors committed by this player.
-	UnforcedErrors int `json:"unforcedErrors" bson:"unforcedErrors"`
+	// Null if points were played but none recorded how they ended.
+	UnforcedErrors *int `json:"unforcedErrors,omitempty" bson:"unforcedErrors,omitempty"`
 	// Number of forced errors induced by this player's shots against the opponent.
-	ForcedErrorsInduced int `json:"forcedErrorsInduced" bson:"forcedErrorsInduced"`
+	// Null if points were played but none recorded how they ended.
+	ForcedErrorsInduced *int `json:"forcedErrorsInduced,omitempty" bson:"forcedErrorsInduced,omitempty"`
+	// Break points this player faced while serving.
+	BreakPointsFaced int `json:"breakPointsFaced" bson:"breakPointsFaced"`
+	// Break points faced while serving that this player's team went on to win.
+	BreakPointsSaved int `json:"breakPointsSaved" bson:"breakPointsSaved"`
+	// Percentage (0-100) of this player's service points where the first serve
+	// went in, counting only points recorded shot by shot.
+	// Null if this player has not served yet.
+	FirstServePercentage *float64 `json:"firstServePercentage,omitempty" bson:"firstServePercentage,omitempty"`
+	// Percentage (0-100) of this player's second serves that went in.
+	// Null if this player has not hit a second serve yet.
+	SecondServePercentage *float64 `json:"secondServePercentage,omitempty" bson:"secondServePercentage,omitempty"`
 }
 
 // Captures the context of a point within a match structure.
@@ -275,11 +817,56 @@ type PointContext struct {
 	ServerSide TeamSide `json:"serverSide" bson:"serverSide"`
 	// Which service box is being served to.
 	ServiceBoxSide ServiceBoxSide `json:"serviceBoxSide" bson:"serviceBoxSide"`
+	// The end of the court the server is serving from.
+	ServerCourtSide PhysicalCourtSide `json:"serverCourtSide" bson:"serverCourtSide"`
 }
 
 type Query struct {
 }
 
+// One change to a player's rating, made when a match they played was decided.
+// Ratings start at 1500 and move by how surprising the result was given both
+// sides' ratings, and by how many more games the winners took.
+type RatingChange struct {
+	ID primitive.ObjectID `json:"id" bson:"_id"`
+	// The rated player.
+	UserID primitive.ObjectID `json:"userId" bson:"userId"`
+	// The completed or retired match that changed the rating.
+	MatchUpID   primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
+	MatchUpType MatchUpType        `json:"matchUpType" bson:"matchUpType"`
+	// Whether the player's side won the match.
+	Won          bool    `json:"won" bson:"won"`
+	RatingBefore float64 `json:"ratingBefore" bson:"ratingBefore"`
+	RatingAfter  float64 `json:"ratingAfter" bson:"ratingAfter"`
+	// ratingAfter minus ratingBefore.
+	Change float64 `json:"change" bson:"change"`
+	// The average rating of the rated opponents the match was played against.
+	OpponentRating float64 `json:"opponentRating" bson:"opponentRating"`
+	// Rated matches the player has played, this one included. Ratings move
+	// faster over the first few matches.
+	MatchesRated int `json:"matchesRated" bson:"matchesRated"`
+	// When the match was decided.
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// Sets when and where a match that has not started will be played. Fields left
+// out keep their current value, and fields set to null are cleared.
+type ScheduleMatchUpInput struct {
+	// The match to schedule.
+	MatchUpID primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
+	// When the match is due to start. Set to null to clear it.
+	ScheduledStartTime graphql.Omittable[*time.Time] `json:"scheduledStartTime,omitempty" bson:"scheduledStartTime,omitempty"`
+	// The court from search-service the match will be played on. Set to null to clear it.
+	CourtID graphql.Omittable[*primitive.ObjectID] `json:"courtId,omitempty" bson:"courtId,omitempty"`
+}
+
+// Invite a user to claim a guest participant of one of your matches.
+type SendGuestClaimInput struct {
+	MatchUpID primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
+	GuestID   primitive.ObjectID `json:"guestId" bson:"guestId"`
+	Claimant  primitive.ObjectID `json:"claimant" bson:"claimant"`
+}
+
 // Describes a single set's structure:
 // - Number of games (NumberOfGames)
 // - Deuce rules
@@ -298,7 +885,7 @@ type SetFormat struct {
 // Input representation of SetFormat,
 // mirroring the SetFormat type.
 type SetFormatInput struct {
-	// Number of games required to win a set (allowed values: 1, 3, 4, 5, 6, 10).
+	// Number of games required to win a set (allowed values: 1, 3, 4, 5, 6, 8, 10).
 	NumberOfGames scalars.NumberOfGames `json:"numberOfGames" bson:"numberOfGames"`
 	// Deuce rule type (e.g., ADV or NO_AD).
 	// This remains required because you must specify some deuce rule.
@@ -325,6 +912,49 @@ type SetScore struct {
 	// True if a tiebreak is currently underway in this set.
 	// False if no tiebreak is needed or it's already completed.
 	IsTiebreakActive bool `json:"isTiebreakActive" bson:"isTiebreakActive"`
+	// How many times the current game has reached deuce (40–40).
+	// Used to apply the ONE_DEUCE rule and reset to 0 when the game ends.
+	DeuceCount int `json:"deuceCount" bson:"deuceCount"`
+}
+
+// Where the shots of one player, of one type and with one outcome went, and
+// where they were hit from. Serves are further split by the service box they
+// were directed to. Only shots recorded with a court position are counted.
+type ShotHeatmap struct {
+	PlayerID primitive.ObjectID `json:"playerId" bson:"playerId"`
+	ShotType ShotType           `json:"shotType" bson:"shotType"`
+	// The service box the serves were directed to. Null for other shot types.
+	ServiceBoxSide *ServiceBoxSide `json:"serviceBoxSide,omitempty" bson:"serviceBoxSide,omitempty"`
+	ShotOutcome    ShotOutcome     `json:"shotOutcome" bson:"shotOutcome"`
+	// Number of shots counted, with a bounce location, a hitter position or both.
+	Shots int `json:"shots" bson:"shots"`
+	// Zones the ball bounced in. Only zones with at least one shot are listed.
+	BounceZones []*HeatmapZone `json:"bounceZones" bson:"bounceZones"`
+	// Zones the shots were hit from. Only zones with at least one shot are listed.
+	HitterZones []*HeatmapZone `json:"hitterZones" bson:"hitterZones"`
+}
+
+// Narrows the heatmaps of a match. Every field is optional and fields combine with AND.
+type ShotHeatmapFilterInput struct {
+	// Only heatmaps of shots hit by this player.
+	PlayerID *primitive.ObjectID `json:"playerId,omitempty" bson:"playerId,omitempty"`
+	// Only heatmaps of this type of shot.
+	ShotType *ShotType `json:"shotType,omitempty" bson:"shotType,omitempty"`
+	// Only heatmaps of serves directed to this service box.
+	ServiceBoxSide *ServiceBoxSide `json:"serviceBoxSide,omitempty" bson:"serviceBoxSide,omitempty"`
+	// Only heatmaps of shots with this outcome.
+	ShotOutcome *ShotOutcome `json:"shotOutcome,omitempty" bson:"shotOutcome,omitempty"`
+}
+
+// The outcome of uploading a batch of offline shots.
+type ShotSyncResult struct {
+	// The match after the batch was applied.
+	MatchUp *MatchUp `json:"matchUp" bson:"matchUp"`
+	// The recorded shot for each shot in the batch, in the same order,
+	// including shots that had already been uploaded.
+	Shots []*MatchUpShot `json:"shots" bson:"shots"`
+	// How many shots in the batch had already been uploaded and were skipped.
+	Skipped int `json:"skipped" bson:"skipped"`
 }
 
 // Holds the game-level data for each side in a single set.
@@ -346,6 +976,12 @@ type SideSetScore struct {
 	TiebreakPoints *int `json:"tiebreakPoints,omitempty" bson:"tiebreakPoints,omitempty"`
 }
 
+// The end of the court a team side is currently playing from.
+type TeamCourtSide struct {
+	TeamSide  TeamSide          `json:"teamSide" bson:"teamSide"`
+	CourtSide PhysicalCourtSide `json:"courtSide" bson:"courtSide"`
+}
+
 // Aggregated statistics for a specific team (TEAM_A or TEAM_B) within a match.
 type TeamStatistics struct {
 	// The team side these stats belong to.
@@ -357,24 +993,52 @@ type TeamStatistics struct {
 	// Total sets won by this team.
 	SetsWon int `json:"setsWon" bson:"setsWon"`
 	// Number of aces served by this team.
-	Aces int `json:"aces" bson:"aces"`
+	// Null if points were played but none recorded how they ended.
+	Aces *int `json:"aces,omitempty" bson:"aces,omitempty"`
 	// Number of double faults committed by this team.
-	DoubleFaults int `json:"doubleFaults" bson:"doubleFaults"`
+	// Null if points were played but none recorded how they ended.
+	DoubleFaults *int `json:"doubleFaults,omitempty" bson:"doubleFaults,omitempty"`
 	// Number of winning shots hit by this team (excluding aces).
-	Winners int `json:"winners" bson:"winners"`
+	// Null if points were played but none recorded how they ended.
+	Winners *int `json:"winners,omitempty" bson:"winners,omitempty"`
 	// Number of unforced errors committed by this team.
-	UnforcedErrors int `json:"unforcedErrors" bson:"unforcedErrors"`
+	// Null if points were played but none recorded how they ended.
+	UnforcedErrors *int `json:"unforcedErrors,omitempty" bson:"unforcedErrors,omitempty"`
 	// Number of forced errors induced by this team's shots against the opponent.
-	ForcedErrorsInduced int `json:"forcedErrorsInduced" bson:"forcedErrorsInduced"`
+	// Null if points were played but none recorded how they ended.
+	ForcedErrorsInduced *int `json:"forcedErrorsInduced,omitempty" bson:"forcedErrorsInduced,omitempty"`
+	// Break points this team faced on its own serve.
+	BreakPointsFaced int `json:"breakPointsFaced" bson:"breakPointsFaced"`
+	// Break points faced on serve that this team went on to win.
+	BreakPointsSaved int `json:"breakPointsSaved" bson:"breakPointsSaved"`
+	// Break points this team earned on the opponent's serve.
+	BreakPointOpportunities int `json:"breakPointOpportunities" bson:"breakPointOpportunities"`
+	// Break point opportunities this team won, breaking the opponent's serve.
+	BreakPointsConverted int `json:"breakPointsConverted" bson:"breakPointsConverted"`
+	// Percentage (0-100) of service points where the first serve went in,
+	// counting only points recorded shot by shot.
+	// Null if this team has not served yet.
+	FirstServePercentage *float64 `json:"firstServePercentage,omitempty" bson:"firstServePercentage,omitempty"`
+	// Percentage (0-100) of second serves that went in.
+	// Null if this team has not hit a second serve yet.
+	SecondServePercentage *float64 `json:"secondServePercentage,omitempty" bson:"secondServePercentage,omitempty"`
+}
+
+// A tennis court from search-service, referenced by its ID. Its name, address
+// and the rest are resolved by search-service.
+type TennisCourt struct {
+	ID primitive.ObjectID `json:"id" bson:"_id"`
 }
 
+func (TennisCourt) IsEntity() {}
+
 // Defines how a tiebreak is played:
 // - Points needed (TiebreakPoints)
 // - Whether a two-point lead is required
 type TiebreakFormat struct {
 	// How many points are needed to win the tiebreak
 	// (allowed values: 5, 6, 7, 8, 9, 10).
-	Points string `json:"points" bson:"points"`
+	Points scalars.TiebreakPoints `json:"points" bson:"points"`
 	// If true, a two-point lead is required to win the tiebreak.
 	MustWinByTwo bool `json:"mustWinByTwo" bson:"mustWinByTwo"`
 	// The set score at which a tiebreak starts (commonly 6).
@@ -385,13 +1049,80 @@ type TiebreakFormat struct {
 // mirroring the TiebreakFormat type.
 type TiebreakFormatInput struct {
 	// Points needed to win the tiebreak (5, 6, 7, 8, 9, or 10).
-	Points string `json:"points" bson:"points"`
+	Points scalars.TiebreakPoints `json:"points" bson:"points"`
 	// If true, a 2-point lead is required to win the tiebreak.
 	MustWinByTwo bool `json:"mustWinByTwo" bson:"mustWinByTwo"`
 	// Optional "trigger" at which a tiebreak starts (commonly 6).
 	TiebreakAt int `json:"tiebreakAt" bson:"tiebreakAt"`
 }
 
+// An event run by a club or organiser. Its entries are placed into one or more
+// draws, and every draw slot is played as a MatchUp under the event's format.
+type Tournament struct {
+	ID primitive.ObjectID `json:"id" bson:"_id"`
+	// The organiser, who owns every match the tournament creates.
+	Owner       primitive.ObjectID `json:"owner" bson:"owner"`
+	Name        string             `json:"name" bson:"name"`
+	MatchUpType MatchUpType        `json:"matchUpType" bson:"matchUpType"`
+	// The rules every match in the tournament is played under.
+	MatchUpFormat *MatchUpFormat       `json:"matchUpFormat" bson:"matchUpFormat"`
+	TrackingStyle MatchUpTrackingStyle `json:"trackingStyle" bson:"trackingStyle"`
+	// Who besides their players and trackers can see the tournament's matches.
+	Visibility  MatchUpVisibility  `json:"visibility" bson:"visibility"`
+	Entries     []*TournamentEntry `json:"entries" bson:"entries"`
+	Draws       []*Draw            `json:"draws" bson:"draws"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+	LastUpdated time.Time          `json:"lastUpdated" bson:"lastUpdated"`
+}
+
+// A player, or doubles team, entered in a tournament.
+type TournamentEntry struct {
+	ID      primitive.ObjectID  `json:"id" bson:"_id"`
+	Players []*TournamentPlayer `json:"players" bson:"players"`
+	// Seeded entries are kept apart in knockout draws, 1 being the favourite.
+	Seed *int `json:"seed,omitempty" bson:"seed,omitempty"`
+}
+
+// A registered user playing for a tournament entry.
+type TournamentPlayer struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	DisplayName string             `json:"displayName" bson:"displayName"`
+}
+
+type TournamentPlayerInput struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	DisplayedName string             `json:"displayedName" bson:"displayedName"`
+}
+
+// Moves a match to a new lifecycle status.
+// Only legal transitions are accepted, e.g. SCHEDULED to IN_PROGRESS or
+// IN_PROGRESS to SUSPENDED.
+type UpdateMatchUpStatusInput struct {
+	// The match to update.
+	MatchUpID primitive.ObjectID `json:"matchUpId" bson:"matchUpId"`
+	// The status to move the match to.
+	Status MatchUpStatus `json:"status" bson:"status"`
+	// The side that retired. Required when status is RETIRED, and not allowed otherwise.
+	RetiringSide *TeamSide `json:"retiringSide,omitempty" bson:"retiringSide,omitempty"`
+	// Optional free-text reason recorded with the change (e.g. "rain delay").
+	Reason *string `json:"reason,omitempty" bson:"reason,omitempty"`
+	// Start the match even though some participants have not accepted their
+	// invitations. Only the match owner can override.
+	OverrideInvitations *bool `json:"overrideInvitations,omitempty" bson:"overrideInvitations,omitempty"`
+}
+
+type User struct {
+	ID primitive.ObjectID `json:"id" bson:"_id"`
+	// The player's current rating, or null until they have played a rated match.
+	Rating *int `json:"rating,omitempty" bson:"rating,omitempty"`
+	// The player's statistics over the decided matches the viewer can see.
+	CareerStats *CareerStatistics `json:"careerStats" bson:"careerStats"`
+	// The player's rating changes, newest first, from the matches the viewer can see.
+	RatingHistory []*RatingChange `json:"ratingHistory" bson:"ratingHistory"`
+}
+
+func (User) IsEntity() {}
+
 // Specifies the deuce rule, i.e., how a game proceeds once it reaches a 40-40 score.
 type DeuceType string
 
@@ -442,6 +1173,172 @@ func (e DeuceType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// Where a draw slot stands.
+type DrawSlotStatus string
+
+const (
+	// Waiting for one or both entries to come through from earlier slots.
+	DrawSlotStatusWaiting DrawSlotStatus = "WAITING"
+	// Both entries are known and the slot's match has been created.
+	DrawSlotStatusScheduled DrawSlotStatus = "SCHEDULED"
+	// Decided by the slot's match.
+	DrawSlotStatusCompleted DrawSlotStatus = "COMPLETED"
+	// Decided without a match: the other side is a bye, or the organiser awarded
+	// the slot after its match was cancelled or abandoned.
+	DrawSlotStatusWalkover DrawSlotStatus = "WALKOVER"
+	// Both sides are byes, so nobody comes through the slot.
+	DrawSlotStatusEmpty DrawSlotStatus = "EMPTY"
+)
+
+var AllDrawSlotStatus = []DrawSlotStatus{
+	DrawSlotStatusWaiting,
+	DrawSlotStatusScheduled,
+	DrawSlotStatusCompleted,
+	DrawSlotStatusWalkover,
+	DrawSlotStatusEmpty,
+}
+
+func (e DrawSlotStatus) IsValid() bool {
+	switch e {
+	case DrawSlotStatusWaiting, DrawSlotStatusScheduled, DrawSlotStatusCompleted, DrawSlotStatusWalkover, DrawSlotStatusEmpty:
+		return true
+	}
+	return false
+}
+
+func (e DrawSlotStatus) String() string {
+	return string(e)
+}
+
+func (e *DrawSlotStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DrawSlotStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DrawSlotStatus", str)
+	}
+	return nil
+}
+
+func (e DrawSlotStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// A bracket within a draw. Single elimination and round robin draws only have
+// MAIN.
+type DrawStructure string
+
+const (
+	DrawStructureMain        DrawStructure = "MAIN"
+	DrawStructureConsolation DrawStructure = "CONSOLATION"
+	DrawStructureEast        DrawStructure = "EAST"
+	DrawStructureWest        DrawStructure = "WEST"
+	DrawStructureNorth       DrawStructure = "NORTH"
+	DrawStructureSouth       DrawStructure = "SOUTH"
+	DrawStructureNorthEast   DrawStructure = "NORTH_EAST"
+	DrawStructureNorthWest   DrawStructure = "NORTH_WEST"
+	DrawStructureSouthEast   DrawStructure = "SOUTH_EAST"
+	DrawStructureSouthWest   DrawStructure = "SOUTH_WEST"
+)
+
+var AllDrawStructure = []DrawStructure{
+	DrawStructureMain,
+	DrawStructureConsolation,
+	DrawStructureEast,
+	DrawStructureWest,
+	DrawStructureNorth,
+	DrawStructureSouth,
+	DrawStructureNorthEast,
+	DrawStructureNorthWest,
+	DrawStructureSouthEast,
+	DrawStructureSouthWest,
+}
+
+func (e DrawStructure) IsValid() bool {
+	switch e {
+	case DrawStructureMain, DrawStructureConsolation, DrawStructureEast, DrawStructureWest, DrawStructureNorth, DrawStructureSouth, DrawStructureNorthEast, DrawStructureNorthWest, DrawStructureSouthEast, DrawStructureSouthWest:
+		return true
+	}
+	return false
+}
+
+func (e DrawStructure) String() string {
+	return string(e)
+}
+
+func (e *DrawStructure) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DrawStructure(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DrawStructure", str)
+	}
+	return nil
+}
+
+func (e DrawStructure) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// How a tournament draw is played out.
+type DrawType string
+
+const (
+	// Knockout bracket. Seeds are kept apart and byes go to the top seeds when
+	// the entries don't fill the bracket.
+	DrawTypeSingleElimination DrawType = "SINGLE_ELIMINATION"
+	// Every entry plays every other entry once, ranked by standings.
+	DrawTypeRoundRobin DrawType = "ROUND_ROBIN"
+	// Knockout EAST bracket whose losers play on in further brackets named after
+	// the points of the compass, so everyone plays several matches.
+	DrawTypeCompass DrawType = "COMPASS"
+	// Knockout MAIN bracket with a CONSOLATION bracket that first-round losers
+	// start and losers of later rounds, up to the semifinals, feed into.
+	DrawTypeFeedInConsolation DrawType = "FEED_IN_CONSOLATION"
+)
+
+var AllDrawType = []DrawType{
+	DrawTypeSingleElimination,
+	DrawTypeRoundRobin,
+	DrawTypeCompass,
+	DrawTypeFeedInConsolation,
+}
+
+func (e DrawType) IsValid() bool {
+	switch e {
+	case DrawTypeSingleElimination, DrawTypeRoundRobin, DrawTypeCompass, DrawTypeFeedInConsolation:
+		return true
+	}
+	return false
+}
+
+func (e DrawType) String() string {
+	return string(e)
+}
+
+func (e *DrawType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DrawType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DrawType", str)
+	}
+	return nil
+}
+
+func (e DrawType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // Describes the style of a ground stroke (forehand or backhand),
 // e.g., slice, topspin, flat, etc.
 type GroundStrokeStyle string
@@ -547,6 +1444,56 @@ func (e GroundStrokeType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// Where a guest claim invitation stands.
+type GuestClaimStatus string
+
+const (
+	// Sent and waiting for the invited user to answer.
+	GuestClaimStatusPending GuestClaimStatus = "PENDING"
+	// Accepted. The guest's matches and shots now belong to the invited user.
+	GuestClaimStatusAccepted GuestClaimStatus = "ACCEPTED"
+	// Turned down by the invited user.
+	GuestClaimStatusDeclined GuestClaimStatus = "DECLINED"
+	// Withdrawn by the match owner before it was answered.
+	GuestClaimStatusCancelled GuestClaimStatus = "CANCELLED"
+)
+
+var AllGuestClaimStatus = []GuestClaimStatus{
+	GuestClaimStatusPending,
+	GuestClaimStatusAccepted,
+	GuestClaimStatusDeclined,
+	GuestClaimStatusCancelled,
+}
+
+func (e GuestClaimStatus) IsValid() bool {
+	switch e {
+	case GuestClaimStatusPending, GuestClaimStatusAccepted, GuestClaimStatusDeclined, GuestClaimStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (e GuestClaimStatus) String() string {
+	return string(e)
+}
+
+func (e *GuestClaimStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GuestClaimStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GuestClaimStatus", str)
+	}
+	return nil
+}
+
+func (e GuestClaimStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // Represents a single side's in-game scoring state in traditional tennis.
 type InGameScore string
 
@@ -600,7 +1547,249 @@ func (e InGameScore) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// Where a registered participant's invitation to a match stands.
+type InvitationStatus string
+
+const (
+	// Sent and waiting for the participant to answer.
+	InvitationStatusPending InvitationStatus = "PENDING"
+	// The participant agreed to play.
+	InvitationStatusAccepted InvitationStatus = "ACCEPTED"
+	// The participant turned the match down.
+	InvitationStatusDeclined InvitationStatus = "DECLINED"
+)
+
+var AllInvitationStatus = []InvitationStatus{
+	InvitationStatusPending,
+	InvitationStatusAccepted,
+	InvitationStatusDeclined,
+}
+
+func (e InvitationStatus) IsValid() bool {
+	switch e {
+	case InvitationStatusPending, InvitationStatusAccepted, InvitationStatusDeclined:
+		return true
+	}
+	return false
+}
+
+func (e InvitationStatus) String() string {
+	return string(e)
+}
+
+func (e *InvitationStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InvitationStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InvitationStatus", str)
+	}
+	return nil
+}
+
+func (e InvitationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Something that happens in a match other than a shot, recorded with
+// addMatchEvent. Events go in the shot list and are scored, undone and redone
+// like shots.
+type MatchEventType string
+
+const (
+	// A serve touched the net cord and landed in, or was called a let for
+	// another reason. The serve is replayed: a let on a first serve gives the
+	// server two serves again, a let on a second serve gives one.
+	MatchEventTypeLet MatchEventType = "LET"
+	// The umpire penalises a player a point, awarding it to the opponent.
+	MatchEventTypePointPenalty MatchEventType = "POINT_PENALTY"
+	// The umpire penalises a player a game, awarding the game being played, or
+	// the tiebreak, to the opponent.
+	MatchEventTypeGamePenalty MatchEventType = "GAME_PENALTY"
+	// The player is defaulted and the opponent is awarded the match. The score
+	// stays as it was when the default was given.
+	MatchEventTypeDefault MatchEventType = "DEFAULT"
+)
+
+var AllMatchEventType = []MatchEventType{
+	MatchEventTypeLet,
+	MatchEventTypePointPenalty,
+	MatchEventTypeGamePenalty,
+	MatchEventTypeDefault,
+}
+
+func (e MatchEventType) IsValid() bool {
+	switch e {
+	case MatchEventTypeLet, MatchEventTypePointPenalty, MatchEventTypeGamePenalty, MatchEventTypeDefault:
+		return true
+	}
+	return false
+}
+
+func (e MatchEventType) String() string {
+	return string(e)
+}
+
+func (e *MatchEventType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MatchEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MatchEventType", str)
+	}
+	return nil
+}
+
+func (e MatchEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// What a match timeline entry marks the end of.
+type MatchTimelineEntryType string
+
+const (
+	MatchTimelineEntryTypePoint MatchTimelineEntryType = "POINT"
+	MatchTimelineEntryTypeGame  MatchTimelineEntryType = "GAME"
+)
+
+var AllMatchTimelineEntryType = []MatchTimelineEntryType{
+	MatchTimelineEntryTypePoint,
+	MatchTimelineEntryTypeGame,
+}
+
+func (e MatchTimelineEntryType) IsValid() bool {
+	switch e {
+	case MatchTimelineEntryTypePoint, MatchTimelineEntryTypeGame:
+		return true
+	}
+	return false
+}
+
+func (e MatchTimelineEntryType) String() string {
+	return string(e)
+}
+
+func (e *MatchTimelineEntryType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MatchTimelineEntryType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MatchTimelineEntryType", str)
+	}
+	return nil
+}
+
+func (e MatchTimelineEntryType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// File formats a match can be exported to and imported from.
+type MatchUpExportFormat string
+
+const (
+	// Point-by-point charting notation in the style of the Match Charting Project:
+	// one CSV row per point with the first and second serve written as shot codes
+	// (e.g. "0fbf*"). Only whole points are included and hitters are implied by
+	// the rally, so it can be imported for singles matches only.
+	MatchUpExportFormatCharting MatchUpExportFormat = "CHARTING"
+	// One CSV row per shot with every recorded shot detail and the score after it.
+	MatchUpExportFormatCSV MatchUpExportFormat = "CSV"
+	// A JSON document with the match setup and every shot. It can be imported
+	// without any extra match details.
+	MatchUpExportFormatJSON MatchUpExportFormat = "JSON"
+)
+
+var AllMatchUpExportFormat = []MatchUpExportFormat{
+	MatchUpExportFormatCharting,
+	MatchUpExportFormatCSV,
+	MatchUpExportFormatJSON,
+}
+
+func (e MatchUpExportFormat) IsValid() bool {
+	switch e {
+	case MatchUpExportFormatCharting, MatchUpExportFormatCSV, MatchUpExportFormatJSON:
+		return true
+	}
+	return false
+}
+
+func (e MatchUpExportFormat) String() string {
+	return string(e)
+}
+
+func (e *MatchUpExportFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MatchUpExportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MatchUpExportFormat", str)
+	}
+	return nil
+}
+
+func (e MatchUpExportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// The result of a finished match from the point of view of one participant.
+type MatchUpOutcome string
+
+const (
+	// The participant's side won the match.
+	MatchUpOutcomeWon MatchUpOutcome = "WON"
+	// The participant's side lost the match.
+	MatchUpOutcomeLost MatchUpOutcome = "LOST"
+)
+
+var AllMatchUpOutcome = []MatchUpOutcome{
+	MatchUpOutcomeWon,
+	MatchUpOutcomeLost,
+}
+
+func (e MatchUpOutcome) IsValid() bool {
+	switch e {
+	case MatchUpOutcomeWon, MatchUpOutcomeLost:
+		return true
+	}
+	return false
+}
+
+func (e MatchUpOutcome) String() string {
+	return string(e)
+}
+
+func (e *MatchUpOutcome) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MatchUpOutcome(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MatchUpOutcome", str)
+	}
+	return nil
+}
+
+func (e MatchUpOutcome) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // Represents the different stages or outcomes a tennis match can go through.
+// Shots can only be recorded while a match is IN_PROGRESS. COMPLETED, CANCELLED,
+// ABANDONED and RETIRED are final.
 type MatchUpStatus string
 
 const (
@@ -669,11 +1858,14 @@ func (e MatchUpStatus) MarshalGQL(w io.Writer) {
 type MatchUpTrackingStyle string
 
 const (
-	// Basic minimal tracking.
+	// Basic minimal tracking. Points can be recorded on their own with addPoint,
+	// and shots only need their type and outcome.
 	MatchUpTrackingStyleBeginner MatchUpTrackingStyle = "BEGINNER"
-	// Moderately detailed tracking.
+	// Moderately detailed tracking. Every point is recorded shot by shot, ground
+	// strokes need their groundStrokeType and rally errors their pointWinReason.
 	MatchUpTrackingStyleIntermediate MatchUpTrackingStyle = "INTERMEDIATE"
-	// Most detailed tracking, for power users or deep analysis.
+	// Most detailed tracking, for power users or deep analysis. Adds serveStyle
+	// on serves and groundStrokeStyle on ground strokes to INTERMEDIATE.
 	MatchUpTrackingStyleAdvanced MatchUpTrackingStyle = "ADVANCED"
 )
 
@@ -756,6 +1948,63 @@ func (e MatchUpType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// Who besides the owner, participants and trackers can see a match and its
+// shots. The values are the shared access levels.
+type MatchUpVisibility string
+
+const (
+	// Anyone.
+	MatchUpVisibilityPublic MatchUpVisibility = "PUBLIC"
+	// The owner's friends.
+	MatchUpVisibilityFriends MatchUpVisibility = "FRIENDS"
+	// The owner's coaches.
+	MatchUpVisibilityCoaches MatchUpVisibility = "COACHES"
+	// The owner's friends and coaches.
+	MatchUpVisibilityFriendsAndCoaches MatchUpVisibility = "FRIENDS_AND_COACHES"
+	// Players who have played a match with the owner.
+	MatchUpVisibilityMatchParticipants MatchUpVisibility = "MATCH_PARTICIPANTS"
+	// Nobody else, for example for practice sets.
+	MatchUpVisibilityPrivate MatchUpVisibility = "PRIVATE"
+)
+
+var AllMatchUpVisibility = []MatchUpVisibility{
+	MatchUpVisibilityPublic,
+	MatchUpVisibilityFriends,
+	MatchUpVisibilityCoaches,
+	MatchUpVisibilityFriendsAndCoaches,
+	MatchUpVisibilityMatchParticipants,
+	MatchUpVisibilityPrivate,
+}
+
+func (e MatchUpVisibility) IsValid() bool {
+	switch e {
+	case MatchUpVisibilityPublic, MatchUpVisibilityFriends, MatchUpVisibilityCoaches, MatchUpVisibilityFriendsAndCoaches, MatchUpVisibilityMatchParticipants, MatchUpVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+func (e MatchUpVisibility) String() string {
+	return string(e)
+}
+
+func (e *MatchUpVisibility) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MatchUpVisibility(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MatchUpVisibility", str)
+	}
+	return nil
+}
+
+func (e MatchUpVisibility) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // Refers to the physical orientation of the tennis court itself, which could matter
 // for sun, wind, or camera placement. For example, a stadium court may label one
 // end 'North' and the other end 'South.' Auto assign A as north and B as south
@@ -871,6 +2120,8 @@ const (
 	PointWinReasonUnforcedError PointWinReason = "UNFORCED_ERROR"
 	// The server failed to execute a valid serve on both the first and second attempt.
 	PointWinReasonDoubleFault PointWinReason = "DOUBLE_FAULT"
+	// The opponent was penalised the point, game or match by the umpire.
+	PointWinReasonPenalty PointWinReason = "PENALTY"
 )
 
 var AllPointWinReason = []PointWinReason{
@@ -879,11 +2130,12 @@ var AllPointWinReason = []PointWinReason{
 	PointWinReasonForcedError,
 	PointWinReasonUnforcedError,
 	PointWinReasonDoubleFault,
+	PointWinReasonPenalty,
 }
 
 func (e PointWinReason) IsValid() bool {
 	switch e {
-	case PointWinReasonAce, PointWinReasonWinner, PointWinReasonForcedError, PointWinReasonUnforcedError, PointWinReasonDoubleFault:
+	case PointWinReasonAce, PointWinReasonWinner, PointWinReasonForcedError, PointWinReasonUnforcedError, PointWinReasonDoubleFault, PointWinReasonPenalty:
 		return true
 	}
 	return false
@@ -1114,17 +2366,27 @@ const (
 	ShotTypeGroundStroke ShotType = "GROUND_STROKE"
 	// A shot taken near the net, without the ball bouncing (e.g., a volley or half-volley).
 	ShotTypeVolley ShotType = "VOLLEY"
+	// A whole point recorded without its rally, by addPoint when tracking at
+	// BEGINNER. The hitter is the player the point is attributed to, or the
+	// server when it isn't attributed to anyone.
+	ShotTypePoint ShotType = "POINT"
+	// Something other than a shot, such as a let or a penalty, recorded by
+	// addMatchEvent. matchEvent says what happened. The hitter is the player the
+	// event is charged to, or the server for a let.
+	ShotTypeMatchEvent ShotType = "MATCH_EVENT"
 )
 
 var AllShotType = []ShotType{
 	ShotTypeServe,
 	ShotTypeGroundStroke,
 	ShotTypeVolley,
+	ShotTypePoint,
+	ShotTypeMatchEvent,
 }
 
 func (e ShotType) IsValid() bool {
 	switch e {
-	case ShotTypeServe, ShotTypeGroundStroke, ShotTypeVolley:
+	case ShotTypeServe, ShotTypeGroundStroke, ShotTypeVolley, ShotTypePoint, ShotTypeMatchEvent:
 		return true
 	}
 	return false
@@ -1195,3 +2457,49 @@ func (e *TeamSide) UnmarshalGQL(v any) error {
 func (e TeamSide) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+// The kind of violation a penalty was given for.
+type ViolationType string
+
+const (
+	// Taking longer than allowed between points, at a change of ends or to
+	// resume play.
+	ViolationTypeTimeViolation ViolationType = "TIME_VIOLATION"
+	// A breach of the code of conduct, such as an audible obscenity, ball or
+	// racket abuse or unsportsmanlike conduct.
+	ViolationTypeCodeViolation ViolationType = "CODE_VIOLATION"
+)
+
+var AllViolationType = []ViolationType{
+	ViolationTypeTimeViolation,
+	ViolationTypeCodeViolation,
+}
+
+func (e ViolationType) IsValid() bool {
+	switch e {
+	case ViolationTypeTimeViolation, ViolationTypeCodeViolation:
+		return true
+	}
+	return false
+}
+
+func (e ViolationType) String() string {
+	return string(e)
+}
+
+func (e *ViolationType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ViolationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ViolationType", str)
+	}
+	return nil
+}
+
+func (e ViolationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
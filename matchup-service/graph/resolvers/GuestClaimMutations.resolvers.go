@@ -0,0 +1,38 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SendGuestClaim is the resolver for the sendGuestClaim field.
+func (r *mutationResolver) SendGuestClaim(ctx context.Context, input model.SendGuestClaimInput) (*model.GuestClaim, error) {
+	return r.MatchUpServiceInterface.SendGuestClaim(ctx, input)
+}
+
+// AcceptGuestClaim is the resolver for the acceptGuestClaim field.
+func (r *mutationResolver) AcceptGuestClaim(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error) {
+	return r.MatchUpServiceInterface.AcceptGuestClaim(ctx, id)
+}
+
+// DeclineGuestClaim is the resolver for the declineGuestClaim field.
+func (r *mutationResolver) DeclineGuestClaim(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error) {
+	return r.MatchUpServiceInterface.DeclineGuestClaim(ctx, id)
+}
+
+// CancelGuestClaim is the resolver for the cancelGuestClaim field.
+func (r *mutationResolver) CancelGuestClaim(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error) {
+	return r.MatchUpServiceInterface.CancelGuestClaim(ctx, id)
+}
+
+// Mutation returns graph.MutationResolver implementation.
+func (r *Resolver) Mutation() graph.MutationResolver { return &mutationResolver{r} }
+
+type mutationResolver struct{ *Resolver }
@@ -0,0 +1,22 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+)
+
+// MyGuestClaims is the resolver for the myGuestClaims field.
+func (r *queryResolver) MyGuestClaims(ctx context.Context, status *model.GuestClaimStatus, limit *int, offset *int) ([]*model.GuestClaim, error) {
+	return r.MatchUpServiceInterface.GetMyGuestClaims(ctx, status, limit, offset)
+}
+
+// Query returns graph.QueryResolver implementation.
+func (r *Resolver) Query() graph.QueryResolver { return &queryResolver{r} }
+
+type queryResolver struct{ *Resolver }
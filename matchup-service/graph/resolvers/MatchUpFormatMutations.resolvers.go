@@ -0,0 +1,22 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateMatchUpFormatPreset is the resolver for the createMatchUpFormatPreset field.
+func (r *mutationResolver) CreateMatchUpFormatPreset(ctx context.Context, input model.CreateMatchUpFormatPresetInput) (*model.MatchUpFormatPreset, error) {
+	return r.MatchUpServiceInterface.CreateMatchUpFormatPreset(ctx, input)
+}
+
+// DeleteMatchUpFormatPreset is the resolver for the deleteMatchUpFormatPreset field.
+func (r *mutationResolver) DeleteMatchUpFormatPreset(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	return r.MatchUpServiceInterface.DeleteMatchUpFormatPreset(ctx, id)
+}
@@ -6,18 +6,17 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/CourtIQ/courtiq-backend/matchup-service/graph"
-	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/schema/scalars"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// TestNumberOfSets is the resolver for the testNumberOfSets field.
-func (r *queryResolver) TestNumberOfSets(ctx context.Context, sets *scalars.NumberOfSets) (scalars.NumberOfSets, error) {
-	panic(fmt.Errorf("not implemented: TestNumberOfSets - testNumberOfSets"))
+// MatchUpFormatPreset is the resolver for the matchUpFormatPreset field.
+func (r *queryResolver) MatchUpFormatPreset(ctx context.Context, id primitive.ObjectID) (*model.MatchUpFormatPreset, error) {
+	return r.MatchUpServiceInterface.GetMatchUpFormatPreset(ctx, id)
 }
 
-// Query returns graph.QueryResolver implementation.
-func (r *Resolver) Query() graph.QueryResolver { return &queryResolver{r} }
-
-type queryResolver struct{ *Resolver }
+// MatchUpFormatPresets is the resolver for the matchUpFormatPresets field.
+func (r *queryResolver) MatchUpFormatPresets(ctx context.Context, limit *int, offset *int) ([]*model.MatchUpFormatPreset, error) {
+	return r.MatchUpServiceInterface.GetMatchUpFormatPresets(ctx, limit, offset)
+}
@@ -0,0 +1,22 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AcceptMatchUpInvitation is the resolver for the acceptMatchUpInvitation field.
+func (r *mutationResolver) AcceptMatchUpInvitation(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.AcceptMatchUpInvitation(ctx, matchUpID)
+}
+
+// DeclineMatchUpInvitation is the resolver for the declineMatchUpInvitation field.
+func (r *mutationResolver) DeclineMatchUpInvitation(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.DeclineMatchUpInvitation(ctx, matchUpID)
+}
@@ -0,0 +1,16 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+)
+
+// MyMatchUpInvitations is the resolver for the myMatchUpInvitations field.
+func (r *queryResolver) MyMatchUpInvitations(ctx context.Context, status *model.InvitationStatus, limit *int, offset *int) ([]*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.GetMyMatchUpInvitations(ctx, status, limit, offset)
+}
@@ -7,8 +7,8 @@ package resolvers
 import (
 	"context"
 
-	"github.com/CourtIQ/courtiq-backend/matchup-service/graph"
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // InitiateMatchUp is the resolver for the initiateMatchUp field.
@@ -16,7 +16,22 @@ func (r *mutationResolver) InitiateMatchUp(ctx context.Context, input model.Init
 	return r.MatchUpServiceInterface.InitiateMatchUp(ctx, input)
 }
 
-// Mutation returns graph.MutationResolver implementation.
-func (r *Resolver) Mutation() graph.MutationResolver { return &mutationResolver{r} }
+// UpdateMatchUpStatus is the resolver for the updateMatchUpStatus field.
+func (r *mutationResolver) UpdateMatchUpStatus(ctx context.Context, input model.UpdateMatchUpStatusInput) (*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.UpdateMatchUpStatus(ctx, input)
+}
+
+// ScheduleMatchUp is the resolver for the scheduleMatchUp field.
+func (r *mutationResolver) ScheduleMatchUp(ctx context.Context, input model.ScheduleMatchUpInput) (*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.ScheduleMatchUp(ctx, input)
+}
 
-type mutationResolver struct{ *Resolver }
+// UpdateMatchUpVisibility is the resolver for the updateMatchUpVisibility field.
+func (r *mutationResolver) UpdateMatchUpVisibility(ctx context.Context, matchUpID primitive.ObjectID, visibility model.MatchUpVisibility) (*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.UpdateMatchUpVisibility(ctx, matchUpID, visibility)
+}
+
+// ImportMatchUp is the resolver for the importMatchUp field.
+func (r *mutationResolver) ImportMatchUp(ctx context.Context, input model.ImportMatchUpInput) (*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.ImportMatchUp(ctx, input)
+}
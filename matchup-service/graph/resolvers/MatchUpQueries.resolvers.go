@@ -0,0 +1,27 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MatchUp is the resolver for the matchUp field.
+func (r *queryResolver) MatchUp(ctx context.Context, id primitive.ObjectID) (*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.GetMatchUpById(ctx, id)
+}
+
+// MyMatchUps is the resolver for the myMatchUps field.
+func (r *queryResolver) MyMatchUps(ctx context.Context, filter *model.MatchUpFilterInput, limit *int, offset *int) ([]*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.GetMyMatchUps(ctx, filter, limit, offset)
+}
+
+// ExportMatchUp is the resolver for the exportMatchUp field.
+func (r *queryResolver) ExportMatchUp(ctx context.Context, matchUpID primitive.ObjectID, format model.MatchUpExportFormat) (*model.MatchUpExport, error) {
+	return r.MatchUpServiceInterface.ExportMatchUp(ctx, matchUpID, format)
+}
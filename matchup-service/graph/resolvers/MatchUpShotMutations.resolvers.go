@@ -6,7 +6,6 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,15 +13,30 @@ import (
 
 // AddShot is the resolver for the addShot field.
 func (r *mutationResolver) AddShot(ctx context.Context, input model.AddShotInput) (*model.MatchUpShot, error) {
-	panic(fmt.Errorf("not implemented: AddShot - addShot"))
+	return r.MatchUpServiceInterface.AddShot(ctx, input)
+}
+
+// AddPoint is the resolver for the addPoint field.
+func (r *mutationResolver) AddPoint(ctx context.Context, input model.AddPointInput) (*model.MatchUpShot, error) {
+	return r.MatchUpServiceInterface.AddPoint(ctx, input)
+}
+
+// AddMatchEvent is the resolver for the addMatchEvent field.
+func (r *mutationResolver) AddMatchEvent(ctx context.Context, input model.AddMatchEventInput) (*model.MatchUpShot, error) {
+	return r.MatchUpServiceInterface.AddMatchEvent(ctx, input)
+}
+
+// SyncShots is the resolver for the syncShots field.
+func (r *mutationResolver) SyncShots(ctx context.Context, matchUpID primitive.ObjectID, shots []*model.AddShotInput, baseShotID *primitive.ObjectID) (*model.ShotSyncResult, error) {
+	return r.MatchUpServiceInterface.SyncShots(ctx, matchUpID, shots, baseShotID)
 }
 
 // UndoLastShot is the resolver for the undoLastShot field.
 func (r *mutationResolver) UndoLastShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error) {
-	panic(fmt.Errorf("not implemented: UndoLastShot - undoLastShot"))
+	return r.MatchUpServiceInterface.UndoLastShot(ctx, matchUpID)
 }
 
 // RedoShot is the resolver for the redoShot field.
 func (r *mutationResolver) RedoShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error) {
-	panic(fmt.Errorf("not implemented: RedoShot - redoShot"))
+	return r.MatchUpServiceInterface.RedoShot(ctx, matchUpID)
 }
@@ -6,7 +6,6 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,20 +13,30 @@ import (
 
 // GetLastShot is the resolver for the getLastShot field.
 func (r *queryResolver) GetLastShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error) {
-	panic(fmt.Errorf("not implemented: GetLastShot - getLastShot"))
+	return r.MatchUpServiceInterface.GetLastShot(ctx, matchUpID)
 }
 
 // GetMatchShots is the resolver for the getMatchShots field.
 func (r *queryResolver) GetMatchShots(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.MatchUpShot, error) {
-	panic(fmt.Errorf("not implemented: GetMatchShots - getMatchShots"))
+	return r.MatchUpServiceInterface.GetMatchUpShots(ctx, matchUpID, nil, nil)
 }
 
 // GetShotByID is the resolver for the getShotById field.
 func (r *queryResolver) GetShotByID(ctx context.Context, shotID primitive.ObjectID) (*model.MatchUpShot, error) {
-	panic(fmt.Errorf("not implemented: GetShotByID - getShotById"))
+	return r.MatchUpServiceInterface.GetShotByID(ctx, shotID)
 }
 
 // GetGameShots is the resolver for the getGameShots field.
 func (r *queryResolver) GetGameShots(ctx context.Context, matchUpID primitive.ObjectID, setNumber int, gameNumber int) ([]*model.MatchUpShot, error) {
-	panic(fmt.Errorf("not implemented: GetGameShots - getGameShots"))
+	return r.MatchUpServiceInterface.GetShotsByGame(ctx, matchUpID, setNumber, gameNumber)
+}
+
+// MatchStateAt is the resolver for the matchStateAt field.
+func (r *queryResolver) MatchStateAt(ctx context.Context, matchUpID primitive.ObjectID, shotID primitive.ObjectID) (*model.MatchStateAt, error) {
+	return r.MatchUpServiceInterface.GetMatchStateAt(ctx, matchUpID, shotID)
+}
+
+// MatchTimeline is the resolver for the matchTimeline field.
+func (r *queryResolver) MatchTimeline(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.MatchTimelineEntry, error) {
+	return r.MatchUpServiceInterface.GetMatchTimeline(ctx, matchUpID)
 }
@@ -0,0 +1,37 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MatchStatistics is the resolver for the matchStatistics field.
+func (r *queryResolver) MatchStatistics(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchStatistics, error) {
+	return r.MatchUpServiceInterface.GetMatchStatistics(ctx, matchUpID)
+}
+
+// PlayerStatistics is the resolver for the playerStatistics field.
+func (r *queryResolver) PlayerStatistics(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.PlayerStatistics, error) {
+	return r.MatchUpServiceInterface.GetPlayerStatistics(ctx, matchUpID)
+}
+
+// MatchMomentum is the resolver for the matchMomentum field.
+func (r *queryResolver) MatchMomentum(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchMomentum, error) {
+	return r.MatchUpServiceInterface.GetMatchMomentum(ctx, matchUpID)
+}
+
+// ShotHeatmaps is the resolver for the shotHeatmaps field.
+func (r *queryResolver) ShotHeatmaps(ctx context.Context, matchUpID primitive.ObjectID, filter *model.ShotHeatmapFilterInput) ([]*model.ShotHeatmap, error) {
+	return r.MatchUpServiceInterface.GetShotHeatmaps(ctx, matchUpID, filter)
+}
+
+// HeadToHead is the resolver for the headToHead field.
+func (r *queryResolver) HeadToHead(ctx context.Context, userA primitive.ObjectID, userB primitive.ObjectID) (*model.HeadToHead, error) {
+	return r.MatchUpServiceInterface.GetHeadToHead(ctx, userA, userB)
+}
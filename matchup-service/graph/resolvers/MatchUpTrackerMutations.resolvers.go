@@ -0,0 +1,22 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GrantMatchUpTracker is the resolver for the grantMatchUpTracker field.
+func (r *mutationResolver) GrantMatchUpTracker(ctx context.Context, matchUpID primitive.ObjectID, userID primitive.ObjectID) (*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.GrantMatchUpTracker(ctx, matchUpID, userID)
+}
+
+// RevokeMatchUpTracker is the resolver for the revokeMatchUpTracker field.
+func (r *mutationResolver) RevokeMatchUpTracker(ctx context.Context, matchUpID primitive.ObjectID, userID primitive.ObjectID) (*model.MatchUp, error) {
+	return r.MatchUpServiceInterface.RevokeMatchUpTracker(ctx, matchUpID, userID)
+}
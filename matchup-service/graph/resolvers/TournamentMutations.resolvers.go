@@ -0,0 +1,31 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+)
+
+// CreateTournament is the resolver for the createTournament field.
+func (r *mutationResolver) CreateTournament(ctx context.Context, input model.CreateTournamentInput) (*model.Tournament, error) {
+	return r.MatchUpServiceInterface.CreateTournament(ctx, input)
+}
+
+// AddTournamentEntry is the resolver for the addTournamentEntry field.
+func (r *mutationResolver) AddTournamentEntry(ctx context.Context, input model.AddTournamentEntryInput) (*model.Tournament, error) {
+	return r.MatchUpServiceInterface.AddTournamentEntry(ctx, input)
+}
+
+// CreateDraw is the resolver for the createDraw field.
+func (r *mutationResolver) CreateDraw(ctx context.Context, input model.CreateDrawInput) (*model.Draw, error) {
+	return r.MatchUpServiceInterface.CreateDraw(ctx, input)
+}
+
+// AwardDrawWalkover is the resolver for the awardDrawWalkover field.
+func (r *mutationResolver) AwardDrawWalkover(ctx context.Context, input model.AwardDrawWalkoverInput) (*model.Draw, error) {
+	return r.MatchUpServiceInterface.AwardDrawWalkover(ctx, input)
+}
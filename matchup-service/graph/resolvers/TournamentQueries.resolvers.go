@@ -0,0 +1,32 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tournament is the resolver for the tournament field.
+func (r *queryResolver) Tournament(ctx context.Context, id primitive.ObjectID) (*model.Tournament, error) {
+	return r.MatchUpServiceInterface.GetTournament(ctx, id)
+}
+
+// MyTournaments is the resolver for the myTournaments field.
+func (r *queryResolver) MyTournaments(ctx context.Context, limit *int, offset *int) ([]*model.Tournament, error) {
+	return r.MatchUpServiceInterface.GetMyTournaments(ctx, limit, offset)
+}
+
+// TournamentDraw is the resolver for the tournamentDraw field.
+func (r *queryResolver) TournamentDraw(ctx context.Context, tournamentID primitive.ObjectID, drawID primitive.ObjectID) (*model.Draw, error) {
+	return r.MatchUpServiceInterface.GetTournamentDraw(ctx, tournamentID, drawID)
+}
+
+// OrderOfPlay is the resolver for the orderOfPlay field.
+func (r *queryResolver) OrderOfPlay(ctx context.Context, tournamentID primitive.ObjectID) ([]*model.OrderOfPlayEntry, error) {
+	return r.MatchUpServiceInterface.GetOrderOfPlay(ctx, tournamentID)
+}
@@ -0,0 +1,32 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+)
+
+// Rating is the resolver for the rating field.
+func (r *userResolver) Rating(ctx context.Context, obj *model.User, federationRequires map[string]any) (*int, error) {
+	return r.MatchUpServiceInterface.GetRating(ctx, obj.ID)
+}
+
+// CareerStats is the resolver for the careerStats field.
+func (r *userResolver) CareerStats(ctx context.Context, obj *model.User, filter *model.CareerStatsFilterInput, federationRequires map[string]any) (*model.CareerStatistics, error) {
+	return r.MatchUpServiceInterface.GetCareerStats(ctx, obj.ID, filter)
+}
+
+// RatingHistory is the resolver for the ratingHistory field.
+func (r *userResolver) RatingHistory(ctx context.Context, obj *model.User, limit *int, offset *int, federationRequires map[string]any) ([]*model.RatingChange, error) {
+	return r.MatchUpServiceInterface.GetRatingHistory(ctx, obj.ID, limit, offset)
+}
+
+// User returns graph.UserResolver implementation.
+func (r *Resolver) User() graph.UserResolver { return &userResolver{r} }
+
+type userResolver struct{ *Resolver }
@@ -7,7 +7,7 @@ import (
 	"github.com/99designs/gqlgen/graphql"
 )
 
-// NumberOfGames is an int with constraints: 1, 3, 4, 5, 6, 10.
+// NumberOfGames is an int with constraints: 1, 3, 4, 5, 6, 8, 10.
 //
 // This custom scalar ensures the caller can only provide one of these
 // discrete integer values, preventing invalid "number of games" from
@@ -21,6 +21,7 @@ var validValues = map[int]bool{
 	4:  true, // "FOUR"
 	5:  true, // "FIVE"
 	6:  true, // "SIX"
+	8:  true, // "EIGHT" (pro set)
 	10: true, // "TEN"
 }
 
@@ -31,7 +32,7 @@ func MarshalNumberOfGames(n NumberOfGames) graphql.Marshaler {
 }
 
 // UnmarshalNumberOfGames parses incoming data into NumberOfGames,
-// ensuring it's a whole integer within our valid set {1,3,4,5,6,10}.
+// ensuring it's a whole integer within our valid set {1,3,4,5,6,8,10}.
 func UnmarshalNumberOfGames(v interface{}) (NumberOfGames, error) {
 	floatVal, err := coerceToFloat64(v)
 	if err != nil {
@@ -46,7 +47,7 @@ func UnmarshalNumberOfGames(v interface{}) (NumberOfGames, error) {
 
 	intVal := int(floatVal)
 	if !validValues[intVal] {
-		return 0, fmt.Errorf("NumberOfGames must be one of [1,3,4,5,6,10], got %d", intVal)
+		return 0, fmt.Errorf("NumberOfGames must be one of [1,3,4,5,6,8,10], got %d", intVal)
 	}
 	return NumberOfGames(intVal), nil
 }
@@ -0,0 +1,207 @@
+package draws
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newEntries creates n entry IDs in seeding order
+func newEntries(n int) []primitive.ObjectID {
+	entries := make([]primitive.ObjectID, n)
+	for i := range entries {
+		entries[i] = primitive.NewObjectID()
+	}
+	return entries
+}
+
+// structures counts the slots of each bracket in a draw
+func structures(slots []*model.DrawSlot) map[model.DrawStructure]int {
+	counts := make(map[model.DrawStructure]int)
+	for _, slot := range slots {
+		counts[slot.Structure]++
+	}
+	return counts
+}
+
+// playAll decides every ready slot for side A until the draw is finished,
+// returning how many matches were played
+func playAll(slots []*model.DrawSlot) int {
+	played := 0
+	for ready := Settle(slots); len(ready) > 0; ready = Settle(slots) {
+		for _, slot := range ready {
+			Decide(slots, slot, model.TeamSideTeamA)
+			played++
+		}
+	}
+	return played
+}
+
+func TestSeedLines(t *testing.T) {
+	assert.Equal(t, []int{1, 2}, seedLines(2))
+	assert.Equal(t, []int{1, 4, 2, 3}, seedLines(4))
+	assert.Equal(t, []int{1, 8, 4, 5, 2, 7, 3, 6}, seedLines(8))
+}
+
+func TestSingleEliminationGivesByesToTopSeeds(t *testing.T) {
+	entries := newEntries(5)
+	slots := Build(model.DrawTypeSingleElimination, entries)
+	require.Len(t, slots, 7)
+
+	// Seeds 1 to 3 go straight through to the second round, where 2 and 3
+	// are ready to play
+	ready := Settle(slots)
+	require.Len(t, ready, 2)
+	assert.Equal(t, "MAIN-1-2", ready[0].ID)
+	assert.Equal(t, entries[3], *ready[0].Sides[0].EntryID)
+	assert.Equal(t, entries[4], *ready[0].Sides[1].EntryID)
+	assert.Equal(t, "MAIN-2-2", ready[1].ID)
+	assert.Equal(t, model.DrawSlotStatusWalkover, Find(slots, "MAIN-1-1").Status)
+	assert.Equal(t, entries[0], *Side(Find(slots, "MAIN-2-1"), model.TeamSideTeamA).EntryID)
+
+	// Seed 4 beats seed 5 and meets seed 1
+	Decide(slots, ready[0], model.TeamSideTeamA)
+	ready = Settle(slots)
+	require.Len(t, ready, 2)
+	assert.Equal(t, "MAIN-2-1", ready[0].ID)
+	assert.Equal(t, entries[3], *ready[0].Sides[1].EntryID)
+
+	assert.Equal(t, 3, playAll(slots))
+	assert.Equal(t, entries[0], *Find(slots, "MAIN-3-1").Winner)
+}
+
+func TestCompassUsesDirectionsTheDrawFills(t *testing.T) {
+	assert.Equal(t, map[model.DrawStructure]int{
+		model.DrawStructureEast:  7,
+		model.DrawStructureWest:  3,
+		model.DrawStructureNorth: 1,
+		model.DrawStructureSouth: 1,
+	}, structures(Build(model.DrawTypeCompass, newEntries(8))))
+
+	slots := Build(model.DrawTypeCompass, newEntries(16))
+	assert.Len(t, structures(slots), 8)
+
+	// Everyone plays at least three matches
+	assert.Equal(t, 15+7+3+3+1+1+1+1, playAll(slots))
+	assert.Equal(t, &model.DrawSlotLink{SlotID: "WEST-1-1", TeamSide: model.TeamSideTeamB}, Find(slots, "EAST-1-2").LoserTo)
+}
+
+func TestFeedInConsolationFeedsLosersBeforeTheFinal(t *testing.T) {
+	entries := newEntries(8)
+	slots := Build(model.DrawTypeFeedInConsolation, entries)
+	assert.Equal(t, map[model.DrawStructure]int{
+		model.DrawStructureMain:        7,
+		model.DrawStructureConsolation: 5,
+	}, structures(slots))
+
+	// Second round losers come in against the consolation winners, the
+	// final's loser doesn't
+	assert.Equal(t, &model.DrawSlotLink{SlotID: "CONSOLATION-2-2", TeamSide: model.TeamSideTeamB}, Find(slots, "MAIN-2-1").LoserTo)
+	assert.Equal(t, &model.DrawSlotLink{SlotID: "CONSOLATION-2-1", TeamSide: model.TeamSideTeamA}, Find(slots, "CONSOLATION-1-1").WinnerTo)
+	assert.Nil(t, Find(slots, "MAIN-3-1").LoserTo)
+
+	assert.Equal(t, 12, playAll(slots))
+	assert.Equal(t, entries[0], *Find(slots, "MAIN-3-1").Winner)
+	assert.NotNil(t, Find(slots, "CONSOLATION-3-1").Winner)
+}
+
+func TestFeedInConsolationPassesByesOn(t *testing.T) {
+	slots := Build(model.DrawTypeFeedInConsolation, newEntries(6))
+
+	// Seeds 1 and 2 have byes, which pass on into the consolation bracket
+	// and give the first-round losers a walkover
+	Settle(slots)
+	assert.True(t, Side(Find(slots, "CONSOLATION-1-1"), model.TeamSideTeamA).Bye)
+	assert.Equal(t, 2+2+1+2+1, playAll(slots))
+	assert.Equal(t, model.DrawSlotStatusWalkover, Find(slots, "CONSOLATION-1-1").Status)
+	assert.Equal(t, model.DrawSlotStatusWalkover, Find(slots, "CONSOLATION-1-2").Status)
+}
+
+func TestRoundRobin(t *testing.T) {
+	entries := newEntries(5)
+	slots := Build(model.DrawTypeRoundRobin, entries)
+	require.Len(t, slots, 10)
+
+	// Every pair meets once, and nobody plays twice in a round
+	pairs := make(map[[2]primitive.ObjectID]bool)
+	rounds := make(map[int]map[primitive.ObjectID]bool)
+	for _, slot := range slots {
+		a, b := *slot.Sides[0].EntryID, *slot.Sides[1].EntryID
+		if a.Hex() > b.Hex() {
+			a, b = b, a
+		}
+		assert.False(t, pairs[[2]primitive.ObjectID{a, b}])
+		pairs[[2]primitive.ObjectID{a, b}] = true
+
+		if rounds[slot.Round] == nil {
+			rounds[slot.Round] = make(map[primitive.ObjectID]bool)
+		}
+		assert.False(t, rounds[slot.Round][a] || rounds[slot.Round][b])
+		rounds[slot.Round][a], rounds[slot.Round][b] = true, true
+	}
+	assert.Len(t, rounds, 5)
+
+	// The second seed beats everyone
+	for _, slot := range Settle(slots) {
+		winner := model.TeamSideTeamA
+		if *slot.Sides[1].EntryID == entries[1] {
+			winner = model.TeamSideTeamB
+		}
+		Decide(slots, slot, winner)
+	}
+	standings := Standings(entries, slots)
+	assert.Equal(t, entries[1], standings[0].EntryID)
+	assert.Equal(t, 4, standings[0].Won)
+	assert.Equal(t, 4, standings[1].Played)
+}
+
+func TestWalkoverCountsInStandings(t *testing.T) {
+	entries := newEntries(2)
+	slots := Build(model.DrawTypeRoundRobin, entries)
+	require.Len(t, slots, 1)
+
+	Walkover(slots, slots[0], model.TeamSideTeamB)
+	assert.Equal(t, model.DrawSlotStatusWalkover, slots[0].Status)
+	assert.Equal(t, entries[1], *slots[0].Winner)
+
+	standings := Standings(entries, slots)
+	assert.Equal(t, entries[1], standings[0].EntryID)
+	assert.Equal(t, 1, standings[0].Won)
+	assert.Equal(t, 1, standings[1].Lost)
+}
+
+func TestReopenTakesEntriesBack(t *testing.T) {
+	entries := newEntries(4)
+	slots := Build(model.DrawTypeFeedInConsolation, entries)
+	first := Settle(slots)[0]
+	Decide(slots, first, model.TeamSideTeamA)
+	require.Len(t, Downstream(slots, first), 2)
+
+	Reopen(slots, first)
+	assert.Equal(t, model.DrawSlotStatusScheduled, first.Status)
+	assert.Nil(t, first.Winner)
+	for _, slot := range Downstream(slots, first) {
+		assert.Nil(t, slot.Sides[0].EntryID)
+	}
+	ready := Settle(slots)
+	require.Len(t, ready, 1)
+	assert.Equal(t, "MAIN-1-2", ready[0].ID)
+}
+
+func TestReopenTakesWalkoversBack(t *testing.T) {
+	slots := Build(model.DrawTypeFeedInConsolation, newEntries(6))
+	first := Settle(slots)[0]
+	Decide(slots, first, model.TeamSideTeamB)
+	Settle(slots)
+	walkover := Find(slots, first.LoserTo.SlotID)
+	require.Equal(t, model.DrawSlotStatusWalkover, walkover.Status)
+
+	// The loser's walkover in the consolation bracket goes with the result
+	Reopen(slots, first)
+	assert.Equal(t, model.DrawSlotStatusWaiting, walkover.Status)
+	assert.Nil(t, walkover.Winner)
+	assert.Nil(t, Side(Find(slots, walkover.WinnerTo.SlotID), walkover.WinnerTo.TeamSide).EntryID)
+}
@@ -0,0 +1,283 @@
+package draws
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// compassFeeds lists where the losers of a round of a compass bracket play
+// on. A bracket that would have fewer than two players is left out, so small
+// draws only use some of the directions.
+var compassFeeds = []struct {
+	from  model.DrawStructure
+	round int
+	to    model.DrawStructure
+}{
+	{model.DrawStructureEast, 1, model.DrawStructureWest},
+	{model.DrawStructureEast, 2, model.DrawStructureNorth},
+	{model.DrawStructureEast, 3, model.DrawStructureNorthEast},
+	{model.DrawStructureWest, 1, model.DrawStructureSouth},
+	{model.DrawStructureWest, 2, model.DrawStructureSouthWest},
+	{model.DrawStructureNorth, 1, model.DrawStructureNorthWest},
+	{model.DrawStructureSouth, 1, model.DrawStructureSouthEast},
+}
+
+// SeedingOrder returns the entries' IDs with the seeded entries first, by
+// seed, and the rest in the order they were entered
+func SeedingOrder(entries []*model.TournamentEntry) []primitive.ObjectID {
+	sorted := append([]*model.TournamentEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Seed, sorted[j].Seed
+		switch {
+		case a == nil:
+			return false
+		case b == nil:
+			return true
+		default:
+			return *a < *b
+		}
+	})
+
+	ids := make([]primitive.ObjectID, len(sorted))
+	for i, entry := range sorted {
+		ids[i] = entry.ID
+	}
+	return ids
+}
+
+// Build lays out the slots of a new draw for at least two entries given in
+// seeding order. Knockout brackets are filled up with byes.
+func Build(drawType model.DrawType, entries []primitive.ObjectID) []*model.DrawSlot {
+	switch drawType {
+	case model.DrawTypeRoundRobin:
+		return roundRobin(entries)
+	case model.DrawTypeCompass:
+		return compass(entries)
+	case model.DrawTypeFeedInConsolation:
+		return feedInConsolation(entries)
+	default:
+		return singleElimination(entries)
+	}
+}
+
+// singleElimination is a single knockout bracket
+func singleElimination(entries []primitive.ObjectID) []*model.DrawSlot {
+	main := bracket(model.DrawStructureMain, bracketSize(len(entries))/2)
+	fill(main[0], entries)
+	return flatten(main)
+}
+
+// compass is a knockout EAST bracket whose losers play on in the brackets
+// listed in compassFeeds
+func compass(entries []primitive.ObjectID) []*model.DrawSlot {
+	east := bracket(model.DrawStructureEast, bracketSize(len(entries))/2)
+	fill(east[0], entries)
+	brackets := map[model.DrawStructure][][]*model.DrawSlot{model.DrawStructureEast: east}
+	slots := flatten(east)
+
+	for _, feed := range compassFeeds {
+		from, ok := brackets[feed.from]
+		if !ok || feed.round > len(from) || len(from[feed.round-1]) < 2 {
+			continue
+		}
+		losers := from[feed.round-1]
+		to := bracket(feed.to, len(losers)/2)
+		for i, slot := range losers {
+			slot.LoserTo = link(to[0][i/2], i)
+		}
+		brackets[feed.to] = to
+		slots = append(slots, flatten(to)...)
+	}
+	return slots
+}
+
+// feedInConsolation is a knockout MAIN bracket with a CONSOLATION bracket.
+// First-round losers start the consolation bracket, and the losers of each
+// later round before the final feed in once it is down to as many players.
+func feedInConsolation(entries []primitive.ObjectID) []*model.DrawSlot {
+	main := bracket(model.DrawStructureMain, bracketSize(len(entries))/2)
+	fill(main[0], entries)
+	slots := flatten(main)
+	if len(main[0]) < 2 {
+		return slots
+	}
+
+	round := 1
+	current := consolationRound(round, len(main[0])/2)
+	for i, slot := range main[0] {
+		slot.LoserTo = link(current[i/2], i)
+	}
+	slots = append(slots, current...)
+
+	// playDown halves the consolation bracket
+	playDown := func() {
+		round++
+		next := consolationRound(round, len(current)/2)
+		for i, slot := range current {
+			slot.WinnerTo = link(next[i/2], i)
+		}
+		current = next
+		slots = append(slots, current...)
+	}
+
+	for r := 2; r < len(main); r++ {
+		feeders := main[r-1]
+		for len(current) > len(feeders) {
+			playDown()
+		}
+
+		// Losers come in against the consolation winners in reverse order,
+		// so players who met early don't meet again straight away
+		round++
+		next := consolationRound(round, len(feeders))
+		for i, slot := range current {
+			slot.WinnerTo = &model.DrawSlotLink{SlotID: next[i].ID, TeamSide: model.TeamSideTeamA}
+		}
+		for i, slot := range feeders {
+			slot.LoserTo = &model.DrawSlotLink{SlotID: next[len(next)-1-i].ID, TeamSide: model.TeamSideTeamB}
+		}
+		current = next
+		slots = append(slots, current...)
+	}
+	for len(current) > 1 {
+		playDown()
+	}
+	return slots
+}
+
+// roundRobin pairs every entry with every other once, round by round by the
+// circle method. With an odd number of entries one sits out each round.
+func roundRobin(entries []primitive.ObjectID) []*model.DrawSlot {
+	lineup := make([]*primitive.ObjectID, len(entries))
+	for i := range entries {
+		entry := entries[i]
+		lineup[i] = &entry
+	}
+	if len(lineup)%2 == 1 {
+		lineup = append(lineup, nil)
+	}
+
+	var slots []*model.DrawSlot
+	n := len(lineup)
+	for round := 1; round < n; round++ {
+		position := 0
+		for i := 0; i < n/2; i++ {
+			a, b := lineup[i], lineup[n-1-i]
+			if a == nil || b == nil {
+				continue
+			}
+			position++
+			slot := newSlot(model.DrawStructureMain, round, position)
+			slot.Sides[0].EntryID = a
+			slot.Sides[1].EntryID = b
+			slots = append(slots, slot)
+		}
+
+		// The first entry stays put and the rest rotate around it
+		lineup = append([]*primitive.ObjectID{lineup[0], lineup[n-1]}, lineup[1:n-1]...)
+	}
+	return slots
+}
+
+// bracket lays out a knockout bracket whose first round has the given number
+// of slots, linking each slot's winner into the next round. The slots are
+// returned round by round.
+func bracket(structure model.DrawStructure, firstRound int) [][]*model.DrawSlot {
+	var rounds [][]*model.DrawSlot
+	for round, count := 1, firstRound; count >= 1; round, count = round+1, count/2 {
+		slots := make([]*model.DrawSlot, count)
+		for i := range slots {
+			slots[i] = newSlot(structure, round, i+1)
+		}
+		if round > 1 {
+			for i, previous := range rounds[len(rounds)-1] {
+				previous.WinnerTo = link(slots[i/2], i)
+			}
+		}
+		rounds = append(rounds, slots)
+	}
+	return rounds
+}
+
+// consolationRound lays out one round of a consolation bracket
+func consolationRound(round, count int) []*model.DrawSlot {
+	slots := make([]*model.DrawSlot, count)
+	for i := range slots {
+		slots[i] = newSlot(model.DrawStructureConsolation, round, i+1)
+	}
+	return slots
+}
+
+// fill places entries on the first round of a bracket by seed. Lines beyond
+// the entries are byes, which fall against the top seeds.
+func fill(firstRound []*model.DrawSlot, entries []primitive.ObjectID) {
+	for line, seed := range seedLines(2 * len(firstRound)) {
+		side := firstRound[line/2].Sides[line%2]
+		if seed <= len(entries) {
+			entry := entries[seed-1]
+			side.EntryID = &entry
+		} else {
+			side.Bye = true
+		}
+	}
+}
+
+// seedLines returns the seed placed on each line of a bracket, keeping the
+// top seeds apart until the late rounds: 1 and 2 can only meet in the final,
+// 1 to 4 in the semifinals, and so on
+func seedLines(size int) []int {
+	lines := []int{1}
+	for len(lines) < size {
+		next := make([]int, 0, 2*len(lines))
+		for _, seed := range lines {
+			next = append(next, seed, 2*len(lines)+1-seed)
+		}
+		lines = next
+	}
+	return lines
+}
+
+// bracketSize returns the smallest power of two that fits the entries
+func bracketSize(entries int) int {
+	size := 2
+	for size < entries {
+		size *= 2
+	}
+	return size
+}
+
+// newSlot creates an empty slot waiting for both its sides
+func newSlot(structure model.DrawStructure, round, position int) *model.DrawSlot {
+	return &model.DrawSlot{
+		ID:        fmt.Sprintf("%s-%d-%d", structure, round, position),
+		Structure: structure,
+		Round:     round,
+		Position:  position,
+		Sides: []*model.DrawSlotSide{
+			{TeamSide: model.TeamSideTeamA},
+			{TeamSide: model.TeamSideTeamB},
+		},
+		Status: model.DrawSlotStatusWaiting,
+	}
+}
+
+// link points at side A of a slot for even lines and side B for odd ones
+func link(slot *model.DrawSlot, line int) *model.DrawSlotLink {
+	teamSide := model.TeamSideTeamA
+	if line%2 == 1 {
+		teamSide = model.TeamSideTeamB
+	}
+	return &model.DrawSlotLink{SlotID: slot.ID, TeamSide: teamSide}
+}
+
+// flatten lists a bracket's slots round by round
+func flatten(rounds [][]*model.DrawSlot) []*model.DrawSlot {
+	var slots []*model.DrawSlot
+	for _, round := range rounds {
+		slots = append(slots, round...)
+	}
+	return slots
+}
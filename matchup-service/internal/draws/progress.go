@@ -0,0 +1,185 @@
+package draws
+
+import (
+	"sort"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Find returns the slot with the given ID, or nil
+func Find(slots []*model.DrawSlot, id string) *model.DrawSlot {
+	for _, slot := range slots {
+		if slot.ID == id {
+			return slot
+		}
+	}
+	return nil
+}
+
+// Side returns a slot's side for a team
+func Side(slot *model.DrawSlot, teamSide model.TeamSide) *model.DrawSlotSide {
+	for _, side := range slot.Sides {
+		if side.TeamSide == teamSide {
+			return side
+		}
+	}
+	return nil
+}
+
+// Settle decides the slots that can't be played because a side is a bye,
+// moving entries and byes on until nothing changes. It returns the slots
+// ready for a match: both entries known and no match created yet.
+func Settle(slots []*model.DrawSlot) []*model.DrawSlot {
+	for changed := true; changed; {
+		changed = false
+		for _, slot := range slots {
+			a, b := Side(slot, model.TeamSideTeamA), Side(slot, model.TeamSideTeamB)
+			if slot.Status != model.DrawSlotStatusWaiting || !known(a) || !known(b) || (!a.Bye && !b.Bye) {
+				continue
+			}
+
+			if a.Bye && b.Bye {
+				slot.Status = model.DrawSlotStatusEmpty
+				moveOn(slots, slot.WinnerTo, nil)
+			} else {
+				winner := a
+				if a.Bye {
+					winner = b
+				}
+				slot.Status = model.DrawSlotStatusWalkover
+				slot.Winner = winner.EntryID
+				moveOn(slots, slot.WinnerTo, winner.EntryID)
+			}
+			moveOn(slots, slot.LoserTo, nil)
+			changed = true
+		}
+	}
+
+	var ready []*model.DrawSlot
+	for _, slot := range slots {
+		if slot.Status == model.DrawSlotStatusWaiting && slot.MatchUpID == nil &&
+			slot.Sides[0].EntryID != nil && slot.Sides[1].EntryID != nil {
+			ready = append(ready, slot)
+		}
+	}
+	return ready
+}
+
+// Decide records the result of a slot's match, moving the winner on and, in
+// compass and consolation draws, the loser too
+func Decide(slots []*model.DrawSlot, slot *model.DrawSlot, winner model.TeamSide) {
+	winnerSide, loserSide := Side(slot, winner), Side(slot, opponent(winner))
+	slot.Status = model.DrawSlotStatusCompleted
+	slot.Winner = winnerSide.EntryID
+	moveOn(slots, slot.WinnerTo, winnerSide.EntryID)
+	moveOn(slots, slot.LoserTo, loserSide.EntryID)
+}
+
+// Walkover decides a slot without its match, moving entries on as Decide does
+func Walkover(slots []*model.DrawSlot, slot *model.DrawSlot, winner model.TeamSide) {
+	Decide(slots, slot, winner)
+	slot.Status = model.DrawSlotStatusWalkover
+}
+
+// Downstream returns the slots a decided slot moved its entries on to
+func Downstream(slots []*model.DrawSlot, slot *model.DrawSlot) []*model.DrawSlot {
+	var downstream []*model.DrawSlot
+	for _, link := range links(slot) {
+		downstream = append(downstream, Find(slots, link.SlotID))
+	}
+	return downstream
+}
+
+// Reopen takes back the result of a slot whose match was reopened. Its
+// entries are taken back out of the slots they moved on to, which go back
+// to waiting without a match. A walkover an entry was given on the way is
+// taken back too.
+func Reopen(slots []*model.DrawSlot, slot *model.DrawSlot) {
+	slot.Status = model.DrawSlotStatusScheduled
+	slot.Winner = nil
+	for _, link := range links(slot) {
+		target := Find(slots, link.SlotID)
+		if target.Status == model.DrawSlotStatusWalkover {
+			Reopen(slots, target)
+		}
+		side := Side(target, link.TeamSide)
+		side.EntryID = nil
+		side.Bye = false
+		target.Status = model.DrawSlotStatusWaiting
+		target.MatchUpID = nil
+	}
+}
+
+// Standings ranks the entries of a round robin by matches won, then by fewest
+// lost. A walkover counts as played, won by the side that went through.
+// Entries level on both keep their seeding order.
+func Standings(entries []primitive.ObjectID, slots []*model.DrawSlot) []*model.DrawStanding {
+	standings := make([]*model.DrawStanding, len(entries))
+	byEntry := make(map[primitive.ObjectID]*model.DrawStanding, len(entries))
+	for i, entry := range entries {
+		standings[i] = &model.DrawStanding{EntryID: entry}
+		byEntry[entry] = standings[i]
+	}
+
+	for _, slot := range slots {
+		if slot.Status != model.DrawSlotStatusCompleted && slot.Status != model.DrawSlotStatusWalkover {
+			continue
+		}
+		for _, side := range slot.Sides {
+			standing := byEntry[*side.EntryID]
+			standing.Played++
+			if *side.EntryID == *slot.Winner {
+				standing.Won++
+			} else {
+				standing.Lost++
+			}
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Won != standings[j].Won {
+			return standings[i].Won > standings[j].Won
+		}
+		return standings[i].Lost < standings[j].Lost
+	})
+	return standings
+}
+
+// moveOn puts an entry, or a bye when entry is nil, on the linked side
+func moveOn(slots []*model.DrawSlot, link *model.DrawSlotLink, entry *primitive.ObjectID) {
+	if link == nil {
+		return
+	}
+	side := Side(Find(slots, link.SlotID), link.TeamSide)
+	if entry == nil {
+		side.Bye = true
+		return
+	}
+	id := *entry
+	side.EntryID = &id
+}
+
+// known reports whether a side's entry or bye has been decided
+func known(side *model.DrawSlotSide) bool {
+	return side.EntryID != nil || side.Bye
+}
+
+// links returns where a slot sends its winner and loser
+func links(slot *model.DrawSlot) []*model.DrawSlotLink {
+	var links []*model.DrawSlotLink
+	for _, link := range []*model.DrawSlotLink{slot.WinnerTo, slot.LoserTo} {
+		if link != nil {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// opponent returns the other team side
+func opponent(teamSide model.TeamSide) model.TeamSide {
+	if teamSide == model.TeamSideTeamA {
+		return model.TeamSideTeamB
+	}
+	return model.TeamSideTeamA
+}
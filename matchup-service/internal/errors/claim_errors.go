@@ -0,0 +1,53 @@
+package errors
+
+import (
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+)
+
+// Guest claim error constants
+const (
+	ErrGuestClaimNotFound       = "guest claim not found"
+	ErrNotAGuest                = "guestId must be a guest participant of the matchup"
+	ErrGuestClaimNotOwner       = "only the matchup owner can invite someone to claim its guests"
+	ErrGuestClaimPending        = "this guest already has a pending claim"
+	ErrGuestClaimNotPending     = "only pending guest claims can be answered or cancelled"
+	ErrClaimantAlreadyPlayed    = "the claimant already played in a match with this guest"
+	ErrGuestClaimNotAddressedTo = "this guest claim was sent to someone else"
+)
+
+// NewGuestClaimNotFoundError returns an error when a claim does not exist or
+// is neither sent by nor addressed to the current user
+func NewGuestClaimNotFoundError() error {
+	return sharedErrors.NewNotFoundError(ErrGuestClaimNotFound)
+}
+
+// NewNotAGuestError returns an error when inviting someone to claim a participant that isn't a guest
+func NewNotAGuestError() error {
+	return sharedErrors.NewValidationError("guestId", ErrNotAGuest)
+}
+
+// NewGuestClaimNotOwnerError returns an error when someone other than the owner sends a claim
+func NewGuestClaimNotOwnerError() error {
+	return sharedErrors.NewForbiddenError(ErrGuestClaimNotOwner)
+}
+
+// NewGuestClaimPendingError returns an error when a guest already has an open invitation
+func NewGuestClaimPendingError() error {
+	return sharedErrors.NewConflictError(ErrGuestClaimPending)
+}
+
+// NewGuestClaimNotPendingError returns an error when answering a claim that was already settled
+func NewGuestClaimNotPendingError() error {
+	return sharedErrors.NewConflictError(ErrGuestClaimNotPending)
+}
+
+// NewClaimantAlreadyPlayedError returns an error when the claimant and the
+// guest are both participants of the same match
+func NewClaimantAlreadyPlayedError() error {
+	return sharedErrors.NewConflictError(ErrClaimantAlreadyPlayed)
+}
+
+// NewGuestClaimNotAddressedToError returns an error when answering a claim sent to another user
+func NewGuestClaimNotAddressedToError() error {
+	return sharedErrors.NewForbiddenError(ErrGuestClaimNotAddressedTo)
+}
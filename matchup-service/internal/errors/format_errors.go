@@ -0,0 +1,21 @@
+package errors
+
+import (
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+)
+
+// Format preset error constants
+const (
+	ErrFormatPresetNotFound = "matchup format preset not found"
+	ErrBuiltInPresetLocked  = "built-in format presets cannot be changed or deleted"
+)
+
+// NewFormatPresetNotFoundError returns an error when a preset does not exist or belongs to another user
+func NewFormatPresetNotFoundError() error {
+	return sharedErrors.NewNotFoundError(ErrFormatPresetNotFound)
+}
+
+// NewBuiltInPresetLockedError returns an error when modifying a built-in preset
+func NewBuiltInPresetLockedError() error {
+	return sharedErrors.NewForbiddenError(ErrBuiltInPresetLocked)
+}
@@ -0,0 +1,47 @@
+package errors
+
+import (
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+)
+
+// Invitation and scheduling error constants
+const (
+	ErrInvitationNotFound     = "you were not invited to this matchup"
+	ErrInvitationClosed       = "invitations can only be answered before the matchup starts"
+	ErrInvitationsNotAccepted = "every invited participant must accept before the matchup starts"
+	ErrOverrideNotOwner       = "only the matchup owner can start it without accepted invitations"
+	ErrScheduleNotOwner       = "only the matchup owner can schedule it"
+	ErrMatchUpAlreadyStarted  = "only matchups that have not started can be scheduled"
+)
+
+// NewInvitationNotFoundError returns an error when the current user has no
+// invitation to answer in the matchup
+func NewInvitationNotFoundError() error {
+	return sharedErrors.NewNotFoundError(ErrInvitationNotFound)
+}
+
+// NewInvitationClosedError returns an error when answering an invitation to a matchup that already started
+func NewInvitationClosedError() error {
+	return sharedErrors.NewConflictError(ErrInvitationClosed)
+}
+
+// NewInvitationsNotAcceptedError returns an error when starting a matchup
+// with invitations still pending or declined
+func NewInvitationsNotAcceptedError() error {
+	return sharedErrors.NewConflictError(ErrInvitationsNotAccepted)
+}
+
+// NewOverrideNotOwnerError returns an error when someone other than the owner overrides the invitations
+func NewOverrideNotOwnerError() error {
+	return sharedErrors.NewForbiddenError(ErrOverrideNotOwner)
+}
+
+// NewScheduleNotOwnerError returns an error when someone other than the owner reschedules a matchup
+func NewScheduleNotOwnerError() error {
+	return sharedErrors.NewForbiddenError(ErrScheduleNotOwner)
+}
+
+// NewMatchUpAlreadyStartedError returns an error when scheduling a matchup that has started
+func NewMatchUpAlreadyStartedError() error {
+	return sharedErrors.NewConflictError(ErrMatchUpAlreadyStarted)
+}
@@ -0,0 +1,200 @@
+package errors
+
+import (
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Matchup error constants
+const (
+	ErrMatchUpNotFound       = "matchup not found"
+	ErrMatchUpAlreadyDecided = "matchup has already been decided"
+	ErrHitterNotParticipant  = "hitter must be one of the participants"
+	ErrInvalidShotSequence   = "invalid shot sequence"
+	ErrShotNotFound          = "shot not found"
+	ErrNothingToUndo         = "there are no shots to undo"
+	ErrNothingToRedo         = "there are no undone shots to redo"
+	ErrWrongServer           = "it is not this player's turn to serve"
+	ErrWrongServiceBox       = "serve must be directed to the "
+	ErrMatchUpNotInProgress  = "shots can only be recorded while the matchup is IN_PROGRESS"
+	ErrInvalidStatusChange   = "invalid matchup status transition"
+	ErrMatchUpNotDecided     = "matchup cannot be completed before the score is decided"
+	ErrRetiringSideRequired  = "retiringSide is required when a matchup is RETIRED"
+	ErrRetiringSideNotNeeded = "retiringSide is only allowed when a matchup is RETIRED"
+	ErrPointOnlyNotAllowed   = "points can only be recorded without their shots when tracking at BEGINNER"
+	ErrStyleFieldRequired    = "required by the matchup's tracking style "
+	ErrPointPlayerRequired   = "playerId is required to attribute the point in doubles"
+	ErrInvalidPointPlayer    = "playerId does not match how the point ended"
+	ErrInvalidPointReason    = "pointWinReason does not match the point winner"
+	ErrShotSyncConflict      = "the matchup has shots the client has not seen"
+	ErrInvalidShotBatch      = "invalid shot batch"
+	ErrMatchUpModified       = "matchup was changed by someone else; reload it and try again"
+	ErrShotReplay            = "the matchup's shots cannot be replayed"
+	ErrEventPlayer           = "playerId must be one of the participants"
+)
+
+// NewMatchUpNotFoundError returns an error when a matchup does not exist
+func NewMatchUpNotFoundError() error {
+	return sharedErrors.NewNotFoundError(ErrMatchUpNotFound)
+}
+
+// NewMatchUpAlreadyDecidedError returns an error when scoring a matchup that is already complete
+func NewMatchUpAlreadyDecidedError() error {
+	return sharedErrors.NewConflictError(ErrMatchUpAlreadyDecided)
+}
+
+// NewHitterNotParticipantError returns an error when the hitter is not part of the matchup
+func NewHitterNotParticipantError() error {
+	return sharedErrors.NewValidationError(
+		"hitterId",
+		ErrHitterNotParticipant,
+	)
+}
+
+// NewInvalidShotSequenceError returns an error when a shot cannot follow the previous one
+func NewInvalidShotSequenceError(reason string) error {
+	return sharedErrors.NewValidationError(
+		"shot",
+		ErrInvalidShotSequence+": "+reason,
+	)
+}
+
+// NewWrongServerError returns an error when a point is started by a player out of the serving order
+func NewWrongServerError() error {
+	return sharedErrors.NewValidationError(
+		"hitterId",
+		ErrWrongServer,
+	)
+}
+
+// NewWrongServiceBoxError returns an error when a serve names a box other than the one due
+func NewWrongServiceBoxError(expected string) error {
+	return sharedErrors.NewValidationError(
+		"serviceBoxSide",
+		ErrWrongServiceBox+expected,
+	)
+}
+
+// NewMatchUpNotInProgressError returns an error when recording shots for a matchup that is not being played
+func NewMatchUpNotInProgressError() error {
+	return sharedErrors.NewConflictError(ErrMatchUpNotInProgress)
+}
+
+// NewInvalidStatusTransitionError returns an error when a matchup cannot move between two statuses
+func NewInvalidStatusTransitionError(from, to string) error {
+	return sharedErrors.NewConflictError(ErrInvalidStatusChange + ": " + from + " to " + to)
+}
+
+// NewMatchUpNotDecidedError returns an error when completing a matchup whose score is still open
+func NewMatchUpNotDecidedError() error {
+	return sharedErrors.NewConflictError(ErrMatchUpNotDecided)
+}
+
+// NewRetiringSideRequiredError returns an error when retiring a matchup without naming the side
+func NewRetiringSideRequiredError() error {
+	return sharedErrors.NewValidationError(
+		"retiringSide",
+		ErrRetiringSideRequired,
+	)
+}
+
+// NewRetiringSideNotAllowedError returns an error when a retiring side is given for another status
+func NewRetiringSideNotAllowedError() error {
+	return sharedErrors.NewValidationError(
+		"retiringSide",
+		ErrRetiringSideNotNeeded,
+	)
+}
+
+// NewShotNotFoundError returns an error when a referenced shot does not exist
+func NewShotNotFoundError() error {
+	return sharedErrors.NewNotFoundError(ErrShotNotFound)
+}
+
+// NewNothingToUndoError returns an error when undoing a matchup without shots
+func NewNothingToUndoError() error {
+	return sharedErrors.NewConflictError(ErrNothingToUndo)
+}
+
+// NewNothingToRedoError returns an error when redoing without any undone shots
+func NewNothingToRedoError() error {
+	return sharedErrors.NewConflictError(ErrNothingToRedo)
+}
+
+// NewPointOnlyNotAllowedError returns an error when recording a point without
+// its shots in a matchup tracked at a style that needs every shot
+func NewPointOnlyNotAllowedError() error {
+	return sharedErrors.NewConflictError(ErrPointOnlyNotAllowed)
+}
+
+// NewTrackingStyleFieldRequiredError returns an error when a shot leaves out
+// a detail the matchup's tracking style requires
+func NewTrackingStyleFieldRequiredError(field, style string) error {
+	return sharedErrors.NewValidationError(
+		field,
+		ErrStyleFieldRequired+style,
+	)
+}
+
+// NewPointPlayerRequiredError returns an error when a doubles point has a reason but no player
+func NewPointPlayerRequiredError() error {
+	return sharedErrors.NewValidationError(
+		"playerId",
+		ErrPointPlayerRequired,
+	)
+}
+
+// NewInvalidPointPlayerError returns an error when a point is attributed to the wrong player
+func NewInvalidPointPlayerError(reason string) error {
+	return sharedErrors.NewValidationError(
+		"playerId",
+		ErrInvalidPointPlayer+": "+reason,
+	)
+}
+
+// NewInvalidPointReasonError returns an error when a point's reason contradicts who won it
+func NewInvalidPointReasonError(reason string) error {
+	return sharedErrors.NewValidationError(
+		"pointWinReason",
+		ErrInvalidPointReason+": "+reason,
+	)
+}
+
+// NewShotSyncConflictError returns an error when an offline batch was
+// recorded on top of a different last shot than the matchup now has
+func NewShotSyncConflictError(lastShot *primitive.ObjectID) error {
+	current := "none"
+	if lastShot != nil {
+		current = lastShot.Hex()
+	}
+	return sharedErrors.NewConflictError(ErrShotSyncConflict + ": its last shot is " + current)
+}
+
+// NewInvalidShotBatchError returns an error when a shot in an offline batch can't be synced
+func NewInvalidShotBatchError(reason string) error {
+	return sharedErrors.NewValidationError(
+		"shots",
+		ErrInvalidShotBatch+": "+reason,
+	)
+}
+
+// NewMatchUpModifiedError returns an error when a matchup is updated from a
+// stale copy because another tracker changed it first
+func NewMatchUpModifiedError() error {
+	return sharedErrors.NewConflictError(ErrMatchUpModified)
+}
+
+// NewShotReplayError returns an error when the stored shots of a matchup
+// break the scoring rules, so its state can't be worked out again
+func NewShotReplayError(reason string) error {
+	return sharedErrors.NewInternalError(ErrShotReplay + ": " + reason)
+}
+
+// NewEventPlayerNotParticipantError returns an error when a match event is
+// charged to someone who isn't playing
+func NewEventPlayerNotParticipantError() error {
+	return sharedErrors.NewValidationError(
+		"playerId",
+		ErrEventPlayer,
+	)
+}
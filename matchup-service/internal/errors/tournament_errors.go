@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"strconv"
+
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+)
+
+// Tournament error constants
+const (
+	ErrTournamentNotFound   = "tournament not found"
+	ErrDrawNotFound         = "draw not found"
+	ErrTournamentNotOwner   = "only the tournament owner can change it"
+	ErrEntryPlayerCount     = "wrong number of players for the tournament's match type"
+	ErrInvalidSeed          = "seed must be at least 1"
+	ErrSeedTaken            = "another entry already has this seed"
+	ErrPlayerAlreadyEntered = "a player is already entered in this tournament"
+	ErrDrawEntryNotFound    = "entryIds must be entries of the tournament"
+	ErrDrawTooFewEntries    = "a draw needs at least two entries"
+	ErrDrawDuplicateEntry   = "an entry can only be placed once in a draw"
+	ErrDrawSlotNotFound     = "draw slot not found"
+	ErrWalkoverNotAllowed   = "a walkover can only be awarded when the slot's match was cancelled or abandoned"
+)
+
+// NewTournamentNotFoundError returns an error when a tournament does not exist
+func NewTournamentNotFoundError() error {
+	return sharedErrors.NewNotFoundError(ErrTournamentNotFound)
+}
+
+// NewDrawNotFoundError returns an error when a tournament has no draw with the given ID
+func NewDrawNotFoundError() error {
+	return sharedErrors.NewNotFoundError(ErrDrawNotFound)
+}
+
+// NewTournamentNotOwnerError returns an error when someone other than the owner changes a tournament
+func NewTournamentNotOwnerError() error {
+	return sharedErrors.NewForbiddenError(ErrTournamentNotOwner)
+}
+
+// NewEntryPlayerCountError returns an error when an entry has the wrong number of players
+func NewEntryPlayerCountError(matchType string, expected int) error {
+	return sharedErrors.NewValidationError(
+		"players",
+		ErrEntryPlayerCount+": "+matchType+" entries have "+strconv.Itoa(expected)+" players",
+	)
+}
+
+// NewInvalidSeedError returns an error for a seed below 1
+func NewInvalidSeedError() error {
+	return sharedErrors.NewValidationError("seed", ErrInvalidSeed)
+}
+
+// NewSeedTakenError returns an error when two entries are given the same seed
+func NewSeedTakenError() error {
+	return sharedErrors.NewConflictError(ErrSeedTaken)
+}
+
+// NewPlayerAlreadyEnteredError returns an error when a player is entered twice
+func NewPlayerAlreadyEnteredError() error {
+	return sharedErrors.NewConflictError(ErrPlayerAlreadyEntered)
+}
+
+// NewDrawEntryNotFoundError returns an error when a draw is given an entry the tournament doesn't have
+func NewDrawEntryNotFoundError() error {
+	return sharedErrors.NewValidationError("entryIds", ErrDrawEntryNotFound)
+}
+
+// NewDrawTooFewEntriesError returns an error when a draw would have fewer than two entries
+func NewDrawTooFewEntriesError() error {
+	return sharedErrors.NewValidationError("entryIds", ErrDrawTooFewEntries)
+}
+
+// NewDrawDuplicateEntryError returns an error when an entry is listed twice for a draw
+func NewDrawDuplicateEntryError() error {
+	return sharedErrors.NewValidationError("entryIds", ErrDrawDuplicateEntry)
+}
+
+// NewDrawSlotNotFoundError returns an error when a draw has no slot with the given ID
+func NewDrawSlotNotFoundError() error {
+	return sharedErrors.NewNotFoundError(ErrDrawSlotNotFound)
+}
+
+// NewWalkoverNotAllowedError returns an error when a walkover is awarded in a
+// slot whose match can still be played or already decided it
+func NewWalkoverNotAllowedError() error {
+	return sharedErrors.NewConflictError(ErrWalkoverNotAllowed)
+}
@@ -0,0 +1,40 @@
+package errors
+
+import (
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+)
+
+// Tracker error constants
+const (
+	ErrNotTracker               = "only the matchup owner and its trackers can record shots"
+	ErrTrackerNotOwner          = "only the matchup owner can grant or revoke tracking"
+	ErrTrackerIsOwner           = "the matchup owner can always track it"
+	ErrTrackerNotFound          = "userId is not a tracker of this matchup"
+	ErrAccessControlUnavailable = "access control is not available"
+)
+
+// NewNotTrackerError returns an error when someone without tracking rights records shots
+func NewNotTrackerError() error {
+	return sharedErrors.NewForbiddenError(ErrNotTracker)
+}
+
+// NewTrackerNotOwnerError returns an error when someone other than the owner changes the trackers
+func NewTrackerNotOwnerError() error {
+	return sharedErrors.NewForbiddenError(ErrTrackerNotOwner)
+}
+
+// NewTrackerIsOwnerError returns an error when granting or revoking tracking for the owner
+func NewTrackerIsOwnerError() error {
+	return sharedErrors.NewValidationError("userId", ErrTrackerIsOwner)
+}
+
+// NewTrackerNotFoundError returns an error when revoking tracking from a user who doesn't have it
+func NewTrackerNotFoundError() error {
+	return sharedErrors.NewNotFoundError(ErrTrackerNotFound)
+}
+
+// NewAccessControlUnavailableError returns an error when roles can't be
+// granted because there is no access checker in the context
+func NewAccessControlUnavailableError() error {
+	return sharedErrors.NewInternalError(ErrAccessControlUnavailable)
+}
@@ -1,23 +1,30 @@
 package errors
 
 import (
+	"strconv"
+
 	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
 )
 
 // Validation error constants
 const (
-	ErrInvalidParticipantCount    = "invalid participant count for match type"
-	ErrInvalidTeamDistribution    = "invalid team distribution for participants"
+	ErrInvalidParticipantCount     = "invalid participant count for match type"
+	ErrInvalidTeamDistribution     = "invalid team distribution for participants"
 	ErrInitialServerNotParticipant = "initial server must be one of the participants"
-	ErrInvalidMatchFormat         = "invalid match format configuration"
-	ErrRequiredField              = "required field is missing"
+	ErrInvalidMatchFormat          = "invalid match format configuration"
+	ErrRequiredField               = "required field is missing"
+	ErrInvalidDateRange            = "startTimeFrom must not be after startTimeTo"
+	ErrFormatSourceRequired        = "exactly one of matchUpFormat and matchUpFormatPresetId must be provided"
+	ErrInvalidImportContent        = "import content could not be read"
+	ErrChartingSinglesOnly         = "charting notation can only be imported for singles matches"
+	ErrSamePlayer                  = "userA and userB must be different players"
 )
 
 // NewInvalidParticipantCountError returns an error for invalid participant count
 func NewInvalidParticipantCountError(matchType string, expected, actual int) error {
 	return sharedErrors.NewValidationError(
 		"participants",
-		ErrInvalidParticipantCount+": "+matchType+" requires "+string(expected)+" participants, but got "+string(actual),
+		ErrInvalidParticipantCount+": "+matchType+" requires "+strconv.Itoa(expected)+" participants, but got "+strconv.Itoa(actual),
 	)
 }
 
@@ -51,4 +58,45 @@ func NewRequiredFieldError(fieldName string) error {
 		fieldName,
 		ErrRequiredField,
 	)
-}
\ No newline at end of file
+}
+
+// NewInvalidDateRangeError returns an error when a date range filter ends before it starts
+func NewInvalidDateRangeError() error {
+	return sharedErrors.NewValidationError(
+		"filter",
+		ErrInvalidDateRange,
+	)
+}
+
+// NewFormatSourceRequiredError returns an error when a match is started with
+// both or neither of a format and a format preset
+func NewFormatSourceRequiredError() error {
+	return sharedErrors.NewValidationError(
+		"matchUpFormat",
+		ErrFormatSourceRequired,
+	)
+}
+
+// NewInvalidImportContentError returns an error when an imported file cannot be parsed or replayed
+func NewInvalidImportContentError(reason string) error {
+	return sharedErrors.NewValidationError(
+		"content",
+		ErrInvalidImportContent+": "+reason,
+	)
+}
+
+// NewChartingSinglesOnlyError returns an error when importing charting notation into a doubles match
+func NewChartingSinglesOnlyError() error {
+	return sharedErrors.NewValidationError(
+		"format",
+		ErrChartingSinglesOnly,
+	)
+}
+
+// NewSamePlayerError returns an error when comparing a player's record against themselves
+func NewSamePlayerError() error {
+	return sharedErrors.NewValidationError(
+		"userB",
+		ErrSamePlayer,
+	)
+}
@@ -0,0 +1,15 @@
+package errors
+
+import (
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+)
+
+// Visibility error constants
+const (
+	ErrVisibilityNotOwner = "only the matchup owner can change who can see it"
+)
+
+// NewVisibilityNotOwnerError returns an error when someone other than the owner changes the visibility
+func NewVisibilityNotOwnerError() error {
+	return sharedErrors.NewForbiddenError(ErrVisibilityNotOwner)
+}
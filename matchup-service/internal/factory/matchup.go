@@ -1,9 +1,11 @@
 package factory
 
 import (
+	"strings"
 	"time"
 
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -15,45 +17,78 @@ func NewMatchUpFactory() *MatchUpFactory {
 	return &MatchUpFactory{}
 }
 
-// CreateFromInput creates a new MatchUp from InitiateMatchUpInput
-func (f *MatchUpFactory) CreateMatchUpFromInitiateMatchUpInput(ownerID primitive.ObjectID, input model.InitiateMatchUpInput) *model.MatchUp {
+// CreateFromInput creates a new MatchUp from InitiateMatchUpInput. The format
+// is resolved by the caller, since it may come from the input or from a preset.
+func (f *MatchUpFactory) CreateMatchUpFromInitiateMatchUpInput(ownerID primitive.ObjectID, input model.InitiateMatchUpInput, format *model.MatchUpFormat) *model.MatchUp {
 	now := time.Now()
 
+	trackingStyle := model.MatchUpTrackingStyleBeginner
+	if input.TrackingStyle != nil {
+		trackingStyle = *input.TrackingStyle
+	}
+
+	visibility := model.MatchUpVisibilityPrivate
+	if input.Visibility != nil {
+		visibility = *input.Visibility
+	}
+
 	// Create base matchup
 	matchUp := &model.MatchUp{
 		Owner:              ownerID,
+		MatchUpFormat:      format,
 		MatchUpTracker:     input.MatchUpTracker,
+		Trackers:           []primitive.ObjectID{},
+		Visibility:         visibility,
 		MatchUpType:        input.MatchUpType,
 		MatchUpStatus:      model.MatchUpStatusScheduled,
+		TrackingStyle:      trackingStyle,
 		InitialServer:      input.InitialServer,
 		CurrentServer:      input.InitialServer,
 		FirstShot:          nil,
 		LastShot:           nil,
 		Winner:             nil,
 		Loser:              nil,
-		ScheduledStartTime: nil,
+		ScheduledStartTime: input.ScheduledStartTime,
 		StartTime:          nil,
 		EndTime:            nil,
+		StatusHistory:      []*model.MatchUpStatusChange{},
 		CreatedAt:          now,
 		LastUpdated:        now,
 	}
 
-	// Set format from input or use default if not provided
-	if input.MatchUpFormat != nil {
-		matchUp.MatchUpFormat = f.convertMatchUpFormat(input.MatchUpFormat)
-	}
-
 	// Set participants from input
 	matchUp.Participants = f.convertParticipants(input.Participants)
+	f.inviteParticipants(ownerID, matchUp.Participants)
+
+	// The owner can always track, anyone else named as tracker is granted it
+	if input.MatchUpTracker != ownerID {
+		matchUp.Trackers = append(matchUp.Trackers, input.MatchUpTracker)
+	}
+
+	if input.CourtID != nil {
+		matchUp.Court = &model.TennisCourt{ID: *input.CourtID}
+	}
 
 	// Initialize score based on format
-	matchUp.CurrentScore = f.initializeScore()
+	matchUp.CurrentScore = f.initializeScore(matchUp.MatchUpFormat)
+
+	// Work out the serving rotation and where everyone starts
+	matchUp.ServingOrder = scoring.InitialServingOrder(matchUp.Participants, input.InitialServer)
+	matchUp.CurrentServiceBoxSide = scoring.ServiceBox(matchUp.CurrentScore)
+	matchUp.CourtSides = scoring.CourtSides(matchUp.CurrentScore)
+	matchUp.NextPointImportance = model.PointImportanceRegular
+	for _, participant := range matchUp.Participants {
+		if participant.ID == input.InitialServer && matchUp.MatchUpFormat != nil {
+			engine := scoring.NewEngine(matchUp.MatchUpFormat)
+			matchUp.NextPointImportance = engine.PointImportance(matchUp.CurrentScore, participant.TeamSide)
+		}
+	}
 
 	return matchUp
 }
 
-// convertMatchUpFormat maps format from input to domain model
-func (f *MatchUpFactory) convertMatchUpFormat(formatInput *model.MatchUpFormatInput) *model.MatchUpFormat {
+// ConvertMatchUpFormat maps format from input to domain model
+func (f *MatchUpFactory) ConvertMatchUpFormat(formatInput *model.MatchUpFormatInput) *model.MatchUpFormat {
 	format := &model.MatchUpFormat{
 		NumberOfSets: formatInput.NumberOfSets,
 		SetFormat: &model.SetFormat{
@@ -93,41 +128,56 @@ func (f *MatchUpFactory) convertMatchUpFormat(formatInput *model.MatchUpFormatIn
 	return format
 }
 
-// initializeScore creates an initial score state based on the match format
-func (f *MatchUpFactory) initializeScore() *model.MatchUpScore {
-	// Create empty score with correct number of sets
-	score := &model.MatchUpScore{
-		Sets:            make([]*model.SetScore, 0),
-		IsMatchComplete: false,
+// CreateMatchUpFormatPresetFromInput creates a new preset owned by the given user
+func (f *MatchUpFactory) CreateMatchUpFormatPresetFromInput(ownerID primitive.ObjectID, input model.CreateMatchUpFormatPresetInput) *model.MatchUpFormatPreset {
+	return &model.MatchUpFormatPreset{
+		ID:            primitive.NewObjectID(),
+		Name:          strings.TrimSpace(input.Name),
+		Description:   input.Description,
+		Owner:         &ownerID,
+		IsBuiltIn:     false,
+		MatchUpFormat: f.ConvertMatchUpFormat(input.MatchUpFormat),
+		CreatedAt:     time.Now(),
 	}
+}
 
-	// Initialize first set
-	firstSet := &model.SetScore{
-		SetIndex:         1,
-		IsCompleted:      false,
-		IsTiebreakActive: false,
-		Sides:            make([]*model.SideSetScore, 2),
+// CreateGuestClaim creates a pending invitation for a user to claim a guest participant
+func (f *MatchUpFactory) CreateGuestClaim(ownerID primitive.ObjectID, input model.SendGuestClaimInput, guest *model.Participant) *model.GuestClaim {
+	now := time.Now()
+	return &model.GuestClaim{
+		Owner:             ownerID,
+		Claimant:          input.Claimant,
+		GuestID:           guest.ID,
+		DisplayName:       guest.DisplayName,
+		MatchUpID:         input.MatchUpID,
+		Status:            model.GuestClaimStatusPending,
+		ClaimedMatchUpIds: []primitive.ObjectID{},
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	}
+}
 
-	// Initialize sides for Team A and Team B
-	firstSet.Sides[0] = &model.SideSetScore{
-		Side:           model.TeamSideTeamA,
-		GamesWon:       0,
-		InGameScore:    model.InGameScoreZero,
-		TiebreakPoints: nil,
+// initializeScore creates an initial score state based on the match format
+func (f *MatchUpFactory) initializeScore(format *model.MatchUpFormat) *model.MatchUpScore {
+	if format != nil {
+		return scoring.NewEngine(format).InitialScore()
 	}
 
-	firstSet.Sides[1] = &model.SideSetScore{
-		Side:           model.TeamSideTeamB,
-		GamesWon:       0,
-		InGameScore:    model.InGameScoreZero,
-		TiebreakPoints: nil,
+	// Create empty score with a single set when no format is known yet
+	return &model.MatchUpScore{
+		Sets: []*model.SetScore{
+			{
+				SetIndex:         1,
+				IsCompleted:      false,
+				IsTiebreakActive: false,
+				Sides: []*model.SideSetScore{
+					{Side: model.TeamSideTeamA, GamesWon: 0, InGameScore: model.InGameScoreZero},
+					{Side: model.TeamSideTeamB, GamesWon: 0, InGameScore: model.InGameScoreZero},
+				},
+			},
+		},
+		IsMatchComplete: false,
 	}
-
-	// Add first set to score
-	score.Sets = append(score.Sets, firstSet)
-
-	return score
 }
 
 // convertParticipants maps participants from input to domain model
@@ -155,3 +205,92 @@ func (f *MatchUpFactory) convertParticipants(participantsInput []*model.Particip
 
 	return participants
 }
+
+// inviteParticipants sends every registered participant other than the owner
+// an invitation to play. Guests have no account to answer with.
+func (f *MatchUpFactory) inviteParticipants(ownerID primitive.ObjectID, participants []*model.Participant) {
+	for _, participant := range participants {
+		if participant.IsGuest || participant.ID == ownerID {
+			continue
+		}
+		pending := model.InvitationStatusPending
+		participant.InvitationStatus = &pending
+	}
+}
+
+// CreateMatchUpShotFromAddShotInput creates a new MatchUpShot from AddShotInput.
+// Scoring related fields, including point importance, are filled in by the
+// caller once the shot is resolved.
+func (f *MatchUpFactory) CreateMatchUpShotFromAddShotInput(input model.AddShotInput, hitterSide model.TeamSide) *model.MatchUpShot {
+	return &model.MatchUpShot{
+		ID:                primitive.NewObjectID(),
+		MatchUpID:         input.MatchUpID,
+		HitterID:          input.HitterID,
+		HitterSide:        hitterSide,
+		ShotType:          input.ShotType,
+		GroundStrokeType:  input.GroundStrokeType,
+		GroundStrokeStyle: input.GroundStrokeStyle,
+		ServeStyle:        input.ServeStyle,
+		ServeNumber:       input.ServeNumber,
+		ServiceBoxSide:    input.ServiceBoxSide,
+		ShotOutcome:       input.ShotOutcome,
+		PointWinReason:    input.PointWinReason,
+		BounceLocation:    courtPosition(input.BounceLocation),
+		HitterPosition:    courtPosition(input.HitterPosition),
+		ClientShotID:      input.ClientShotID,
+		Timestamp:         time.Now(),
+	}
+}
+
+// courtPosition copies an optional position from an input
+func courtPosition(input *model.CourtPositionInput) *model.CourtPosition {
+	if input == nil {
+		return nil
+	}
+	return &model.CourtPosition{X: input.X, Y: input.Y}
+}
+
+// CreateMatchUpShotFromAddPointInput creates the record of a point played
+// without its rally. The hitter is whoever the point is attributed to: its
+// outcome is WON_POINT when their side won the point and ERROR when it lost.
+func (f *MatchUpFactory) CreateMatchUpShotFromAddPointInput(input model.AddPointInput, hitter *model.Participant) *model.MatchUpShot {
+	outcome := model.ShotOutcomeWonPoint
+	if hitter.TeamSide != input.PointWinner {
+		outcome = model.ShotOutcomeError
+	}
+
+	return &model.MatchUpShot{
+		ID:             primitive.NewObjectID(),
+		MatchUpID:      input.MatchUpID,
+		HitterID:       hitter.ID,
+		HitterSide:     hitter.TeamSide,
+		ShotType:       model.ShotTypePoint,
+		ShotOutcome:    outcome,
+		PointWinReason: input.PointWinReason,
+		Timestamp:      time.Now(),
+	}
+}
+
+// CreateMatchUpShotFromAddMatchEventInput creates the record of a let or a
+// penalty. The hitter is the player the event is charged to, the server for
+// a let. A penalty is an ERROR by that player, since their side loses the
+// point; a let doesn't end the point.
+func (f *MatchUpFactory) CreateMatchUpShotFromAddMatchEventInput(input model.AddMatchEventInput, player *model.Participant) *model.MatchUpShot {
+	eventType := input.EventType
+	outcome := model.ShotOutcomeError
+	if eventType == model.MatchEventTypeLet {
+		outcome = model.ShotOutcomeContinuedRally
+	}
+
+	return &model.MatchUpShot{
+		ID:          primitive.NewObjectID(),
+		MatchUpID:   input.MatchUpID,
+		HitterID:    player.ID,
+		HitterSide:  player.TeamSide,
+		ShotType:    model.ShotTypeMatchEvent,
+		MatchEvent:  &eventType,
+		Violation:   input.Violation,
+		ShotOutcome: outcome,
+		Timestamp:   time.Now(),
+	}
+}
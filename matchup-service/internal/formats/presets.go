@@ -0,0 +1,131 @@
+package formats
+
+import (
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/schema/scalars"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// builtInCreatedAt is reported as the creation time of every built-in preset
+var builtInCreatedAt = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// builtIn describes a preset shipped with the service. IDs are fixed so
+// clients can refer to a built-in preset without looking it up first.
+type builtIn struct {
+	id          string
+	name        string
+	description string
+	format      func() *model.MatchUpFormat
+}
+
+var builtIns = []builtIn{
+	{
+		id:          "000000000000000000000001",
+		name:        "Best of 3, advantage",
+		description: "Best of three advantage sets with a 7-point tiebreak at 6-6.",
+		format: func() *model.MatchUpFormat {
+			return &model.MatchUpFormat{
+				NumberOfSets: 3,
+				SetFormat:    tiebreakSet(6, model.DeuceTypeNormalDeuce, 7, true, 6),
+			}
+		},
+	},
+	{
+		id:          "000000000000000000000002",
+		name:        "Best of 3, match tiebreak",
+		description: "Best of three advantage sets with a 10-point match tiebreak in lieu of the final set.",
+		format: func() *model.MatchUpFormat {
+			return &model.MatchUpFormat{
+				NumberOfSets:   3,
+				SetFormat:      tiebreakSet(6, model.DeuceTypeNormalDeuce, 7, true, 6),
+				FinalSetFormat: tiebreakSet(1, model.DeuceTypeNormalDeuce, 10, true, 0),
+			}
+		},
+	},
+	{
+		id:          "000000000000000000000003",
+		name:        "Fast4",
+		description: "Best of three sets to four games with no-ad scoring and a 5-point sudden-death tiebreak at 3-3.",
+		format: func() *model.MatchUpFormat {
+			return &model.MatchUpFormat{
+				NumberOfSets: 3,
+				SetFormat:    tiebreakSet(4, model.DeuceTypeSuddenDeath, 5, false, 3),
+			}
+		},
+	},
+	{
+		id:          "000000000000000000000004",
+		name:        "8-game pro set",
+		description: "A single advantage set to eight games with a 7-point tiebreak at 8-8.",
+		format: func() *model.MatchUpFormat {
+			return &model.MatchUpFormat{
+				NumberOfSets: 1,
+				SetFormat:    tiebreakSet(8, model.DeuceTypeNormalDeuce, 7, true, 8),
+			}
+		},
+	},
+	{
+		id:          "000000000000000000000005",
+		name:        "College doubles, no-ad",
+		description: "A single no-ad set to six games with a 7-point tiebreak at 6-6, as played in college doubles.",
+		format: func() *model.MatchUpFormat {
+			return &model.MatchUpFormat{
+				NumberOfSets: 1,
+				SetFormat:    tiebreakSet(6, model.DeuceTypeSuddenDeath, 7, true, 6),
+			}
+		},
+	},
+}
+
+// BuiltInPresets returns the standard presets in display order. Each call
+// returns fresh copies, so callers may modify them freely.
+func BuiltInPresets() []*model.MatchUpFormatPreset {
+	presets := make([]*model.MatchUpFormatPreset, len(builtIns))
+	for i, preset := range builtIns {
+		presets[i] = preset.toModel()
+	}
+	return presets
+}
+
+// FindBuiltInPreset returns the built-in preset with the given ID, if there is one
+func FindBuiltInPreset(id primitive.ObjectID) (*model.MatchUpFormatPreset, bool) {
+	for _, preset := range builtIns {
+		if preset.id == id.Hex() {
+			return preset.toModel(), true
+		}
+	}
+	return nil, false
+}
+
+// toModel converts a built-in definition into the API type
+func (b builtIn) toModel() *model.MatchUpFormatPreset {
+	id, err := primitive.ObjectIDFromHex(b.id)
+	if err != nil {
+		panic(err)
+	}
+	description := b.description
+	return &model.MatchUpFormatPreset{
+		ID:            id,
+		Name:          b.name,
+		Description:   &description,
+		IsBuiltIn:     true,
+		MatchUpFormat: b.format(),
+		CreatedAt:     builtInCreatedAt,
+	}
+}
+
+// tiebreakSet builds a set format that is decided by a tiebreak at tiebreakAt games all
+func tiebreakSet(games int, deuceType model.DeuceType, points int, winByTwo bool, tiebreakAt int) *model.SetFormat {
+	return &model.SetFormat{
+		NumberOfGames: scalars.NumberOfGames(games),
+		DeuceType:     deuceType,
+		MustWinByTwo:  false,
+		TiebreakFormat: &model.TiebreakFormat{
+			Points:       scalars.TiebreakPoints(points),
+			MustWinByTwo: winByTwo,
+			TiebreakAt:   &tiebreakAt,
+		},
+	}
+}
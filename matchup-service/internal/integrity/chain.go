@@ -0,0 +1,240 @@
+package integrity
+
+import (
+	"sort"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Kind names a kind of problem with a matchup's shots
+type Kind string
+
+const (
+	// BrokenLink is a firstShot, lastShot, prevShotId or nextShotId that
+	// doesn't lead where the chain goes
+	BrokenLink Kind = "BROKEN_LINK"
+	// OrphanedShot is a shot of the matchup that no link leads to
+	OrphanedShot Kind = "ORPHANED_SHOT"
+	// TimestampDisorder is a shot recorded earlier than the shot it follows,
+	// so reading the shots by timestamp puts them in the wrong order
+	TimestampDisorder Kind = "TIMESTAMP_DISORDER"
+	// StaleSnapshot is a matchStateAfterShot that differs from scoring the
+	// shots again
+	StaleSnapshot Kind = "STALE_SNAPSHOT"
+	// StaleMatchUp is a matchup whose score, last shot or winner differs
+	// from scoring its shots again
+	StaleMatchUp Kind = "STALE_MATCHUP"
+	// Unreplayable is a shot the scoring rules reject when the chain is
+	// scored again, which can't be repaired automatically
+	Unreplayable Kind = "UNREPLAYABLE"
+)
+
+// Problem is one thing wrong with a matchup's shots
+type Problem struct {
+	Kind Kind
+	// The shot the problem was found on, nil for the matchup itself
+	ShotID *primitive.ObjectID
+	Detail string
+}
+
+// String describes the problem on one line
+func (p Problem) String() string {
+	if p.ShotID == nil {
+		return string(p.Kind) + ": " + p.Detail
+	}
+	return string(p.Kind) + " shot " + p.ShotID.Hex() + ": " + p.Detail
+}
+
+// Report lists the problems found with one matchup's shots
+type Report struct {
+	MatchUpID primitive.ObjectID
+	Problems  []Problem
+	// Whether the problems were repaired
+	Repaired bool
+}
+
+// Chain is a matchup's shots put back in linked-list order
+type Chain struct {
+	// Shots in order from the first, including undone shots after the last one
+	Shots []*model.MatchUpShot
+	// Active is how many of the shots lead up to and include the matchup's
+	// last shot. The rest have been undone.
+	Active int
+	// Orphans are the matchup's shots that no link leads to
+	Orphans  []*model.MatchUpShot
+	Problems []Problem
+}
+
+// Walk follows a matchup's shots from its first shot. Where a nextShotId is
+// missing or loops back, the walk carries on with the shot that names the
+// last one reached as its prevShotId, so a single bad link doesn't lose the
+// rest of the chain.
+func Walk(matchUp *model.MatchUp, shots []*model.MatchUpShot) *Chain {
+	chain := &Chain{}
+	byID := make(map[primitive.ObjectID]*model.MatchUpShot, len(shots))
+	children := make(map[primitive.ObjectID][]*model.MatchUpShot)
+	var heads []*model.MatchUpShot
+	for _, shot := range shots {
+		byID[shot.ID] = shot
+		if shot.PrevShotID == nil {
+			heads = append(heads, shot)
+		} else {
+			children[*shot.PrevShotID] = append(children[*shot.PrevShotID], shot)
+		}
+	}
+	visited := make(map[primitive.ObjectID]bool, len(shots))
+	// firstUnvisited picks the earliest recorded shot not on the chain yet
+	firstUnvisited := func(candidates []*model.MatchUpShot) *model.MatchUpShot {
+		var first *model.MatchUpShot
+		for _, shot := range candidates {
+			if !visited[shot.ID] && (first == nil || shot.Timestamp.Before(first.Timestamp)) {
+				first = shot
+			}
+		}
+		return first
+	}
+
+	var current *model.MatchUpShot
+	switch {
+	case matchUp.FirstShot != nil:
+		current = byID[*matchUp.FirstShot]
+		if current == nil {
+			chain.problem(BrokenLink, nil, "firstShot "+matchUp.FirstShot.Hex()+" does not exist")
+			current = firstUnvisited(heads)
+		}
+	case len(shots) > 0:
+		chain.problem(BrokenLink, nil, "the matchup has shots but no firstShot")
+		current = firstUnvisited(heads)
+	}
+
+	var prev *model.MatchUpShot
+	for current != nil {
+		visited[current.ID] = true
+		chain.Shots = append(chain.Shots, current)
+		switch {
+		case prev == nil && current.PrevShotID != nil:
+			chain.problem(BrokenLink, &current.ID, "the first shot has prevShotId "+current.PrevShotID.Hex())
+		case prev != nil && (current.PrevShotID == nil || *current.PrevShotID != prev.ID):
+			chain.problem(BrokenLink, &current.ID, "prevShotId does not name the shot before it, "+prev.ID.Hex())
+		}
+		if prev != nil && current.Timestamp.Before(prev.Timestamp) {
+			chain.problem(TimestampDisorder, &current.ID, "recorded at "+current.Timestamp.Format(time.RFC3339Nano)+
+				", before the shot it follows at "+prev.Timestamp.Format(time.RFC3339Nano))
+		}
+
+		prev, current = current, nil
+		if prev.NextShotID == nil {
+			break
+		}
+		next, ok := byID[*prev.NextShotID]
+		switch {
+		case !ok:
+			chain.problem(BrokenLink, &prev.ID, "nextShotId "+prev.NextShotID.Hex()+" does not exist")
+		case visited[next.ID]:
+			chain.problem(BrokenLink, &prev.ID, "nextShotId "+prev.NextShotID.Hex()+" loops back")
+		default:
+			current = next
+			continue
+		}
+		current = firstUnvisited(children[prev.ID])
+	}
+
+	// Everything from the first shot up to the last is active
+	if matchUp.LastShot != nil {
+		chain.Active = -1
+		for i, shot := range chain.Shots {
+			if shot.ID == *matchUp.LastShot {
+				chain.Active = i + 1
+				break
+			}
+		}
+		if chain.Active < 0 {
+			chain.problem(BrokenLink, nil, "lastShot "+matchUp.LastShot.Hex()+" is not on the chain")
+			chain.Active = len(chain.Shots)
+		}
+	}
+
+	for _, shot := range shots {
+		if !visited[shot.ID] {
+			chain.Orphans = append(chain.Orphans, shot)
+		}
+	}
+	sort.SliceStable(chain.Orphans, func(i, j int) bool {
+		return chain.Orphans[i].Timestamp.Before(chain.Orphans[j].Timestamp)
+	})
+	for _, orphan := range chain.Orphans {
+		chain.problem(OrphanedShot, &orphan.ID, "no link leads to the shot")
+	}
+	return chain
+}
+
+// problem records a problem found while walking
+func (c *Chain) problem(kind Kind, shotID *primitive.ObjectID, detail string) {
+	var id *primitive.ObjectID
+	if shotID != nil {
+		copied := *shotID
+		id = &copied
+	}
+	c.Problems = append(c.Problems, Problem{Kind: kind, ShotID: id, Detail: detail})
+}
+
+// Relink points every shot's links at its neighbours in the chain and moves
+// any shot recorded before the one it follows to just after it, so reading
+// the shots by timestamp gives the chain's order. It returns the shots it changed.
+func (c *Chain) Relink() []*model.MatchUpShot {
+	var changed []*model.MatchUpShot
+	for i, shot := range c.Shots {
+		var prevID, nextID *primitive.ObjectID
+		if i > 0 {
+			prevID = &c.Shots[i-1].ID
+		}
+		if i < len(c.Shots)-1 {
+			nextID = &c.Shots[i+1].ID
+		}
+
+		modified := false
+		if !SameID(shot.PrevShotID, prevID) {
+			shot.PrevShotID = copyID(prevID)
+			modified = true
+		}
+		if !SameID(shot.NextShotID, nextID) {
+			shot.NextShotID = copyID(nextID)
+			modified = true
+		}
+		if i > 0 && shot.Timestamp.Before(c.Shots[i-1].Timestamp) {
+			// Timestamps are stored to the millisecond
+			shot.Timestamp = c.Shots[i-1].Timestamp.Add(time.Millisecond)
+			modified = true
+		}
+		if modified {
+			changed = append(changed, shot)
+		}
+	}
+	return changed
+}
+
+// First returns the ID of the chain's first shot, nil if it has none
+func (c *Chain) First() *primitive.ObjectID {
+	if len(c.Shots) == 0 {
+		return nil
+	}
+	return copyID(&c.Shots[0].ID)
+}
+
+// SameID reports whether two optional IDs are both missing or both the same ID
+func SameID(a, b *primitive.ObjectID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func copyID(id *primitive.ObjectID) *primitive.ObjectID {
+	if id == nil {
+		return nil
+	}
+	copied := *id
+	return &copied
+}
@@ -0,0 +1,113 @@
+package integrity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// linked builds a chain of shots a second apart, linked both ways, and a
+// matchup whose last shot is the one at index last
+func linked(count, last int) (*model.MatchUp, []*model.MatchUpShot) {
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	shots := make([]*model.MatchUpShot, count)
+	for i := range shots {
+		shots[i] = &model.MatchUpShot{ID: primitive.NewObjectID(), Timestamp: start.Add(time.Duration(i) * time.Second)}
+		if i > 0 {
+			shots[i].PrevShotID = &shots[i-1].ID
+			shots[i-1].NextShotID = &shots[i].ID
+		}
+	}
+	matchUp := &model.MatchUp{ID: primitive.NewObjectID()}
+	if count > 0 {
+		matchUp.FirstShot = &shots[0].ID
+		matchUp.LastShot = &shots[last].ID
+	}
+	return matchUp, shots
+}
+
+func kinds(problems []Problem) []Kind {
+	result := make([]Kind, len(problems))
+	for i, problem := range problems {
+		result[i] = problem.Kind
+	}
+	return result
+}
+
+func TestWalkIntactChain(t *testing.T) {
+	matchUp, shots := linked(4, 2)
+
+	chain := Walk(matchUp, shots)
+	assert.Empty(t, chain.Problems)
+	assert.Equal(t, shots, chain.Shots)
+	assert.Equal(t, 3, chain.Active)
+	assert.Empty(t, chain.Relink())
+
+	// Everything undone
+	matchUp.LastShot = nil
+	assert.Equal(t, 0, Walk(matchUp, shots).Active)
+
+	assert.Empty(t, Walk(&model.MatchUp{}, nil).Shots)
+}
+
+func TestWalkFollowsPrevLinksPastBrokenNext(t *testing.T) {
+	matchUp, shots := linked(4, 3)
+	missing := primitive.NewObjectID()
+	shots[1].NextShotID = &missing
+	orphan := &model.MatchUpShot{ID: primitive.NewObjectID(), PrevShotID: &missing, Timestamp: shots[3].Timestamp}
+
+	chain := Walk(matchUp, append(shots, orphan))
+	assert.Equal(t, shots, chain.Shots)
+	assert.Equal(t, []*model.MatchUpShot{orphan}, chain.Orphans)
+	assert.Equal(t, []Kind{BrokenLink, OrphanedShot}, kinds(chain.Problems))
+	assert.Equal(t, shots[1].ID, *chain.Problems[0].ShotID)
+
+	changed := chain.Relink()
+	require.Len(t, changed, 1)
+	assert.Equal(t, shots[2].ID, *shots[1].NextShotID)
+}
+
+func TestWalkDetectsLoopsAndBadPrevLinks(t *testing.T) {
+	matchUp, shots := linked(3, 2)
+	shots[2].NextShotID = &shots[0].ID
+	shots[1].PrevShotID = nil
+
+	chain := Walk(matchUp, shots)
+	assert.Equal(t, shots, chain.Shots)
+	assert.Equal(t, []Kind{BrokenLink, BrokenLink}, kinds(chain.Problems))
+
+	assert.Len(t, chain.Relink(), 2)
+	assert.Nil(t, shots[2].NextShotID)
+	assert.Equal(t, shots[0].ID, *shots[1].PrevShotID)
+}
+
+func TestWalkWithoutFirstShot(t *testing.T) {
+	matchUp, shots := linked(3, 2)
+	matchUp.FirstShot = nil
+	stray := primitive.NewObjectID()
+	matchUp.LastShot = &stray
+
+	chain := Walk(matchUp, shots)
+	assert.Equal(t, shots, chain.Shots)
+	assert.Equal(t, 3, chain.Active)
+	assert.Equal(t, []Kind{BrokenLink, BrokenLink}, kinds(chain.Problems))
+	assert.Equal(t, shots[0].ID, *chain.First())
+}
+
+func TestWalkDetectsTimestampDisorder(t *testing.T) {
+	matchUp, shots := linked(3, 2)
+	shots[1].Timestamp = shots[0].Timestamp.Add(-time.Minute)
+
+	chain := Walk(matchUp, shots)
+	assert.Equal(t, []Kind{TimestampDisorder}, kinds(chain.Problems))
+	assert.Equal(t, shots[1].ID, *chain.Problems[0].ShotID)
+
+	// The shot moves to just after the shot it follows
+	assert.Len(t, chain.Relink(), 1)
+	assert.Equal(t, shots[0].Timestamp.Add(time.Millisecond), shots[1].Timestamp)
+	assert.Empty(t, Walk(matchUp, shots).Problems)
+}
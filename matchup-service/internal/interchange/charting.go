@@ -0,0 +1,408 @@
+package interchange
+
+import (
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+)
+
+// The charting notation follows the Match Charting Project: each point is
+// written as the first and second serve, e.g. "0e" then "0fbf*". A serve
+// starts with its direction (4 wide, 5 body, 6 T, 0 unknown), every rally
+// shot is a letter, and the last shot ends with * for a winner or ace, # for a
+// forced error or unreturnable serve and @ for an unforced error. Digits and
+// other marks after a shot describe direction, depth and court position,
+// which CourtIQ doesn't track and skips on import. A point whose rally
+// wasn't charted is written as S when the server won it and R when the
+// returner did, which is how points tracked at BEGINNER are exported. A let
+// is a c before the serve it was called on. The notation has no penalties,
+// so a point decided by one is written as S or R too.
+const (
+	serveDirections       = "0456"
+	unknownServeDirection = "0"
+	errorTypes            = "nwdxge!"
+	unknownErrorType      = "e"
+	winnerEnding          = '*'
+	forcedEnding          = '#'
+	unforcedEnding        = '@'
+	letServe              = 'c'
+	serverWonPoint        = "S"
+	returnerWonPoint      = "R"
+)
+
+// chartingColumns is the header of the charting format, one row per point.
+// Score columns give the score before the point, with player 1 as TEAM_A.
+var chartingColumns = []string{"Pt", "Set1", "Set2", "Gm1", "Gm2", "Pts", "Svr", "1st", "2nd", "PtWinner"}
+
+// chartedShot is what a rally letter stands for
+type chartedShot struct {
+	shotType   model.ShotType
+	strokeType model.GroundStrokeType
+	style      model.GroundStrokeStyle
+}
+
+// rallyShots maps rally letters to shots. Half volleys and swinging volleys
+// are read as volleys and tricks as ground strokes of unknown type.
+var rallyShots = map[rune]chartedShot{
+	'f': {model.ShotTypeGroundStroke, model.GroundStrokeTypeForehand, ""},
+	'b': {model.ShotTypeGroundStroke, model.GroundStrokeTypeBackhand, ""},
+	'r': {model.ShotTypeGroundStroke, model.GroundStrokeTypeForehand, model.GroundStrokeStyleSlice},
+	's': {model.ShotTypeGroundStroke, model.GroundStrokeTypeBackhand, model.GroundStrokeStyleSlice},
+	'l': {model.ShotTypeGroundStroke, model.GroundStrokeTypeForehand, model.GroundStrokeStyleLob},
+	'm': {model.ShotTypeGroundStroke, model.GroundStrokeTypeBackhand, model.GroundStrokeStyleLob},
+	'u': {model.ShotTypeGroundStroke, model.GroundStrokeTypeForehand, model.GroundStrokeStyleDropShot},
+	'y': {model.ShotTypeGroundStroke, model.GroundStrokeTypeBackhand, model.GroundStrokeStyleDropShot},
+	'o': {model.ShotTypeGroundStroke, model.GroundStrokeTypeForehand, model.GroundStrokeStyleSmash},
+	'p': {model.ShotTypeGroundStroke, model.GroundStrokeTypeBackhand, model.GroundStrokeStyleSmash},
+	't': {model.ShotTypeGroundStroke, "", ""},
+	'q': {model.ShotTypeGroundStroke, "", ""},
+	'v': {model.ShotTypeVolley, "", ""},
+	'z': {model.ShotTypeVolley, "", ""},
+	'h': {model.ShotTypeVolley, "", ""},
+	'i': {model.ShotTypeVolley, "", ""},
+	'j': {model.ShotTypeVolley, "", ""},
+	'k': {model.ShotTypeVolley, "", ""},
+}
+
+// encodeCharting writes one row per completed point. A point still in
+// progress at the end of the shot list is left out.
+func encodeCharting(matchUp *model.MatchUp, shots []*model.MatchUpShot) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+	if err := writer.Write(chartingColumns); err != nil {
+		return "", err
+	}
+
+	before := scoring.NewEngine(matchUp.MatchUpFormat).InitialScore()
+	var point []*model.MatchUpShot
+	number := 0
+	for _, shot := range shots {
+		point = append(point, shot)
+		state := shot.MatchStateAfterShot
+		if state == nil || !state.PointCompleted || state.PointWinner == nil {
+			continue
+		}
+
+		number++
+		if err := writer.Write(chartingRow(number, before, point, *state.PointWinner)); err != nil {
+			return "", err
+		}
+		before = state.Score
+		point = nil
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// chartingRow writes a completed point with the score before it
+func chartingRow(number int, before *model.MatchUpScore, point []*model.MatchUpShot, winner model.TeamSide) []string {
+	serverSide := point[0].PointContext.ServerSide
+	set := scoring.CurrentSet(before)
+	server, receiver := scoring.SideScore(set, serverSide), scoring.SideScore(set, scoring.Opponent(serverSide))
+
+	points := pointsText(server.InGameScore) + "-" + pointsText(receiver.InGameScore)
+	if set.IsTiebreakActive {
+		points = strconv.Itoa(scoring.TiebreakPoints(server)) + "-" + strconv.Itoa(scoring.TiebreakPoints(receiver))
+	}
+
+	last := point[len(point)-1]
+	uncharted := point[0].ShotType == model.ShotTypePoint || last.ShotType == model.ShotTypeMatchEvent
+	first, second := "", ""
+	switch {
+	case uncharted && winner == serverSide:
+		first = serverWonPoint
+	case uncharted:
+		first = returnerWonPoint
+	default:
+		first = chartingCode(point)
+		if split := firstServeShots(point); split > 0 {
+			first, second = chartingCode(point[:split]), chartingCode(point[split:])
+		}
+	}
+
+	return []string{
+		strconv.Itoa(number),
+		strconv.Itoa(scoring.SetsWon(before, model.TeamSideTeamA)),
+		strconv.Itoa(scoring.SetsWon(before, model.TeamSideTeamB)),
+		strconv.Itoa(scoring.SideScore(set, model.TeamSideTeamA).GamesWon),
+		strconv.Itoa(scoring.SideScore(set, model.TeamSideTeamB).GamesWon),
+		points,
+		playerNumber(serverSide),
+		first,
+		second,
+		playerNumber(winner),
+	}
+}
+
+// firstServeShots returns how many of a point's shots, lets included, go in
+// the 1st column when the first serve was a fault, or 0 when it went in
+func firstServeShots(point []*model.MatchUpShot) int {
+	for i, shot := range point {
+		if shot.ShotType != model.ShotTypeServe {
+			continue
+		}
+		if shot.ShotOutcome == model.ShotOutcomeFirstFault && i < len(point)-1 {
+			return i + 1
+		}
+		return 0
+	}
+	return 0
+}
+
+// chartingCode writes a serve and the rally that followed it
+func chartingCode(shots []*model.MatchUpShot) string {
+	var code strings.Builder
+	for _, shot := range shots {
+		if scoring.IsLet(shot) {
+			code.WriteRune(letServe)
+			continue
+		}
+		if shot.ShotType == model.ShotTypeServe {
+			code.WriteString(unknownServeDirection)
+		} else {
+			code.WriteRune(rallyCode(shot))
+		}
+	}
+
+	last := shots[len(shots)-1]
+	switch {
+	case last.ShotType == model.ShotTypeServe && last.ShotOutcome == model.ShotOutcomeWonPoint:
+		if last.PointWinReason == nil || *last.PointWinReason == model.PointWinReasonAce {
+			code.WriteRune(winnerEnding)
+		} else {
+			code.WriteRune(forcedEnding)
+		}
+	case last.ShotType == model.ShotTypeServe && last.ShotOutcome != model.ShotOutcomeContinuedRally:
+		// A fault has an error type but no ending
+		code.WriteString(unknownErrorType)
+	case last.ShotOutcome == model.ShotOutcomeWonPoint:
+		code.WriteRune(winnerEnding)
+	case last.ShotOutcome == model.ShotOutcomeError:
+		code.WriteString(unknownErrorType)
+		if last.PointWinReason != nil && *last.PointWinReason == model.PointWinReasonForcedError {
+			code.WriteRune(forcedEnding)
+		} else {
+			code.WriteRune(unforcedEnding)
+		}
+	}
+	return code.String()
+}
+
+// rallyCode returns the letter for a shot after the serve
+func rallyCode(shot *model.MatchUpShot) rune {
+	if shot.ShotType == model.ShotTypeVolley {
+		return 'v'
+	}
+	if shot.GroundStrokeType == nil {
+		return 'q'
+	}
+
+	backhand := *shot.GroundStrokeType == model.GroundStrokeTypeBackhand
+	letters := [2]rune{'f', 'b'}
+	if shot.GroundStrokeStyle != nil {
+		switch *shot.GroundStrokeStyle {
+		case model.GroundStrokeStyleSlice:
+			letters = [2]rune{'r', 's'}
+		case model.GroundStrokeStyleLob:
+			letters = [2]rune{'l', 'm'}
+		case model.GroundStrokeStyleDropShot:
+			letters = [2]rune{'u', 'y'}
+		case model.GroundStrokeStyleSmash:
+			letters = [2]rune{'o', 'p'}
+		}
+	}
+	if backhand {
+		return letters[1]
+	}
+	return letters[0]
+}
+
+// playerNumber returns the charting player number of a side
+func playerNumber(side model.TeamSide) string {
+	if side == model.TeamSideTeamA {
+		return "1"
+	}
+	return "2"
+}
+
+// decodeCharting reads the charting format. Only the 1st and 2nd columns are
+// used; the score is rebuilt by replaying the points.
+func decodeCharting(content string) (*Document, error) {
+	rows, header, err := readCSV(content)
+	if err != nil {
+		return nil, err
+	}
+	firstColumn, hasFirst := header["1st"]
+	secondColumn, hasSecond := header["2nd"]
+	if !hasFirst || !hasSecond {
+		return nil, internalErrors.NewInvalidImportContentError("missing column 1st or 2nd")
+	}
+
+	document := &Document{}
+	for i, row := range rows {
+		var first, second string
+		if firstColumn < len(row) {
+			first = strings.TrimSpace(row[firstColumn])
+		}
+		if secondColumn < len(row) {
+			second = strings.TrimSpace(row[secondColumn])
+		}
+		if first == "" && second == "" {
+			continue
+		}
+		if first == serverWonPoint || first == returnerWonPoint {
+			document.Shots = append(document.Shots, unchartedPoint(first == serverWonPoint))
+			continue
+		}
+
+		shots, faulted, err := parseServe(first, model.ServeNumberFirstServe)
+		if err == nil && faulted {
+			var secondShots []*ShotRecord
+			secondShots, _, err = parseServe(second, model.ServeNumberSecondServe)
+			shots = append(shots, secondShots...)
+		}
+		if err != nil {
+			return nil, internalErrors.NewInvalidImportContentError("point " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+		document.Shots = append(document.Shots, shots...)
+	}
+	return document, nil
+}
+
+// parseServe reads the code for one serve and the rally after it. It reports
+// faulted when a first serve missed and the point continues on the second serve.
+func parseServe(code string, serveNumber model.ServeNumber) ([]*ShotRecord, bool, error) {
+	chars := []rune(code)
+	var shots []*ShotRecord
+	i := 0
+	for i < len(chars) && chars[i] == letServe {
+		shots = append(shots, letRecord(serveNumber))
+		i++
+	}
+	if i >= len(chars) || !strings.ContainsRune(serveDirections, chars[i]) {
+		return nil, false, errors.New("a serve must start with a direction (0, 4, 5 or 6)")
+	}
+
+	serve := &ShotRecord{
+		Role:        RoleServer,
+		ShotType:    model.ShotTypeServe,
+		ServeNumber: &serveNumber,
+		ShotOutcome: model.ShotOutcomeContinuedRally,
+	}
+	shots = append(shots, serve)
+	current, next := serve, RoleReceiver
+	missed := false
+
+	for i++; i < len(chars); i++ {
+		c := chars[i]
+		if charted, ok := rallyShots[c]; ok {
+			current = charted.record(next)
+			shots = append(shots, current)
+			next = next.opposite()
+			missed = false
+			continue
+		}
+
+		switch {
+		case strings.ContainsRune(errorTypes, c):
+			missed = true
+		case c == winnerEnding || c == forcedEnding || c == unforcedEnding:
+			if err := endPoint(current, c); err != nil {
+				return nil, false, err
+			}
+			return shots, false, nil
+		}
+	}
+
+	// A serve with an error type and nothing after it is a fault
+	if current == serve && missed {
+		if serveNumber == model.ServeNumberFirstServe {
+			serve.ShotOutcome = model.ShotOutcomeFirstFault
+			return shots, true, nil
+		}
+		serve.ShotOutcome = model.ShotOutcomeError
+		return shots, false, nil
+	}
+	return nil, false, errors.New("the point does not end with *, # or @")
+}
+
+// letRecord records a let called on the server's serve
+func letRecord(serveNumber model.ServeNumber) *ShotRecord {
+	let := model.MatchEventTypeLet
+	return &ShotRecord{
+		Role:        RoleServer,
+		ShotType:    model.ShotTypeMatchEvent,
+		MatchEvent:  &let,
+		ServeNumber: &serveNumber,
+		ShotOutcome: model.ShotOutcomeContinuedRally,
+	}
+}
+
+// unchartedPoint records a point whose rally wasn't charted, attributed to the server
+func unchartedPoint(serverWon bool) *ShotRecord {
+	outcome := model.ShotOutcomeWonPoint
+	if !serverWon {
+		outcome = model.ShotOutcomeError
+	}
+	return &ShotRecord{
+		Role:        RoleServer,
+		ShotType:    model.ShotTypePoint,
+		ShotOutcome: outcome,
+	}
+}
+
+// endPoint applies the ending mark to the last shot of a point
+func endPoint(shot *ShotRecord, ending rune) error {
+	var outcome model.ShotOutcome
+	var reason model.PointWinReason
+	switch {
+	case shot.ShotType == model.ShotTypeServe && ending == winnerEnding:
+		outcome, reason = model.ShotOutcomeWonPoint, model.PointWinReasonAce
+	case shot.ShotType == model.ShotTypeServe && ending == forcedEnding:
+		outcome, reason = model.ShotOutcomeWonPoint, model.PointWinReasonWinner
+	case shot.ShotType == model.ShotTypeServe:
+		return errors.New("a serve cannot end with an unforced error")
+	case ending == winnerEnding:
+		outcome, reason = model.ShotOutcomeWonPoint, model.PointWinReasonWinner
+	case ending == forcedEnding:
+		outcome, reason = model.ShotOutcomeError, model.PointWinReasonForcedError
+	default:
+		outcome, reason = model.ShotOutcomeError, model.PointWinReasonUnforcedError
+	}
+
+	shot.ShotOutcome = outcome
+	shot.PointWinReason = &reason
+	return nil
+}
+
+// opposite returns the role hitting the next shot of a rally
+func (r HitterRole) opposite() HitterRole {
+	if r == RoleServer {
+		return RoleReceiver
+	}
+	return RoleServer
+}
+
+// record creates the shot record for a rally letter
+func (c chartedShot) record(role HitterRole) *ShotRecord {
+	shot := &ShotRecord{
+		Role:        role,
+		ShotType:    c.shotType,
+		ShotOutcome: model.ShotOutcomeContinuedRally,
+	}
+	if c.strokeType != "" {
+		strokeType := c.strokeType
+		shot.GroundStrokeType = &strokeType
+	}
+	if c.style != "" {
+		style := c.style
+		shot.GroundStrokeStyle = &style
+	}
+	return shot
+}
@@ -0,0 +1,191 @@
+package interchange
+
+import (
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// csvColumns is the header of the per-shot CSV format
+var csvColumns = []string{
+	"shotNumber", "setNumber", "gameNumber", "pointNumber", "serverId",
+	"hitterId", "hitterName", "hitterSide", "shotType", "groundStrokeType",
+	"groundStrokeStyle", "serveStyle", "serveNumber", "serviceBoxSide",
+	"shotOutcome", "pointWinReason", "matchEvent", "violation", "bounceX",
+	"bounceY", "hitterX", "hitterY", "pointWinner", "score",
+}
+
+// encodeCSV writes one row per shot
+func encodeCSV(matchUp *model.MatchUp, shots []*model.MatchUpShot) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+	if err := writer.Write(csvColumns); err != nil {
+		return "", err
+	}
+
+	for _, entry := range newShotEntries(matchUp, shots) {
+		hitterID := ""
+		if entry.HitterID != nil {
+			hitterID = entry.HitterID.Hex()
+		}
+		row := []string{
+			strconv.Itoa(entry.ShotNumber),
+			strconv.Itoa(entry.SetNumber),
+			strconv.Itoa(entry.GameNumber),
+			strconv.Itoa(entry.PointNumber),
+			entry.ServerID.Hex(),
+			hitterID,
+			entry.HitterName,
+			entry.HitterSide.String(),
+			entry.ShotType.String(),
+			optional(entry.GroundStrokeType),
+			optional(entry.GroundStrokeStyle),
+			optional(entry.ServeStyle),
+			optional(entry.ServeNumber),
+			optional(entry.ServiceBoxSide),
+			entry.ShotOutcome.String(),
+			optional(entry.PointWinReason),
+			optional(entry.MatchEvent),
+			optional(entry.Violation),
+		}
+		row = append(row, positionColumns(entry.BounceLocation)...)
+		row = append(row, positionColumns(entry.HitterPosition)...)
+		row = append(row,
+			optional(entry.PointWinner),
+			entry.Score,
+		)
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return builder.String(), writer.Error()
+}
+
+// decodeCSV reads the per-shot CSV format. Columns are matched by header
+// name, so they may come in any order and unknown columns are ignored.
+func decodeCSV(content string) (*Document, error) {
+	rows, header, err := readCSV(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, required := range []string{"shotType", "shotOutcome"} {
+		if _, ok := header[required]; !ok {
+			return nil, internalErrors.NewInvalidImportContentError("missing column " + required)
+		}
+	}
+
+	document := &Document{Shots: make([]*ShotRecord, 0, len(rows))}
+	for i, row := range rows {
+		value := func(column string) string {
+			if index, ok := header[column]; ok && index < len(row) {
+				return strings.TrimSpace(row[index])
+			}
+			return ""
+		}
+
+		entry := &shotEntry{
+			ShotNumber:        i + 1,
+			HitterName:        value("hitterName"),
+			ShotType:          model.ShotType(value("shotType")),
+			GroundStrokeType:  enumValue[model.GroundStrokeType](value("groundStrokeType")),
+			GroundStrokeStyle: enumValue[model.GroundStrokeStyle](value("groundStrokeStyle")),
+			ServeStyle:        enumValue[model.ServeStyle](value("serveStyle")),
+			ServeNumber:       enumValue[model.ServeNumber](value("serveNumber")),
+			ShotOutcome:       model.ShotOutcome(value("shotOutcome")),
+			PointWinReason:    enumValue[model.PointWinReason](value("pointWinReason")),
+			MatchEvent:        enumValue[model.MatchEventType](value("matchEvent")),
+			Violation:         enumValue[model.ViolationType](value("violation")),
+		}
+		if hex := value("hitterId"); hex != "" {
+			hitterID, err := primitive.ObjectIDFromHex(hex)
+			if err != nil {
+				return nil, internalErrors.NewInvalidImportContentError("shot " + strconv.Itoa(i+1) + " has an invalid hitterId")
+			}
+			entry.HitterID = &hitterID
+		}
+
+		if entry.BounceLocation, err = positionValue(value("bounceX"), value("bounceY")); err != nil {
+			return nil, internalErrors.NewInvalidImportContentError("shot " + strconv.Itoa(i+1) + " has an invalid bounce position")
+		}
+		if entry.HitterPosition, err = positionValue(value("hitterX"), value("hitterY")); err != nil {
+			return nil, internalErrors.NewInvalidImportContentError("shot " + strconv.Itoa(i+1) + " has an invalid hitter position")
+		}
+
+		record, err := entry.record()
+		if err != nil {
+			return nil, err
+		}
+		document.Shots = append(document.Shots, record)
+	}
+	return document, nil
+}
+
+// readCSV parses CSV content into its data rows and a column index by header name
+func readCSV(content string) ([][]string, map[string]int, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, internalErrors.NewInvalidImportContentError(err.Error())
+	}
+	if len(records) == 0 {
+		return nil, nil, internalErrors.NewInvalidImportContentError("the file is empty")
+	}
+
+	header := make(map[string]int, len(records[0]))
+	for i, column := range records[0] {
+		header[strings.TrimSpace(column)] = i
+	}
+	return records[1:], header, nil
+}
+
+// optional writes an optional enum value, or an empty string when it is not set
+func optional[T interface{ String() string }](value *T) string {
+	if value == nil {
+		return ""
+	}
+	return (*value).String()
+}
+
+// positionColumns writes an optional court position as its x and y columns
+func positionColumns(position *model.CourtPosition) []string {
+	if position == nil {
+		return []string{"", ""}
+	}
+	return []string{
+		strconv.FormatFloat(position.X, 'f', -1, 64),
+		strconv.FormatFloat(position.Y, 'f', -1, 64),
+	}
+}
+
+// positionValue reads an optional court position from its x and y columns,
+// which must be given together
+func positionValue(x, y string) (*model.CourtPosition, error) {
+	if x == "" && y == "" {
+		return nil, nil
+	}
+	xValue, err := strconv.ParseFloat(x, 64)
+	if err != nil {
+		return nil, err
+	}
+	yValue, err := strconv.ParseFloat(y, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CourtPosition{X: xValue, Y: yValue}, nil
+}
+
+// enumValue reads an optional enum value, treating an empty string as not set
+func enumValue[T ~string](value string) *T {
+	if value == "" {
+		return nil
+	}
+	typed := T(value)
+	return &typed
+}
@@ -0,0 +1,120 @@
+package interchange
+
+import (
+	"strconv"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// shotEntry is one shot as written to the CSV and JSON formats
+type shotEntry struct {
+	ShotNumber        int                      `json:"shotNumber"`
+	SetNumber         int                      `json:"setNumber"`
+	GameNumber        int                      `json:"gameNumber"`
+	PointNumber       int                      `json:"pointNumber"`
+	ServerID          primitive.ObjectID       `json:"serverId"`
+	HitterID          *primitive.ObjectID      `json:"hitterId,omitempty"`
+	HitterName        string                   `json:"hitterName,omitempty"`
+	HitterSide        model.TeamSide           `json:"hitterSide"`
+	ShotType          model.ShotType           `json:"shotType"`
+	GroundStrokeType  *model.GroundStrokeType  `json:"groundStrokeType,omitempty"`
+	GroundStrokeStyle *model.GroundStrokeStyle `json:"groundStrokeStyle,omitempty"`
+	ServeStyle        *model.ServeStyle        `json:"serveStyle,omitempty"`
+	ServeNumber       *model.ServeNumber       `json:"serveNumber,omitempty"`
+	ServiceBoxSide    *model.ServiceBoxSide    `json:"serviceBoxSide,omitempty"`
+	ShotOutcome       model.ShotOutcome        `json:"shotOutcome"`
+	PointWinReason    *model.PointWinReason    `json:"pointWinReason,omitempty"`
+	MatchEvent        *model.MatchEventType    `json:"matchEvent,omitempty"`
+	Violation         *model.ViolationType     `json:"violation,omitempty"`
+	BounceLocation    *model.CourtPosition     `json:"bounceLocation,omitempty"`
+	HitterPosition    *model.CourtPosition     `json:"hitterPosition,omitempty"`
+	PointWinner       *model.TeamSide          `json:"pointWinner,omitempty"`
+	Score             string                   `json:"score"`
+}
+
+// newShotEntries flattens the shots of a matchup into entries
+func newShotEntries(matchUp *model.MatchUp, shots []*model.MatchUpShot) []*shotEntry {
+	entries := make([]*shotEntry, len(shots))
+	for i, shot := range shots {
+		hitterID := shot.HitterID
+		entry := &shotEntry{
+			ShotNumber:        i + 1,
+			HitterID:          &hitterID,
+			HitterName:        participantName(matchUp, shot.HitterID),
+			HitterSide:        shot.HitterSide,
+			ShotType:          shot.ShotType,
+			GroundStrokeType:  shot.GroundStrokeType,
+			GroundStrokeStyle: shot.GroundStrokeStyle,
+			ServeStyle:        shot.ServeStyle,
+			ServeNumber:       shot.ServeNumber,
+			ServiceBoxSide:    shot.ServiceBoxSide,
+			ShotOutcome:       shot.ShotOutcome,
+			PointWinReason:    shot.PointWinReason,
+			MatchEvent:        shot.MatchEvent,
+			Violation:         shot.Violation,
+			BounceLocation:    shot.BounceLocation,
+			HitterPosition:    shot.HitterPosition,
+		}
+		if shot.PointContext != nil {
+			entry.SetNumber = shot.PointContext.SetNumber
+			entry.GameNumber = shot.PointContext.GameNumber
+			entry.PointNumber = shot.PointContext.PointNumber
+			entry.ServerID = shot.PointContext.ServerID
+		}
+		if state := shot.MatchStateAfterShot; state != nil {
+			entry.PointWinner = state.PointWinner
+			entry.Score = ScoreText(state.Score)
+		}
+		entries[i] = entry
+	}
+	return entries
+}
+
+// record checks an entry read from a file and converts it into a shot record.
+// Score and context columns are informational: they are recomputed on replay.
+func (e *shotEntry) record() (*ShotRecord, error) {
+	if e.HitterID == nil && e.HitterName == "" {
+		return nil, internalErrors.NewInvalidImportContentError("shot " + strconv.Itoa(e.ShotNumber) + " has no hitterId or hitterName")
+	}
+	if !e.ShotType.IsValid() {
+		return nil, internalErrors.NewInvalidImportContentError("shot " + strconv.Itoa(e.ShotNumber) + " has an invalid shotType")
+	}
+	if !e.ShotOutcome.IsValid() {
+		return nil, internalErrors.NewInvalidImportContentError("shot " + strconv.Itoa(e.ShotNumber) + " has an invalid shotOutcome")
+	}
+	for column, valid := range map[string]bool{
+		"groundStrokeType":  e.GroundStrokeType == nil || e.GroundStrokeType.IsValid(),
+		"groundStrokeStyle": e.GroundStrokeStyle == nil || e.GroundStrokeStyle.IsValid(),
+		"serveStyle":        e.ServeStyle == nil || e.ServeStyle.IsValid(),
+		"serveNumber":       e.ServeNumber == nil || e.ServeNumber.IsValid(),
+		"pointWinReason":    e.PointWinReason == nil || e.PointWinReason.IsValid(),
+		"matchEvent":        e.MatchEvent == nil || e.MatchEvent.IsValid(),
+		"violation":         e.Violation == nil || e.Violation.IsValid(),
+	} {
+		if !valid {
+			return nil, internalErrors.NewInvalidImportContentError("shot " + strconv.Itoa(e.ShotNumber) + " has an invalid " + column)
+		}
+	}
+	if (e.ShotType == model.ShotTypeMatchEvent) != (e.MatchEvent != nil) {
+		return nil, internalErrors.NewInvalidImportContentError("shot " + strconv.Itoa(e.ShotNumber) + " must have a matchEvent exactly when its shotType is MATCH_EVENT")
+	}
+
+	return &ShotRecord{
+		HitterID:          e.HitterID,
+		HitterName:        e.HitterName,
+		Role:              RoleNamed,
+		ShotType:          e.ShotType,
+		GroundStrokeType:  e.GroundStrokeType,
+		GroundStrokeStyle: e.GroundStrokeStyle,
+		ServeStyle:        e.ServeStyle,
+		ServeNumber:       e.ServeNumber,
+		ShotOutcome:       e.ShotOutcome,
+		PointWinReason:    e.PointWinReason,
+		MatchEvent:        e.MatchEvent,
+		Violation:         e.Violation,
+		BounceLocation:    e.BounceLocation,
+		HitterPosition:    e.HitterPosition,
+	}, nil
+}
@@ -0,0 +1,199 @@
+package interchange
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HitterRole identifies a hitter relative to the server, for notations that
+// don't say who hit each shot
+type HitterRole int
+
+const (
+	// RoleNamed means the hitter is given by ID or display name
+	RoleNamed HitterRole = iota
+	// RoleServer means the shot was hit by the server of the point
+	RoleServer
+	// RoleReceiver means the shot was hit by the receiver of the point
+	RoleReceiver
+)
+
+// ShotRecord is one shot read from an imported file, before it is matched
+// to a participant and replayed through the scoring rules
+type ShotRecord struct {
+	HitterID          *primitive.ObjectID
+	HitterName        string
+	Role              HitterRole
+	ShotType          model.ShotType
+	GroundStrokeType  *model.GroundStrokeType
+	GroundStrokeStyle *model.GroundStrokeStyle
+	ServeStyle        *model.ServeStyle
+	ServeNumber       *model.ServeNumber
+	ShotOutcome       model.ShotOutcome
+	PointWinReason    *model.PointWinReason
+	MatchEvent        *model.MatchEventType
+	Violation         *model.ViolationType
+	BounceLocation    *model.CourtPosition
+	HitterPosition    *model.CourtPosition
+}
+
+// AddShotInput converts the record into the input used to replay it
+func (r *ShotRecord) AddShotInput(matchUpID, hitterID primitive.ObjectID) model.AddShotInput {
+	return model.AddShotInput{
+		MatchUpID:         matchUpID,
+		HitterID:          hitterID,
+		ShotType:          r.ShotType,
+		GroundStrokeType:  r.GroundStrokeType,
+		GroundStrokeStyle: r.GroundStrokeStyle,
+		ServeStyle:        r.ServeStyle,
+		ServeNumber:       r.ServeNumber,
+		ShotOutcome:       r.ShotOutcome,
+		PointWinReason:    r.PointWinReason,
+		BounceLocation:    positionInput(r.BounceLocation),
+		HitterPosition:    positionInput(r.HitterPosition),
+	}
+}
+
+// positionInput converts an optional court position back into its input
+func positionInput(position *model.CourtPosition) *model.CourtPositionInput {
+	if position == nil {
+		return nil
+	}
+	return &model.CourtPositionInput{X: position.X, Y: position.Y}
+}
+
+// AddPointInput converts a record of a point played without its rally into
+// the input used to replay it. The hitter is who the point is attributed to.
+func (r *ShotRecord) AddPointInput(matchUpID primitive.ObjectID, hitter *model.Participant) model.AddPointInput {
+	winner := hitter.TeamSide
+	if r.ShotOutcome != model.ShotOutcomeWonPoint {
+		winner = scoring.Opponent(hitter.TeamSide)
+	}
+	input := model.AddPointInput{
+		MatchUpID:      matchUpID,
+		PointWinner:    winner,
+		PointWinReason: r.PointWinReason,
+	}
+	if r.PointWinReason != nil {
+		input.PlayerID = &hitter.ID
+	}
+	return input
+}
+
+// AddMatchEventInput converts a record of a let or penalty into the input
+// used to replay it. The hitter is who the event is charged to.
+func (r *ShotRecord) AddMatchEventInput(matchUpID, hitterID primitive.ObjectID) model.AddMatchEventInput {
+	return model.AddMatchEventInput{
+		MatchUpID: matchUpID,
+		EventType: *r.MatchEvent,
+		PlayerID:  &hitterID,
+		Violation: r.Violation,
+	}
+}
+
+// Document is the content of an imported file
+type Document struct {
+	// Setup is the match setup carried by the file, or nil when the format has none
+	Setup *model.InitiateMatchUpInput
+	// GuestIDs lists the setup's participants that were guests
+	GuestIDs []primitive.ObjectID
+	Shots    []*ShotRecord
+}
+
+// Export writes a matchup and its active shots, in order, to the given format
+func Export(matchUp *model.MatchUp, shots []*model.MatchUpShot, format model.MatchUpExportFormat) (*model.MatchUpExport, error) {
+	var content, extension, contentType string
+	var err error
+	switch format {
+	case model.MatchUpExportFormatCharting:
+		content, err = encodeCharting(matchUp, shots)
+		extension, contentType = "csv", "text/csv"
+	case model.MatchUpExportFormatCSV:
+		content, err = encodeCSV(matchUp, shots)
+		extension, contentType = "csv", "text/csv"
+	case model.MatchUpExportFormatJSON:
+		content, err = encodeJSON(matchUp, shots)
+		extension, contentType = "json", "application/json"
+	default:
+		return nil, internalErrors.NewRequiredFieldError("format")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.MatchUpExport{
+		Format:      format,
+		FileName:    "matchup-" + matchUp.ID.Hex() + "-" + strings.ToLower(format.String()) + "." + extension,
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
+
+// Import reads a file written in the given format
+func Import(format model.MatchUpExportFormat, content string) (*Document, error) {
+	switch format {
+	case model.MatchUpExportFormatCharting:
+		return decodeCharting(content)
+	case model.MatchUpExportFormatCSV:
+		return decodeCSV(content)
+	case model.MatchUpExportFormatJSON:
+		return decodeJSON(content)
+	default:
+		return nil, internalErrors.NewRequiredFieldError("format")
+	}
+}
+
+// ScoreText writes a score as plain text with TEAM_A first, e.g. "6-4 3-2 30-15"
+// or "6-4 6-6 (5-3)" during a tiebreak
+func ScoreText(score *model.MatchUpScore) string {
+	if score == nil {
+		return ""
+	}
+
+	var parts []string
+	for _, set := range score.Sets {
+		a, b := scoring.SideScore(set, model.TeamSideTeamA), scoring.SideScore(set, model.TeamSideTeamB)
+		parts = append(parts, strconv.Itoa(a.GamesWon)+"-"+strconv.Itoa(b.GamesWon))
+		if set.IsCompleted {
+			continue
+		}
+		switch {
+		case set.IsTiebreakActive:
+			parts = append(parts, "("+strconv.Itoa(scoring.TiebreakPoints(a))+"-"+strconv.Itoa(scoring.TiebreakPoints(b))+")")
+		case a.InGameScore != model.InGameScoreZero || b.InGameScore != model.InGameScoreZero:
+			parts = append(parts, pointsText(a.InGameScore)+"-"+pointsText(b.InGameScore))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// pointsText writes a game score the way it is called on court
+func pointsText(score model.InGameScore) string {
+	switch score {
+	case model.InGameScoreFifteen:
+		return "15"
+	case model.InGameScoreThirty:
+		return "30"
+	case model.InGameScoreForty:
+		return "40"
+	case model.InGameScoreAdv:
+		return "AD"
+	default:
+		return "0"
+	}
+}
+
+// participantName returns the display name of a participant, or an empty string
+func participantName(matchUp *model.MatchUp, id primitive.ObjectID) string {
+	for _, participant := range matchUp.Participants {
+		if participant.ID == id {
+			return participant.DisplayName
+		}
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package interchange
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// jsonDocument is the layout of the JSON format
+type jsonDocument struct {
+	MatchUp *jsonMatchUp `json:"matchUp"`
+	Shots   []*shotEntry `json:"shots"`
+}
+
+// jsonMatchUp is the match setup and result written to the JSON format
+type jsonMatchUp struct {
+	ID            primitive.ObjectID          `json:"id"`
+	MatchUpType   model.MatchUpType           `json:"matchUpType"`
+	TrackingStyle *model.MatchUpTrackingStyle `json:"trackingStyle,omitempty"`
+	MatchUpFormat *model.MatchUpFormat        `json:"matchUpFormat"`
+	Participants  []*model.Participant        `json:"participants"`
+	InitialServer primitive.ObjectID          `json:"initialServer"`
+	MatchUpStatus model.MatchUpStatus         `json:"matchUpStatus"`
+	StartTime     *time.Time                  `json:"startTime,omitempty"`
+	EndTime       *time.Time                  `json:"endTime,omitempty"`
+	Winner        *model.TeamSide             `json:"winner,omitempty"`
+	Score         string                      `json:"score"`
+}
+
+// encodeJSON writes the match setup followed by every shot
+func encodeJSON(matchUp *model.MatchUp, shots []*model.MatchUpShot) (string, error) {
+	document := jsonDocument{
+		MatchUp: &jsonMatchUp{
+			ID:            matchUp.ID,
+			MatchUpType:   matchUp.MatchUpType,
+			MatchUpFormat: matchUp.MatchUpFormat,
+			Participants:  matchUp.Participants,
+			InitialServer: matchUp.InitialServer,
+			MatchUpStatus: matchUp.MatchUpStatus,
+			StartTime:     matchUp.StartTime,
+			EndTime:       matchUp.EndTime,
+			Winner:        matchUp.Winner,
+			Score:         ScoreText(matchUp.CurrentScore),
+		},
+		Shots: newShotEntries(matchUp, shots),
+	}
+	if matchUp.TrackingStyle != "" {
+		document.MatchUp.TrackingStyle = &matchUp.TrackingStyle
+	}
+
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// decodeJSON reads the JSON format, including the match setup when present.
+// Participant IDs are kept, guests included, so the shots' hitter IDs still
+// match; guests are listed separately so they stay guests after the import.
+func decodeJSON(content string) (*Document, error) {
+	var document jsonDocument
+	if err := json.Unmarshal([]byte(content), &document); err != nil {
+		return nil, internalErrors.NewInvalidImportContentError(err.Error())
+	}
+
+	result := &Document{Shots: make([]*ShotRecord, 0, len(document.Shots))}
+	for i, entry := range document.Shots {
+		entry.ShotNumber = i + 1
+		record, err := entry.record()
+		if err != nil {
+			return nil, err
+		}
+		result.Shots = append(result.Shots, record)
+	}
+
+	if setup := document.MatchUp; setup != nil && setup.MatchUpFormat != nil && setup.MatchUpFormat.SetFormat != nil {
+		participants := make([]*model.ParticipantInput, len(setup.Participants))
+		for i, participant := range setup.Participants {
+			id := participant.ID
+			participants[i] = &model.ParticipantInput{
+				ID:            &id,
+				DisplayedName: participant.DisplayName,
+				TeamSide:      participant.TeamSide,
+			}
+			if participant.IsGuest {
+				result.GuestIDs = append(result.GuestIDs, id)
+			}
+		}
+		result.Setup = &model.InitiateMatchUpInput{
+			MatchUpType:   setup.MatchUpType,
+			TrackingStyle: setup.TrackingStyle,
+			MatchUpFormat: formatInput(setup.MatchUpFormat),
+			Participants:  participants,
+			InitialServer: setup.InitialServer,
+		}
+	}
+	return result, nil
+}
+
+// formatInput converts a stored format back into the input that creates it
+func formatInput(format *model.MatchUpFormat) *model.MatchUpFormatInput {
+	input := &model.MatchUpFormatInput{
+		NumberOfSets: format.NumberOfSets,
+		SetFormat:    setFormatInput(format.SetFormat),
+	}
+	if format.FinalSetFormat != nil {
+		input.FinalSetFormat = setFormatInput(format.FinalSetFormat)
+	}
+	return input
+}
+
+// setFormatInput converts a stored set format back into its input
+func setFormatInput(format *model.SetFormat) *model.SetFormatInput {
+	input := &model.SetFormatInput{
+		NumberOfGames: format.NumberOfGames,
+		DeuceType:     format.DeuceType,
+		MustWinByTwo:  format.MustWinByTwo,
+	}
+	if format.TiebreakFormat != nil {
+		input.TiebreakFormat = &model.TiebreakFormatInput{
+			Points:       format.TiebreakFormat.Points,
+			MustWinByTwo: format.TiebreakFormat.MustWinByTwo,
+			TiebreakAt:   scoring.TiebreakAt(format),
+		}
+	}
+	return input
+}
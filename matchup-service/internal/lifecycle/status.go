@@ -0,0 +1,140 @@
+package lifecycle
+
+import (
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// transitions lists the statuses a match can move to from each status.
+// Statuses without an entry are final.
+var transitions = map[model.MatchUpStatus][]model.MatchUpStatus{
+	model.MatchUpStatusRequested: {
+		model.MatchUpStatusScheduled,
+		model.MatchUpStatusCancelled,
+	},
+	model.MatchUpStatusScheduled: {
+		model.MatchUpStatusInProgress,
+		model.MatchUpStatusCancelled,
+	},
+	model.MatchUpStatusInProgress: {
+		model.MatchUpStatusSuspended,
+		model.MatchUpStatusCompleted,
+		model.MatchUpStatusRetired,
+		model.MatchUpStatusAbandoned,
+	},
+	model.MatchUpStatusSuspended: {
+		model.MatchUpStatusInProgress,
+		model.MatchUpStatusRetired,
+		model.MatchUpStatusAbandoned,
+	},
+}
+
+// CanTransition reports whether a match may move from one status to another
+func CanTransition(from, to model.MatchUpStatus) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal reports whether a status ends the match for good
+func IsFinal(status model.MatchUpStatus) bool {
+	switch status {
+	case model.MatchUpStatusCompleted,
+		model.MatchUpStatusCancelled,
+		model.MatchUpStatusAbandoned,
+		model.MatchUpStatusRetired:
+		return true
+	default:
+		return false
+	}
+}
+
+// Change describes a requested status transition
+type Change struct {
+	To           model.MatchUpStatus
+	RetiringSide *model.TeamSide
+	// The side that won a completed match. Left out, the side with more
+	// sets won; a default decides the match whatever the set count.
+	Winner    *model.TeamSide
+	Reason    *string
+	ChangedBy primitive.ObjectID
+	ChangedAt time.Time
+	// Start the match even though some invitations are not accepted
+	OverrideInvitations bool
+	// Reopen a completed match whose deciding shot was undone. Only the undo
+	// path sets it; otherwise a completed match is final.
+	Reopen bool
+}
+
+// Apply moves a matchup to a new status, filling in the start and end times
+// and the result, and appends the change to the status history
+func Apply(matchUp *model.MatchUp, change Change) error {
+	from := matchUp.MatchUpStatus
+	reopen := change.Reopen && from == model.MatchUpStatusCompleted && change.To == model.MatchUpStatusInProgress
+	if !reopen && !CanTransition(from, change.To) {
+		return internalErrors.NewInvalidStatusTransitionError(from.String(), change.To.String())
+	}
+
+	switch change.To {
+	case model.MatchUpStatusInProgress:
+		if from == model.MatchUpStatusScheduled && !change.OverrideInvitations && !InvitationsAccepted(matchUp) {
+			return internalErrors.NewInvitationsNotAcceptedError()
+		}
+		if matchUp.StartTime == nil {
+			matchUp.StartTime = &change.ChangedAt
+		}
+		// Reopened after the deciding shot was undone
+		if from == model.MatchUpStatusCompleted {
+			matchUp.EndTime = nil
+		}
+	case model.MatchUpStatusCompleted:
+		if matchUp.CurrentScore == nil || !matchUp.CurrentScore.IsMatchComplete {
+			return internalErrors.NewMatchUpNotDecidedError()
+		}
+		winner := scoring.MatchWinner(matchUp.CurrentScore)
+		if change.Winner != nil {
+			winner = *change.Winner
+		}
+		loser := scoring.Opponent(winner)
+		matchUp.Winner = &winner
+		matchUp.Loser = &loser
+	case model.MatchUpStatusRetired:
+		if change.RetiringSide == nil {
+			return internalErrors.NewRetiringSideRequiredError()
+		}
+		winner := scoring.Opponent(*change.RetiringSide)
+		matchUp.Winner = &winner
+		matchUp.Loser = change.RetiringSide
+		matchUp.RetiringSide = change.RetiringSide
+	}
+	if IsFinal(change.To) {
+		matchUp.EndTime = &change.ChangedAt
+	}
+
+	matchUp.MatchUpStatus = change.To
+	matchUp.StatusHistory = append(matchUp.StatusHistory, &model.MatchUpStatusChange{
+		FromStatus: from,
+		ToStatus:   change.To,
+		ChangedBy:  change.ChangedBy,
+		ChangedAt:  change.ChangedAt,
+		Reason:     change.Reason,
+	})
+	return nil
+}
+
+// InvitationsAccepted reports whether every invited participant agreed to play
+func InvitationsAccepted(matchUp *model.MatchUp) bool {
+	for _, participant := range matchUp.Participants {
+		if participant.InvitationStatus != nil && *participant.InvitationStatus != model.InvitationStatusAccepted {
+			return false
+		}
+	}
+	return true
+}
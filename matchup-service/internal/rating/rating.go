@@ -0,0 +1,172 @@
+package rating
+
+import (
+	"math"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/lifecycle"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Initial is the rating of a player before their first rated match
+const Initial = 1500.0
+
+const (
+	// scale is the rating gap at which the stronger side is expected to win
+	// ten times as often as it loses
+	scale = 400.0
+	// Ratings move by provisionalK over a player's first provisionalMatches
+	// matches so new players find their level quickly, then by establishedK
+	provisionalMatches = 10
+	provisionalK       = 40.0
+	establishedK       = 20.0
+	// A win by lopsidedMargin games or more, a 6-0 6-0, moves ratings by
+	// maxMarginBonus more than a win by a single game
+	lopsidedMargin = 12
+	maxMarginBonus = 0.5
+)
+
+// Player is a player's standing going into a match
+type Player struct {
+	Rating       float64
+	MatchesRated int
+}
+
+// Side is one side of a match: its rated players and the games it won
+type Side struct {
+	Players []Player
+	Games   int
+}
+
+// Average returns the side's team rating, the average of its players' ratings
+func (s Side) Average() float64 {
+	total := 0.0
+	for _, player := range s.Players {
+		total += player.Rating
+	}
+	return total / float64(len(s.Players))
+}
+
+// Expected returns the score a side rated rating is expected to make against
+// a side rated opponent, from 0 for a certain loss to 1 for a certain win
+func Expected(rating, opponent float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponent-rating)/scale))
+}
+
+// k returns how far a player's rating can move in one match
+func k(player Player) float64 {
+	if player.MatchesRated < provisionalMatches {
+		return provisionalK
+	}
+	return establishedK
+}
+
+// marginMultiplier grows with the winners' game margin, quickly at first and
+// then flattening out, so running up the score is worth little
+func marginMultiplier(margin int) float64 {
+	if margin <= 0 {
+		return 1
+	}
+	bonus := math.Log1p(float64(margin)) / math.Log1p(lopsidedMargin)
+	return 1 + maxMarginBonus*math.Min(bonus, 1)
+}
+
+// Rate returns each player's rating change after the winner side beat the
+// loser side. Both sides play at their team rating, and every player on a side
+// shares the same result, scaled by their own K factor.
+func Rate(winner, loser Side) (winnerChanges, loserChanges []float64) {
+	expected := Expected(winner.Average(), loser.Average())
+	multiplier := marginMultiplier(winner.Games - loser.Games)
+
+	winnerChanges = make([]float64, len(winner.Players))
+	for i, player := range winner.Players {
+		winnerChanges[i] = k(player) * multiplier * (1 - expected)
+	}
+	loserChanges = make([]float64, len(loser.Players))
+	for i, player := range loser.Players {
+		loserChanges[i] = -k(player) * multiplier * (1 - expected)
+	}
+	return winnerChanges, loserChanges
+}
+
+// Match works out the rating changes a decided matchup makes, one for each
+// participant who isn't a guest. current holds the latest change of each
+// player rated before, and players missing from it start at the initial
+// rating. Matchups without a winner, with only guests on a side, or started
+// before every invited player accepted can't be rated and return nil.
+func Match(matchUp *model.MatchUp, current map[primitive.ObjectID]*model.RatingChange, at time.Time) []*model.RatingChange {
+	if matchUp.Winner == nil || !lifecycle.InvitationsAccepted(matchUp) {
+		return nil
+	}
+	winnerSide := *matchUp.Winner
+	loserSide := scoring.Opponent(winnerSide)
+
+	var winnerIDs, loserIDs []primitive.ObjectID
+	winner, loser := Side{Games: games(matchUp, winnerSide)}, Side{Games: games(matchUp, loserSide)}
+	for _, participant := range matchUp.Participants {
+		if participant.IsGuest {
+			continue
+		}
+		player := Player{Rating: Initial}
+		if latest := current[participant.ID]; latest != nil {
+			player = Player{Rating: latest.RatingAfter, MatchesRated: latest.MatchesRated}
+		}
+		switch participant.TeamSide {
+		case winnerSide:
+			winnerIDs = append(winnerIDs, participant.ID)
+			winner.Players = append(winner.Players, player)
+		case loserSide:
+			loserIDs = append(loserIDs, participant.ID)
+			loser.Players = append(loser.Players, player)
+		}
+	}
+	if len(winner.Players) == 0 || len(loser.Players) == 0 {
+		return nil
+	}
+
+	winnerChanges, loserChanges := Rate(winner, loser)
+	changes := make([]*model.RatingChange, 0, len(winnerIDs)+len(loserIDs))
+	newChange := func(userID primitive.ObjectID, player Player, change float64, won bool, opponent Side) *model.RatingChange {
+		return &model.RatingChange{
+			ID:             primitive.NewObjectID(),
+			UserID:         userID,
+			MatchUpID:      matchUp.ID,
+			MatchUpType:    matchUp.MatchUpType,
+			Won:            won,
+			RatingBefore:   player.Rating,
+			RatingAfter:    player.Rating + change,
+			Change:         change,
+			OpponentRating: opponent.Average(),
+			MatchesRated:   player.MatchesRated + 1,
+			CreatedAt:      at,
+		}
+	}
+	for i, userID := range winnerIDs {
+		changes = append(changes, newChange(userID, winner.Players[i], winnerChanges[i], true, loser))
+	}
+	for i, userID := range loserIDs {
+		changes = append(changes, newChange(userID, loser.Players[i], loserChanges[i], false, winner))
+	}
+	return changes
+}
+
+// games counts the games a side won over the whole match
+func games(matchUp *model.MatchUp, side model.TeamSide) int {
+	if matchUp.CurrentScore == nil {
+		return 0
+	}
+	total := 0
+	for _, set := range matchUp.CurrentScore.Sets {
+		if score := scoring.SideScore(set, side); score != nil {
+			total += score.GamesWon
+		}
+	}
+	return total
+}
+
+// Rounded returns a rating as the whole number stored on the user
+func Rounded(rating float64) int {
+	return int(math.Round(rating))
+}
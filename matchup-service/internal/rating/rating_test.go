@@ -0,0 +1,113 @@
+package rating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// decided builds a one set matchup won by team A with the given games
+func decided(gamesA, gamesB int, participants ...*model.Participant) *model.MatchUp {
+	winner := model.TeamSideTeamA
+	return &model.MatchUp{
+		ID:           primitive.NewObjectID(),
+		MatchUpType:  model.MatchUpTypeSingles,
+		Participants: participants,
+		Winner:       &winner,
+		CurrentScore: &model.MatchUpScore{
+			IsMatchComplete: true,
+			Sets: []*model.SetScore{{
+				IsCompleted: true,
+				Sides: []*model.SideSetScore{
+					{Side: model.TeamSideTeamA, GamesWon: gamesA},
+					{Side: model.TeamSideTeamB, GamesWon: gamesB},
+				},
+			}},
+		},
+	}
+}
+
+func participant(side model.TeamSide, guest bool) *model.Participant {
+	return &model.Participant{ID: primitive.NewObjectID(), TeamSide: side, IsGuest: guest}
+}
+
+func TestExpected(t *testing.T) {
+	assert.InDelta(t, 0.5, Expected(1500, 1500), 1e-9)
+	assert.InDelta(t, 10.0/11, Expected(1900, 1500), 1e-9)
+	assert.InDelta(t, 1, Expected(1500, 1600)+Expected(1600, 1500), 1e-9)
+}
+
+func TestRateBetweenNewPlayers(t *testing.T) {
+	// Evenly matched and provisional: a one game win moves both by a bit over K/2
+	winner, loser := Rate(
+		Side{Players: []Player{{Rating: Initial}}, Games: 6},
+		Side{Players: []Player{{Rating: Initial}}, Games: 5},
+	)
+	assert.InDelta(t, 22.7, winner[0], 0.01)
+	assert.InDelta(t, -winner[0], loser[0], 1e-9)
+
+	// A bagel moves them by half as much again
+	winner, _ = Rate(
+		Side{Players: []Player{{Rating: Initial}}, Games: 12},
+		Side{Players: []Player{{Rating: Initial}}, Games: 0},
+	)
+	assert.InDelta(t, 30, winner[0], 1e-9)
+}
+
+func TestRateUsesTeamAveragesAndOwnK(t *testing.T) {
+	winner, loser := Rate(
+		Side{Players: []Player{{Rating: 1400}, {Rating: 1600, MatchesRated: 30}}, Games: 6},
+		Side{Players: []Player{{Rating: 1500}, {Rating: 1500}}, Games: 6},
+	)
+	// Same team rating, so each player wins half their K
+	assert.InDelta(t, 20, winner[0], 1e-9)
+	assert.InDelta(t, 10, winner[1], 1e-9)
+	assert.InDelta(t, -20, loser[0], 1e-9)
+}
+
+func TestUpsetMovesRatingsFurther(t *testing.T) {
+	upset, _ := Rate(
+		Side{Players: []Player{{Rating: 1300}}, Games: 6},
+		Side{Players: []Player{{Rating: 1700}}, Games: 4},
+	)
+	expected, _ := Rate(
+		Side{Players: []Player{{Rating: 1700}}, Games: 6},
+		Side{Players: []Player{{Rating: 1300}}, Games: 4},
+	)
+	assert.Greater(t, upset[0], 9*expected[0])
+}
+
+func TestMatchSkipsGuests(t *testing.T) {
+	playerA := participant(model.TeamSideTeamA, false)
+	guest := participant(model.TeamSideTeamB, true)
+	now := time.Now()
+
+	// Nobody rated to play against
+	assert.Nil(t, Match(decided(6, 0, playerA, guest), nil, now))
+
+	// A guest partner doesn't count towards the team rating
+	playerB := participant(model.TeamSideTeamB, false)
+	partner := participant(model.TeamSideTeamA, true)
+	current := map[primitive.ObjectID]*model.RatingChange{
+		playerB.ID: {RatingAfter: 1600, MatchesRated: 4},
+	}
+	changes := Match(decided(6, 4, playerA, partner, playerB), current, now)
+	require.Len(t, changes, 2)
+
+	assert.Equal(t, playerA.ID, changes[0].UserID)
+	assert.True(t, changes[0].Won)
+	assert.Equal(t, Initial, changes[0].RatingBefore)
+	assert.Equal(t, 1600.0, changes[0].OpponentRating)
+	assert.Equal(t, 1, changes[0].MatchesRated)
+
+	assert.Equal(t, playerB.ID, changes[1].UserID)
+	assert.False(t, changes[1].Won)
+	assert.Equal(t, 1600.0, changes[1].RatingBefore)
+	assert.Equal(t, 5, changes[1].MatchesRated)
+	assert.InDelta(t, changes[1].RatingBefore+changes[1].Change, changes[1].RatingAfter, 1e-9)
+	assert.InDelta(t, -changes[0].Change, changes[1].Change, 1e-9)
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/db"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FormatPresetsRepository defines the interface for saved matchup format presets.
+// Built-in presets are not stored and never go through this repository.
+type FormatPresetsRepository interface {
+	FindByID(ctx context.Context, id primitive.ObjectID) (*model.MatchUpFormatPreset, error)
+	FindByOwner(ctx context.Context, ownerID primitive.ObjectID, limit, offset *int) ([]*model.MatchUpFormatPreset, error)
+	Insert(ctx context.Context, preset *model.MatchUpFormatPreset) (*model.MatchUpFormatPreset, error)
+	Delete(ctx context.Context, id primitive.ObjectID) (bool, error)
+}
+
+// FormatPresetsRepositoryImpl implements FormatPresetsRepository
+type FormatPresetsRepositoryImpl struct {
+	baseRepo *repository.BaseRepository[model.MatchUpFormatPreset]
+	factory  *repository.RepositoryFactory
+}
+
+// NewFormatPresetsRepository creates a new instance of FormatPresetsRepository
+func NewFormatPresetsRepository(factory *repository.RepositoryFactory) FormatPresetsRepository {
+	baseRepo := repository.NewRepository[model.MatchUpFormatPreset](factory, db.TennisMatchupFormatsCollection)
+	return &FormatPresetsRepositoryImpl{
+		baseRepo: baseRepo,
+		factory:  factory,
+	}
+}
+
+// FindByID finds a saved preset by its ID
+func (r *FormatPresetsRepositoryImpl) FindByID(ctx context.Context, id primitive.ObjectID) (*model.MatchUpFormatPreset, error) {
+	preset, err := r.baseRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return preset, nil
+}
+
+// FindByOwner retrieves the presets a user saved, newest first
+func (r *FormatPresetsRepositoryImpl) FindByOwner(ctx context.Context, ownerID primitive.ObjectID, limit, offset *int) ([]*model.MatchUpFormatPreset, error) {
+	opts := options.Find().SetSort(bson.D{
+		{Key: "createdAt", Value: -1},
+		{Key: "_id", Value: -1},
+	})
+	if limit != nil {
+		opts.SetLimit(int64(*limit))
+	}
+	if offset != nil {
+		opts.SetSkip(int64(*offset))
+	}
+
+	presets, err := r.baseRepo.Find(ctx, bson.M{"owner": ownerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return presets, nil
+}
+
+// Insert saves a new preset
+func (r *FormatPresetsRepositoryImpl) Insert(ctx context.Context, preset *model.MatchUpFormatPreset) (*model.MatchUpFormatPreset, error) {
+	if preset.ID == primitive.NilObjectID {
+		preset.ID = primitive.NewObjectID()
+	}
+
+	created, err := r.baseRepo.Insert(ctx, preset)
+	if err != nil {
+		return nil, err
+	}
+	return created.(*model.MatchUpFormatPreset), nil
+}
+
+// Delete removes a saved preset
+func (r *FormatPresetsRepositoryImpl) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	err := r.baseRepo.Delete(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
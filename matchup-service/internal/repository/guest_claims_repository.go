@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/db"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GuestClaimsRepository defines the interface for guest claim invitations
+type GuestClaimsRepository interface {
+	FindByID(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error)
+	FindPendingByGuest(ctx context.Context, guestID primitive.ObjectID) (*model.GuestClaim, error)
+	FindByClaimant(ctx context.Context, claimantID primitive.ObjectID, status *model.GuestClaimStatus, limit, offset *int) ([]*model.GuestClaim, error)
+	Insert(ctx context.Context, claim *model.GuestClaim) (*model.GuestClaim, error)
+	Update(ctx context.Context, claim *model.GuestClaim) (*model.GuestClaim, error)
+}
+
+// GuestClaimsRepositoryImpl implements GuestClaimsRepository
+type GuestClaimsRepositoryImpl struct {
+	baseRepo *repository.BaseRepository[model.GuestClaim]
+	factory  *repository.RepositoryFactory
+}
+
+// NewGuestClaimsRepository creates a new instance of GuestClaimsRepository
+func NewGuestClaimsRepository(factory *repository.RepositoryFactory) GuestClaimsRepository {
+	baseRepo := repository.NewRepository[model.GuestClaim](factory, db.TennisMatchupClaimsCollection)
+	return &GuestClaimsRepositoryImpl{
+		baseRepo: baseRepo,
+		factory:  factory,
+	}
+}
+
+// FindByID finds a guest claim by its ID
+func (r *GuestClaimsRepositoryImpl) FindByID(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error) {
+	claim, err := r.baseRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// FindPendingByGuest finds the open invitation for a guest, or nil if there is none
+func (r *GuestClaimsRepositoryImpl) FindPendingByGuest(ctx context.Context, guestID primitive.ObjectID) (*model.GuestClaim, error) {
+	filter := bson.M{
+		"guestId": guestID,
+		"status":  model.GuestClaimStatusPending,
+	}
+
+	claim, err := r.baseRepo.FindOne(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// FindByClaimant retrieves the invitations sent to a user, newest first
+func (r *GuestClaimsRepositoryImpl) FindByClaimant(ctx context.Context, claimantID primitive.ObjectID, status *model.GuestClaimStatus, limit, offset *int) ([]*model.GuestClaim, error) {
+	filter := bson.M{"claimant": claimantID}
+	if status != nil {
+		filter["status"] = *status
+	}
+
+	opts := options.Find().SetSort(bson.D{
+		{Key: "createdAt", Value: -1},
+		{Key: "_id", Value: -1},
+	})
+	if limit != nil {
+		opts.SetLimit(int64(*limit))
+	}
+	if offset != nil {
+		opts.SetSkip(int64(*offset))
+	}
+
+	claims, err := r.baseRepo.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Insert saves a new guest claim
+func (r *GuestClaimsRepositoryImpl) Insert(ctx context.Context, claim *model.GuestClaim) (*model.GuestClaim, error) {
+	if claim.ID == primitive.NilObjectID {
+		claim.ID = primitive.NewObjectID()
+	}
+
+	created, err := r.baseRepo.Insert(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+	return created.(*model.GuestClaim), nil
+}
+
+// Update saves a guest claim's new status
+func (r *GuestClaimsRepositoryImpl) Update(ctx context.Context, claim *model.GuestClaim) (*model.GuestClaim, error) {
+	updated, err := r.baseRepo.Update(ctx, claim.ID, claim)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureShotIndexes creates the necessary indexes for the shots collection
+func EnsureShotIndexes(ctx context.Context, mdb *db.MongoDB) error {
+	shotIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "matchUpId", Value: 1}},
+			Options: options.Index().
+				SetName("matchup_id"),
+		},
+		{
+			// A client idempotency key can only be recorded once per matchup
+			Keys: bson.D{
+				{Key: "matchUpId", Value: 1},
+				{Key: "clientShotId", Value: 1},
+			},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"clientShotId": bson.M{"$exists": true}}).
+				SetName("unique_client_shot_id"),
+		},
+	}
+
+	err := mdb.EnsureIndexes(ctx, db.TennisMatchupsShotsCollection, shotIndexes)
+	if err != nil {
+		log.Printf("Failed to create shot indexes: %v", err)
+		return err
+	}
+
+	log.Println("Shot indexes created successfully")
+	return nil
+}
+
+// EnsureRatingIndexes creates the necessary indexes for the rating history collection
+func EnsureRatingIndexes(ctx context.Context, mdb *db.MongoDB) error {
+	ratingIndexes := []mongo.IndexModel{
+		{
+			// A user's history and latest rating are read newest first
+			Keys: bson.D{
+				{Key: "userId", Value: 1},
+				{Key: "createdAt", Value: -1},
+				{Key: "_id", Value: -1},
+			},
+			Options: options.Index().
+				SetName("user_history"),
+		},
+		{
+			Keys: bson.D{{Key: "matchUpId", Value: 1}},
+			Options: options.Index().
+				SetName("matchup_id"),
+		},
+	}
+
+	err := mdb.EnsureIndexes(ctx, db.TennisRatingHistoryCollection, ratingIndexes)
+	if err != nil {
+		log.Printf("Failed to create rating indexes: %v", err)
+		return err
+	}
+
+	log.Println("Rating indexes created successfully")
+	return nil
+}
@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
 	"github.com/CourtIQ/courtiq-backend/shared/pkg/db"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
 	"github.com/CourtIQ/courtiq-backend/shared/pkg/repository"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -19,12 +23,16 @@ type MatchupsRepository interface {
 	Delete(ctx context.Context, id primitive.ObjectID) (bool, error)
 	GetMatchups(ctx context.Context, limit, offset *int) ([]*model.MatchUp, error)
 	GetMatchupsByTeam(ctx context.Context, teamID primitive.ObjectID, limit, offset *int) ([]*model.MatchUp, error)
-	GetMyMatchupsByStatus(ctx context.Context, status model.MatchUpStatus, limit, offset *int) ([]*model.MatchUp, error)
+	GetMyMatchupsByStatus(ctx context.Context, userID primitive.ObjectID, status model.MatchUpStatus, limit, offset *int) ([]*model.MatchUp, error)
+	FindByParticipant(ctx context.Context, participantID primitive.ObjectID, filter *model.MatchUpFilterInput, limit, offset *int) ([]*model.MatchUp, error)
+	FindDecided(ctx context.Context) ([]*model.MatchUp, error)
+	FindByInvitee(ctx context.Context, userID primitive.ObjectID, status *model.InvitationStatus, limit, offset *int) ([]*model.MatchUp, error)
 }
 
 // MatchupsRepositoryImpl implements MatchupsRepository
 type MatchupsRepositoryImpl struct {
 	baseRepo *repository.BaseRepository[model.MatchUp]
+	factory  *repository.RepositoryFactory
 }
 
 // NewMatchupsRepository creates a new instance of MatchupsRepository
@@ -32,6 +40,7 @@ func NewMatchupsRepository(factory *repository.RepositoryFactory) MatchupsReposi
 	baseRepo := repository.NewRepository[model.MatchUp](factory, db.TennisMatchupsCollection)
 	return &MatchupsRepositoryImpl{
 		baseRepo: baseRepo,
+		factory:  factory,
 	}
 }
 
@@ -57,13 +66,50 @@ func (r *MatchupsRepositoryImpl) Insert(ctx context.Context, matchup *model.Matc
 	return matchup, nil
 }
 
-// Update updates an existing matchup
+// Update replaces an existing matchup. The whole document is replaced so that
+// optional fields cleared in memory (e.g. lastShot after undoing every shot)
+// are removed from the stored document as well.
+//
+// The replace only applies if the stored version still matches the one the
+// matchup was read at, and bumps it. A matchup changed since it was read is
+// rejected with a conflict rather than silently overwritten.
 func (r *MatchupsRepositoryImpl) Update(ctx context.Context, matchup *model.MatchUp) (*model.MatchUp, error) {
-	updated, err := r.baseRepo.Update(ctx, matchup.ID, matchup)
+	expected := matchup.Version
+	filter := bson.M{"_id": matchup.ID, "version": expected}
+	if expected == 0 {
+		// Matchups stored before versioning have no version yet
+		filter = bson.M{"_id": matchup.ID, "$or": []bson.M{
+			{"version": 0},
+			{"version": bson.M{"$exists": false}},
+		}}
+	}
+	opts := options.FindOneAndReplace().SetReturnDocument(options.After)
+
+	matchup.Version = expected + 1
+	var updated model.MatchUp
+	collection := r.factory.GetCollection(db.TennisMatchupsCollection)
+	err := collection.FindOneAndReplace(ctx, filter, matchup, opts).Decode(&updated)
 	if err != nil {
-		return nil, err
+		matchup.Version = expected
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, r.updateMissError(ctx, matchup.ID)
+		}
+		return nil, sharedErrors.WrapError(err, "failed to update matchup")
+	}
+	return &updated, nil
+}
+
+// updateMissError tells apart an update of a matchup that doesn't exist from
+// one made against a stale version
+func (r *MatchupsRepositoryImpl) updateMissError(ctx context.Context, id primitive.ObjectID) error {
+	count, err := r.baseRepo.Count(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
 	}
-	return updated, nil
+	if count == 0 {
+		return sharedErrors.ErrNotFound
+	}
+	return internalErrors.NewMatchUpModifiedError()
 }
 
 // Delete deletes a matchup
@@ -117,13 +163,73 @@ func (r *MatchupsRepositoryImpl) GetMatchupsByTeam(ctx context.Context, teamID p
 	return matchups, nil
 }
 
-// GetMyMatchupsByStatus retrieves matchups for the current user with a specific status
-func (r *MatchupsRepositoryImpl) GetMyMatchupsByStatus(ctx context.Context, status model.MatchUpStatus, limit, offset *int) ([]*model.MatchUp, error) {
+// GetMyMatchupsByStatus retrieves matchups the user took part in with a specific status
+func (r *MatchupsRepositoryImpl) GetMyMatchupsByStatus(ctx context.Context, userID primitive.ObjectID, status model.MatchUpStatus, limit, offset *int) ([]*model.MatchUp, error) {
+	filter := &model.MatchUpFilterInput{
+		Status: []model.MatchUpStatus{status},
+	}
+	return r.FindByParticipant(ctx, userID, filter, limit, offset)
+}
+
+// FindByParticipant retrieves matchups a user took part in, most recently
+// started first, narrowed down by the optional filter
+func (r *MatchupsRepositoryImpl) FindByParticipant(ctx context.Context, participantID primitive.ObjectID, filter *model.MatchUpFilterInput, limit, offset *int) ([]*model.MatchUp, error) {
+	opts := options.Find().SetSort(bson.D{
+		{Key: "startTime", Value: -1},
+		{Key: "_id", Value: -1},
+	})
+	if limit != nil {
+		opts.SetLimit(int64(*limit))
+	}
+	if offset != nil {
+		opts.SetSkip(int64(*offset))
+	}
+
+	matchups, err := r.baseRepo.Find(ctx, participantFilter(participantID, filter), opts)
+	if err != nil {
+		return nil, err
+	}
+	return matchups, nil
+}
+
+// FindDecided retrieves every completed or retired matchup in the order they
+// ended, oldest first
+func (r *MatchupsRepositoryImpl) FindDecided(ctx context.Context) ([]*model.MatchUp, error) {
+	filter := bson.M{"matchUpStatus": bson.M{"$in": []model.MatchUpStatus{
+		model.MatchUpStatusCompleted,
+		model.MatchUpStatusRetired,
+	}}}
+	opts := options.Find().SetSort(bson.D{
+		{Key: "endTime", Value: 1},
+		{Key: "_id", Value: 1},
+	})
+
+	matchups, err := r.baseRepo.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	return matchups, nil
+}
+
+// FindByInvitee retrieves the matchups that have not started yet and that a
+// user was invited to, newest first
+func (r *MatchupsRepositoryImpl) FindByInvitee(ctx context.Context, userID primitive.ObjectID, status *model.InvitationStatus, limit, offset *int) ([]*model.MatchUp, error) {
+	invitation := bson.M{"_id": userID, "invitationStatus": bson.M{"$exists": true}}
+	if status != nil {
+		invitation["invitationStatus"] = *status
+	}
 	filter := bson.M{
-		"status": status,
+		"participants": bson.M{"$elemMatch": invitation},
+		"matchUpStatus": bson.M{"$in": []model.MatchUpStatus{
+			model.MatchUpStatusRequested,
+			model.MatchUpStatusScheduled,
+		}},
 	}
 
-	opts := options.Find()
+	opts := options.Find().SetSort(bson.D{
+		{Key: "createdAt", Value: -1},
+		{Key: "_id", Value: -1},
+	})
 	if limit != nil {
 		opts.SetLimit(int64(*limit))
 	}
@@ -137,3 +243,70 @@ func (r *MatchupsRepositoryImpl) GetMyMatchupsByStatus(ctx context.Context, stat
 	}
 	return matchups, nil
 }
+
+// participantFilter builds the query for matchups a user took part in
+func participantFilter(participantID primitive.ObjectID, filter *model.MatchUpFilterInput) bson.M {
+	conditions := []bson.M{
+		{"participants._id": participantID},
+	}
+	if filter == nil {
+		return conditions[0]
+	}
+
+	if len(filter.Status) > 0 {
+		conditions = append(conditions, bson.M{"matchUpStatus": bson.M{"$in": filter.Status}})
+	}
+	if filter.MatchUpType != nil {
+		conditions = append(conditions, bson.M{"matchUpType": *filter.MatchUpType})
+	}
+	if filter.ParticipantID != nil {
+		conditions = append(conditions, bson.M{"participants._id": *filter.ParticipantID})
+	}
+
+	startTime := bson.M{}
+	if filter.StartTimeFrom != nil {
+		startTime["$gte"] = *filter.StartTimeFrom
+	}
+	if filter.StartTimeTo != nil {
+		startTime["$lte"] = *filter.StartTimeTo
+	}
+	if len(startTime) > 0 {
+		conditions = append(conditions, bson.M{"startTime": startTime})
+	}
+
+	// Side-relative filters have to hold for one of the two sides the user could be on
+	if filter.OpponentID != nil || filter.Outcome != nil {
+		var sides []bson.M
+		for _, side := range model.AllTeamSide {
+			sides = append(sides, sideFilter(participantID, side, filter))
+		}
+		conditions = append(conditions, bson.M{"$or": sides})
+	}
+
+	return bson.M{"$and": conditions}
+}
+
+// sideFilter matches the opponent and outcome filters for a user playing on the given side
+func sideFilter(participantID primitive.ObjectID, side model.TeamSide, filter *model.MatchUpFilterInput) bson.M {
+	opponentSide := model.TeamSideTeamA
+	if side == model.TeamSideTeamA {
+		opponentSide = model.TeamSideTeamB
+	}
+
+	conditions := []bson.M{
+		{"participants": bson.M{"$elemMatch": bson.M{"_id": participantID, "teamSide": side}}},
+	}
+	if filter.OpponentID != nil {
+		conditions = append(conditions, bson.M{
+			"participants": bson.M{"$elemMatch": bson.M{"_id": *filter.OpponentID, "teamSide": opponentSide}},
+		})
+	}
+	if filter.Outcome != nil {
+		winner := side
+		if *filter.Outcome == model.MatchUpOutcomeLost {
+			winner = opponentSide
+		}
+		conditions = append(conditions, bson.M{"winner": winner})
+	}
+	return bson.M{"$and": conditions}
+}
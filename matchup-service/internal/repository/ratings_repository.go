@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/db"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RatingsRepository defines the interface for player rating history
+type RatingsRepository interface {
+	FindLatest(ctx context.Context, userID primitive.ObjectID) (*model.RatingChange, error)
+	FindByMatchUp(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.RatingChange, error)
+	FindByUser(ctx context.Context, userID primitive.ObjectID, limit, offset *int) ([]*model.RatingChange, error)
+	InsertMany(ctx context.Context, changes []*model.RatingChange) error
+	DeleteByMatchUp(ctx context.Context, matchUpID primitive.ObjectID) (int64, error)
+	DeleteAll(ctx context.Context) (int64, error)
+}
+
+// RatingsRepositoryImpl implements RatingsRepository
+type RatingsRepositoryImpl struct {
+	baseRepo *repository.BaseRepository[model.RatingChange]
+	factory  *repository.RepositoryFactory
+}
+
+// NewRatingsRepository creates a new instance of RatingsRepository
+func NewRatingsRepository(factory *repository.RepositoryFactory) RatingsRepository {
+	baseRepo := repository.NewRepository[model.RatingChange](factory, db.TennisRatingHistoryCollection)
+	return &RatingsRepositoryImpl{
+		baseRepo: baseRepo,
+		factory:  factory,
+	}
+}
+
+// newestFirst orders rating changes from the latest back
+var newestFirst = bson.D{
+	{Key: "createdAt", Value: -1},
+	{Key: "_id", Value: -1},
+}
+
+// FindLatest finds a user's most recent rating change, or nil if they have never been rated
+func (r *RatingsRepositoryImpl) FindLatest(ctx context.Context, userID primitive.ObjectID) (*model.RatingChange, error) {
+	opts := options.Find().SetSort(newestFirst).SetLimit(1)
+
+	changes, err := r.baseRepo.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return changes[0], nil
+}
+
+// FindByMatchUp retrieves the rating changes a matchup made
+func (r *RatingsRepositoryImpl) FindByMatchUp(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.RatingChange, error) {
+	changes, err := r.baseRepo.Find(ctx, bson.M{"matchUpId": matchUpID})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// FindByUser retrieves a user's rating history, newest first
+func (r *RatingsRepositoryImpl) FindByUser(ctx context.Context, userID primitive.ObjectID, limit, offset *int) ([]*model.RatingChange, error) {
+	opts := options.Find().SetSort(newestFirst)
+	if limit != nil {
+		opts.SetLimit(int64(*limit))
+	}
+	if offset != nil {
+		opts.SetSkip(int64(*offset))
+	}
+
+	changes, err := r.baseRepo.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// InsertMany saves the rating changes made by one or more matchups
+func (r *RatingsRepositoryImpl) InsertMany(ctx context.Context, changes []*model.RatingChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(changes))
+	for i, change := range changes {
+		if change.ID == primitive.NilObjectID {
+			change.ID = primitive.NewObjectID()
+		}
+		documents[i] = change
+	}
+
+	collection := r.factory.GetCollection(db.TennisRatingHistoryCollection)
+	if _, err := collection.InsertMany(ctx, documents); err != nil {
+		return sharedErrors.WrapError(err, "failed to insert rating changes")
+	}
+	return nil
+}
+
+// DeleteByMatchUp removes the rating changes a matchup made
+func (r *RatingsRepositoryImpl) DeleteByMatchUp(ctx context.Context, matchUpID primitive.ObjectID) (int64, error) {
+	collection := r.factory.GetCollection(db.TennisRatingHistoryCollection)
+	result, err := collection.DeleteMany(ctx, bson.M{"matchUpId": matchUpID})
+	if err != nil {
+		return 0, sharedErrors.WrapError(err, "failed to delete rating changes")
+	}
+	return result.DeletedCount, nil
+}
+
+// DeleteAll removes every rating change, ahead of rebuilding the history
+func (r *RatingsRepositoryImpl) DeleteAll(ctx context.Context) (int64, error) {
+	collection := r.factory.GetCollection(db.TennisRatingHistoryCollection)
+	result, err := collection.DeleteMany(ctx, bson.M{})
+	if err != nil {
+		return 0, sharedErrors.WrapError(err, "failed to delete rating history")
+	}
+	return result.DeletedCount, nil
+}
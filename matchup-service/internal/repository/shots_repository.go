@@ -5,6 +5,7 @@ import (
 
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
 	"github.com/CourtIQ/courtiq-backend/shared/pkg/db"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
 	"github.com/CourtIQ/courtiq-backend/shared/pkg/repository"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -17,9 +18,12 @@ type ShotsRepository interface {
 	FindByMatchUpID(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.MatchUpShot, error)
 	FindShotsByGame(ctx context.Context, matchUpID primitive.ObjectID, setNumber, gameNumber int) ([]*model.MatchUpShot, error)
 	FindLastShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error)
+	FindByClientShotID(ctx context.Context, matchUpID primitive.ObjectID, clientShotID string) (*model.MatchUpShot, error)
 	Insert(ctx context.Context, shot *model.MatchUpShot) (*model.MatchUpShot, error)
 	Update(ctx context.Context, shot *model.MatchUpShot) (*model.MatchUpShot, error)
 	Delete(ctx context.Context, id primitive.ObjectID) (bool, error)
+	DeleteByIDs(ctx context.Context, ids []primitive.ObjectID) (int64, error)
+	ReassignPlayer(ctx context.Context, matchUpID, fromID, toID primitive.ObjectID) (int64, error)
 }
 
 // ShotsRepositoryImpl implements ShotsRepository
@@ -98,6 +102,17 @@ func (r *ShotsRepositoryImpl) FindLastShot(ctx context.Context, matchUpID primit
 	return &shot, nil
 }
 
+// FindByClientShotID finds the shot recorded with a client idempotency key,
+// or returns nil when the matchup has no such shot
+func (r *ShotsRepositoryImpl) FindByClientShotID(ctx context.Context, matchUpID primitive.ObjectID, clientShotID string) (*model.MatchUpShot, error) {
+	filter := bson.M{
+		"matchUpId":    matchUpID,
+		"clientShotId": clientShotID,
+	}
+
+	return r.baseRepo.FindOne(ctx, filter)
+}
+
 // Insert creates a new shot
 func (r *ShotsRepositoryImpl) Insert(ctx context.Context, shot *model.MatchUpShot) (*model.MatchUpShot, error) {
 	if shot.ID == primitive.NilObjectID {
@@ -128,3 +143,51 @@ func (r *ShotsRepositoryImpl) Delete(ctx context.Context, id primitive.ObjectID)
 	}
 	return true, nil
 }
+
+// DeleteByIDs deletes all shots with the given IDs
+func (r *ShotsRepositoryImpl) DeleteByIDs(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	filter := bson.M{
+		"_id": bson.M{"$in": ids},
+	}
+
+	collection := r.factory.GetCollection(db.TennisMatchupsShotsCollection)
+	result, err := collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, sharedErrors.WrapError(err, "failed to delete shots")
+	}
+	return result.DeletedCount, nil
+}
+
+// ReassignPlayer replaces a player's ID as hitter and as server in every shot
+// of a matchup, including the serving state each shot's snapshot restores on
+// undo and redo, returning how many references were changed
+func (r *ShotsRepositoryImpl) ReassignPlayer(ctx context.Context, matchUpID, fromID, toID primitive.ObjectID) (int64, error) {
+	collection := r.factory.GetCollection(db.TennisMatchupsShotsCollection)
+
+	var modified int64
+	for _, field := range []string{"hitterId", "pointContext.serverId", "matchStateAfterShot.currentServer"} {
+		filter := bson.M{"matchUpId": matchUpID, field: fromID}
+		result, err := collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{field: toID}})
+		if err != nil {
+			return modified, sharedErrors.WrapError(err, "failed to reassign shots")
+		}
+		modified += result.ModifiedCount
+	}
+
+	// The player's place in the serving order is kept
+	filter := bson.M{"matchUpId": matchUpID, "matchStateAfterShot.servingOrder": fromID}
+	update := bson.M{"$set": bson.M{"matchStateAfterShot.servingOrder.$[player]": toID}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"player": fromID}},
+	})
+	result, err := collection.UpdateMany(ctx, filter, update, opts)
+	if err != nil {
+		return modified, sharedErrors.WrapError(err, "failed to reassign shots")
+	}
+	modified += result.ModifiedCount
+	return modified, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/db"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TournamentsRepository defines the interface for tournaments. A tournament
+// is stored with its entries and draws in one document.
+type TournamentsRepository interface {
+	FindByID(ctx context.Context, id primitive.ObjectID) (*model.Tournament, error)
+	FindByUser(ctx context.Context, userID primitive.ObjectID, limit, offset *int) ([]*model.Tournament, error)
+	Insert(ctx context.Context, tournament *model.Tournament) (*model.Tournament, error)
+	Update(ctx context.Context, tournament *model.Tournament) (*model.Tournament, error)
+}
+
+// TournamentsRepositoryImpl implements TournamentsRepository
+type TournamentsRepositoryImpl struct {
+	baseRepo *repository.BaseRepository[model.Tournament]
+	factory  *repository.RepositoryFactory
+}
+
+// NewTournamentsRepository creates a new instance of TournamentsRepository
+func NewTournamentsRepository(factory *repository.RepositoryFactory) TournamentsRepository {
+	baseRepo := repository.NewRepository[model.Tournament](factory, db.TennisTournamentsCollection)
+	return &TournamentsRepositoryImpl{
+		baseRepo: baseRepo,
+		factory:  factory,
+	}
+}
+
+// FindByID finds a tournament by its ID
+func (r *TournamentsRepositoryImpl) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Tournament, error) {
+	tournament, err := r.baseRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return tournament, nil
+}
+
+// FindByUser retrieves the tournaments a user runs or is entered in, newest first
+func (r *TournamentsRepositoryImpl) FindByUser(ctx context.Context, userID primitive.ObjectID, limit, offset *int) ([]*model.Tournament, error) {
+	filter := bson.M{
+		"$or": []bson.M{
+			{"owner": userID},
+			{"entries.players._id": userID},
+		},
+	}
+
+	opts := options.Find().SetSort(bson.D{
+		{Key: "createdAt", Value: -1},
+		{Key: "_id", Value: -1},
+	})
+	if limit != nil {
+		opts.SetLimit(int64(*limit))
+	}
+	if offset != nil {
+		opts.SetSkip(int64(*offset))
+	}
+
+	tournaments, err := r.baseRepo.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	return tournaments, nil
+}
+
+// Insert saves a new tournament
+func (r *TournamentsRepositoryImpl) Insert(ctx context.Context, tournament *model.Tournament) (*model.Tournament, error) {
+	if tournament.ID == primitive.NilObjectID {
+		tournament.ID = primitive.NewObjectID()
+	}
+
+	created, err := r.baseRepo.Insert(ctx, tournament)
+	if err != nil {
+		return nil, err
+	}
+	return created.(*model.Tournament), nil
+}
+
+// Update saves a tournament's entries and draws
+func (r *TournamentsRepositoryImpl) Update(ctx context.Context, tournament *model.Tournament) (*model.Tournament, error) {
+	updated, err := r.baseRepo.Update(ctx, tournament.ID, tournament)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
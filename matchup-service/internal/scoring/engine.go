@@ -0,0 +1,249 @@
+package scoring
+
+import (
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+)
+
+// PointResult describes what awarding a single point did to the match
+type PointResult struct {
+	PointWinner    model.TeamSide
+	GameCompleted  bool
+	SetCompleted   bool
+	MatchCompleted bool
+}
+
+// Engine applies tennis scoring rules for a single MatchUpFormat.
+// It never mutates the score it is given: every transition returns a fresh
+// copy, so the previous state can stay attached to the shot that produced it.
+type Engine struct {
+	format *model.MatchUpFormat
+}
+
+// NewEngine creates a scoring engine for the given format
+func NewEngine(format *model.MatchUpFormat) *Engine {
+	return &Engine{format: format}
+}
+
+// SetFormatFor returns the set format in force for a 1-based set index,
+// using finalSetFormat for the deciding set when one is configured
+func (e *Engine) SetFormatFor(setIndex int) *model.SetFormat {
+	if e.format.FinalSetFormat != nil && setIndex == int(e.format.NumberOfSets) {
+		return e.format.FinalSetFormat
+	}
+	return e.format.SetFormat
+}
+
+// SetsToWin returns how many sets a side needs to take the match
+func (e *Engine) SetsToWin() int {
+	return int(e.format.NumberOfSets)/2 + 1
+}
+
+// InitialScore creates the score for a match that has not started yet
+func (e *Engine) InitialScore() *model.MatchUpScore {
+	return &model.MatchUpScore{
+		Sets:            []*model.SetScore{e.newSet(1)},
+		IsMatchComplete: false,
+	}
+}
+
+// AwardPoint returns the score after the given side wins the next point
+func (e *Engine) AwardPoint(score *model.MatchUpScore, winner model.TeamSide) (*model.MatchUpScore, PointResult, error) {
+	result := PointResult{PointWinner: winner}
+	if score.IsMatchComplete {
+		return nil, result, internalErrors.NewMatchUpAlreadyDecidedError()
+	}
+
+	next := CloneScore(score)
+	set := CurrentSet(next)
+	if set == nil {
+		return nil, result, internalErrors.NewInvalidMatchFormatError("score has no sets")
+	}
+	setFormat := e.SetFormatFor(set.SetIndex)
+	won, lost := SideScore(set, winner), SideScore(set, Opponent(winner))
+
+	var gameWon bool
+	if set.IsTiebreakActive {
+		gameWon = awardTiebreakPoint(setFormat.TiebreakFormat, won, lost)
+	} else {
+		gameWon = awardGamePoint(setFormat.DeuceType, set, won, lost)
+	}
+	if !gameWon {
+		return next, result, nil
+	}
+
+	// The game (or tiebreak) is over, so it counts as one game for the winner
+	result.GameCompleted = true
+	won.GamesWon++
+	won.InGameScore = model.InGameScoreZero
+	lost.InGameScore = model.InGameScoreZero
+	set.DeuceCount = 0
+
+	setWon := false
+	switch {
+	case set.IsTiebreakActive:
+		set.IsTiebreakActive = false
+		setWon = true
+	case setWonOnGames(setFormat, won.GamesWon, lost.GamesWon):
+		setWon = true
+	case tiebreakDue(setFormat, won.GamesWon, lost.GamesWon):
+		startTiebreak(set)
+	}
+	if !setWon {
+		return next, result, nil
+	}
+
+	result.SetCompleted = true
+	set.IsCompleted = true
+	if SetsWon(next, winner) >= e.SetsToWin() {
+		result.MatchCompleted = true
+		next.IsMatchComplete = true
+		return next, result, nil
+	}
+
+	next.Sets = append(next.Sets, e.newSet(set.SetIndex+1))
+	return next, result, nil
+}
+
+// AwardGame returns the score after the given side wins the rest of the game
+// being played, or of the tiebreak, as when the other side is penalised a game
+func (e *Engine) AwardGame(score *model.MatchUpScore, winner model.TeamSide) (*model.MatchUpScore, PointResult, error) {
+	for {
+		next, result, err := e.AwardPoint(score, winner)
+		if err != nil || result.GameCompleted {
+			return next, result, err
+		}
+		score = next
+	}
+}
+
+// AwardMatch returns the score after the given side is awarded the match, as
+// when the other side is defaulted. The games and points stand as they were.
+func (e *Engine) AwardMatch(score *model.MatchUpScore, winner model.TeamSide) (*model.MatchUpScore, PointResult, error) {
+	result := PointResult{PointWinner: winner}
+	if score.IsMatchComplete {
+		return nil, result, internalErrors.NewMatchUpAlreadyDecidedError()
+	}
+
+	next := CloneScore(score)
+	next.IsMatchComplete = true
+	result.MatchCompleted = true
+	return next, result, nil
+}
+
+// newSet creates an empty set, starting straight in a tiebreak when the
+// format calls for one at 0–0 (e.g. a match tiebreak in lieu of a final set)
+func (e *Engine) newSet(index int) *model.SetScore {
+	set := &model.SetScore{
+		SetIndex:         index,
+		IsCompleted:      false,
+		IsTiebreakActive: false,
+		Sides: []*model.SideSetScore{
+			{Side: model.TeamSideTeamA, GamesWon: 0, InGameScore: model.InGameScoreZero},
+			{Side: model.TeamSideTeamB, GamesWon: 0, InGameScore: model.InGameScoreZero},
+		},
+	}
+	if tiebreakDue(e.SetFormatFor(index), 0, 0) {
+		startTiebreak(set)
+	}
+	return set
+}
+
+// awardGamePoint advances a regular game and reports whether it was won
+func awardGamePoint(deuceType model.DeuceType, set *model.SetScore, won, lost *model.SideSetScore) bool {
+	switch won.InGameScore {
+	case model.InGameScoreZero:
+		won.InGameScore = model.InGameScoreFifteen
+	case model.InGameScoreFifteen:
+		won.InGameScore = model.InGameScoreThirty
+	case model.InGameScoreThirty:
+		won.InGameScore = model.InGameScoreForty
+		if lost.InGameScore == model.InGameScoreForty {
+			set.DeuceCount++
+		}
+	case model.InGameScoreForty:
+		switch lost.InGameScore {
+		case model.InGameScoreForty:
+			if deuceIsDeciding(deuceType, set.DeuceCount) {
+				return true
+			}
+			won.InGameScore = model.InGameScoreAdv
+		case model.InGameScoreAdv:
+			// Back to deuce
+			lost.InGameScore = model.InGameScoreForty
+			set.DeuceCount++
+		default:
+			return true
+		}
+	case model.InGameScoreAdv:
+		return true
+	}
+	return false
+}
+
+// deuceIsDeciding reports whether the point played at deuce wins the game
+func deuceIsDeciding(deuceType model.DeuceType, deuceCount int) bool {
+	switch deuceType {
+	case model.DeuceTypeSuddenDeath:
+		return true
+	case model.DeuceTypeOneDeuce:
+		// Advantage is played after the first deuce only
+		return deuceCount >= 2
+	default:
+		return false
+	}
+}
+
+// awardTiebreakPoint advances a tiebreak and reports whether it was won
+func awardTiebreakPoint(format *model.TiebreakFormat, won, lost *model.SideSetScore) bool {
+	wonPoints := TiebreakPoints(won) + 1
+	lostPoints := TiebreakPoints(lost)
+	won.TiebreakPoints = &wonPoints
+
+	if format == nil || wonPoints < int(format.Points) {
+		return false
+	}
+	if format.MustWinByTwo {
+		return wonPoints-lostPoints >= 2
+	}
+	return wonPoints > lostPoints
+}
+
+// setWonOnGames reports whether a game count closes the set without a tiebreak
+func setWonOnGames(format *model.SetFormat, won, lost int) bool {
+	if won < int(format.NumberOfGames) {
+		return false
+	}
+	if won-lost >= 2 {
+		return true
+	}
+	return !format.MustWinByTwo && format.TiebreakFormat == nil && won > lost
+}
+
+// tiebreakDue reports whether the set score triggers a tiebreak
+func tiebreakDue(format *model.SetFormat, won, lost int) bool {
+	if format.TiebreakFormat == nil {
+		return false
+	}
+	at := TiebreakAt(format)
+	return won == at && lost == at
+}
+
+// startTiebreak switches a set into tiebreak scoring
+func startTiebreak(set *model.SetScore) {
+	set.IsTiebreakActive = true
+	for _, side := range set.Sides {
+		zero := 0
+		side.TiebreakPoints = &zero
+		side.InGameScore = model.InGameScoreZero
+	}
+}
+
+// TiebreakAt returns the games-all score at which a set's tiebreak starts,
+// defaulting to the number of games in the set
+func TiebreakAt(format *model.SetFormat) int {
+	if format.TiebreakFormat != nil && format.TiebreakFormat.TiebreakAt != nil {
+		return *format.TiebreakFormat.TiebreakAt
+	}
+	return int(format.NumberOfGames)
+}
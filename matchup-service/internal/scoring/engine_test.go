@@ -0,0 +1,368 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/schema/scalars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFormat builds a format with the same rules for every set
+func testFormat(sets int, games int, deuce model.DeuceType, tiebreakAt *int) *model.MatchUpFormat {
+	setFormat := &model.SetFormat{
+		NumberOfGames: scalars.NumberOfGames(games),
+		DeuceType:     deuce,
+		MustWinByTwo:  tiebreakAt == nil,
+	}
+	if tiebreakAt != nil {
+		setFormat.TiebreakFormat = &model.TiebreakFormat{
+			Points:       7,
+			MustWinByTwo: true,
+			TiebreakAt:   tiebreakAt,
+		}
+	}
+	return &model.MatchUpFormat{
+		NumberOfSets: scalars.NumberOfSets(sets),
+		SetFormat:    setFormat,
+	}
+}
+
+// play awards the given sequence of points and returns the final score
+func play(t *testing.T, engine *Engine, score *model.MatchUpScore, winners ...model.TeamSide) (*model.MatchUpScore, PointResult) {
+	t.Helper()
+	var result PointResult
+	for _, winner := range winners {
+		var err error
+		score, result, err = engine.AwardPoint(score, winner)
+		require.NoError(t, err)
+	}
+	return score, result
+}
+
+// repeat returns a side repeated n times
+func repeat(side model.TeamSide, n int) []model.TeamSide {
+	sides := make([]model.TeamSide, n)
+	for i := range sides {
+		sides[i] = side
+	}
+	return sides
+}
+
+// games returns the points for n straight games won by a side
+func games(side model.TeamSide, n int) []model.TeamSide {
+	return repeat(side, 4*n)
+}
+
+// deuce returns the points that take a fresh game to 40-40
+func deuce() []model.TeamSide {
+	return []model.TeamSide{
+		model.TeamSideTeamA, model.TeamSideTeamB,
+		model.TeamSideTeamA, model.TeamSideTeamB,
+		model.TeamSideTeamA, model.TeamSideTeamB,
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+const (
+	a = model.TeamSideTeamA
+	b = model.TeamSideTeamB
+)
+
+func TestAwardPointDoesNotMutateInput(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+	initial := engine.InitialScore()
+
+	next, result, err := engine.AwardPoint(initial, a)
+	require.NoError(t, err)
+
+	assert.Equal(t, model.InGameScoreZero, SideScore(initial.Sets[0], a).InGameScore)
+	assert.Equal(t, model.InGameScoreFifteen, SideScore(next.Sets[0], a).InGameScore)
+	assert.False(t, result.GameCompleted)
+}
+
+func TestGameProgression(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+
+	score, result := play(t, engine, engine.InitialScore(), a, a, a)
+	assert.Equal(t, model.InGameScoreForty, SideScore(score.Sets[0], a).InGameScore)
+	assert.False(t, result.GameCompleted)
+
+	score, result = play(t, engine, score, a)
+	assert.True(t, result.GameCompleted)
+	assert.Equal(t, 1, SideScore(score.Sets[0], a).GamesWon)
+	assert.Equal(t, model.InGameScoreZero, SideScore(score.Sets[0], a).InGameScore)
+}
+
+func TestDeuceTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		deuceType  model.DeuceType
+		points     []model.TeamSide
+		gameWon    bool
+		deuceCount int
+	}{
+		{"normal deuce gives advantage", model.DeuceTypeNormalDeuce, []model.TeamSide{a}, false, 1},
+		{"normal deuce back to deuce", model.DeuceTypeNormalDeuce, []model.TeamSide{a, b}, false, 2},
+		{"normal deuce win from advantage", model.DeuceTypeNormalDeuce, []model.TeamSide{a, b, b, b}, true, 0},
+		{"sudden death decides at deuce", model.DeuceTypeSuddenDeath, []model.TeamSide{b}, true, 0},
+		{"one deuce plays advantage once", model.DeuceTypeOneDeuce, []model.TeamSide{a}, false, 1},
+		{"one deuce decides at second deuce", model.DeuceTypeOneDeuce, []model.TeamSide{a, b, b}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(testFormat(3, 6, tt.deuceType, intPtr(6)))
+			score, _ := play(t, engine, engine.InitialScore(), deuce()...)
+			score, result := play(t, engine, score, tt.points...)
+
+			assert.Equal(t, tt.gameWon, result.GameCompleted)
+			assert.Equal(t, tt.deuceCount, score.Sets[0].DeuceCount)
+		})
+	}
+}
+
+func TestSetWonByTwoGames(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+
+	// 5-5, then 6-5 does not close the set
+	var points []model.TeamSide
+	for i := 0; i < 5; i++ {
+		points = append(points, games(a, 1)...)
+		points = append(points, games(b, 1)...)
+	}
+	score, _ := play(t, engine, engine.InitialScore(), points...)
+	score, result := play(t, engine, score, games(a, 1)...)
+	assert.False(t, result.SetCompleted)
+
+	// 7-5 does
+	score, result = play(t, engine, score, games(a, 1)...)
+	assert.True(t, result.SetCompleted)
+	assert.Len(t, score.Sets, 2)
+	assert.Equal(t, 1, SetsWon(score, a))
+}
+
+func TestTiebreak(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+
+	var points []model.TeamSide
+	for i := 0; i < 6; i++ {
+		points = append(points, games(a, 1)...)
+		points = append(points, games(b, 1)...)
+	}
+	score, result := play(t, engine, engine.InitialScore(), points...)
+	require.True(t, result.GameCompleted)
+	assert.True(t, score.Sets[0].IsTiebreakActive)
+
+	// 6-6 in the tiebreak must be won by two
+	for i := 0; i < 6; i++ {
+		score, _ = play(t, engine, score, a, b)
+	}
+	score, result = play(t, engine, score, a)
+	assert.False(t, result.GameCompleted)
+	assert.Equal(t, 7, TiebreakPoints(SideScore(score.Sets[0], a)))
+
+	score, result = play(t, engine, score, a)
+	assert.True(t, result.SetCompleted)
+	assert.Equal(t, 7, SideScore(score.Sets[0], a).GamesWon)
+	assert.False(t, score.Sets[0].IsTiebreakActive)
+}
+
+func TestAdvantageSetWithoutTiebreak(t *testing.T) {
+	engine := NewEngine(testFormat(1, 6, model.DeuceTypeNormalDeuce, nil))
+
+	var points []model.TeamSide
+	for i := 0; i < 6; i++ {
+		points = append(points, games(a, 1)...)
+		points = append(points, games(b, 1)...)
+	}
+	score, _ := play(t, engine, engine.InitialScore(), points...)
+	assert.False(t, score.Sets[0].IsTiebreakActive)
+
+	score, result := play(t, engine, score, games(b, 2)...)
+	assert.True(t, result.MatchCompleted)
+	assert.Equal(t, 8, SideScore(score.Sets[0], b).GamesWon)
+}
+
+func TestFinalSetMatchTiebreak(t *testing.T) {
+	format := testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6))
+	format.FinalSetFormat = &model.SetFormat{
+		NumberOfGames: 1,
+		DeuceType:     model.DeuceTypeNormalDeuce,
+		MustWinByTwo:  false,
+		TiebreakFormat: &model.TiebreakFormat{
+			Points:       10,
+			MustWinByTwo: true,
+			TiebreakAt:   intPtr(0),
+		},
+	}
+	engine := NewEngine(format)
+
+	score, result := play(t, engine, engine.InitialScore(), games(a, 6)...)
+	require.True(t, result.SetCompleted)
+	score, result = play(t, engine, score, games(b, 6)...)
+	require.True(t, result.SetCompleted)
+
+	require.Len(t, score.Sets, 3)
+	assert.True(t, score.Sets[2].IsTiebreakActive)
+
+	score, result = play(t, engine, score, repeat(b, 9)...)
+	assert.False(t, result.MatchCompleted)
+	score, result = play(t, engine, score, b)
+	assert.True(t, result.MatchCompleted)
+	assert.True(t, score.IsMatchComplete)
+	assert.Equal(t, 2, SetsWon(score, b))
+}
+
+func TestAwardPointAfterMatchComplete(t *testing.T) {
+	format := testFormat(1, 1, model.DeuceTypeSuddenDeath, nil)
+	format.SetFormat.MustWinByTwo = false
+	engine := NewEngine(format)
+
+	score, result := play(t, engine, engine.InitialScore(), games(a, 1)...)
+	require.True(t, result.MatchCompleted)
+
+	_, _, err := engine.AwardPoint(score, b)
+	assert.Error(t, err)
+}
+
+func TestAwardGameAndMatch(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+
+	// At 40-AD a game penalty against TEAM_B still gives TEAM_A the game
+	score, _ := play(t, engine, engine.InitialScore(), append(deuce(), b)...)
+	score, result, err := engine.AwardGame(score, a)
+	require.NoError(t, err)
+	assert.True(t, result.GameCompleted)
+	assert.Equal(t, 1, SideScore(score.Sets[0], a).GamesWon)
+	assert.Equal(t, 0, SideScore(score.Sets[0], b).GamesWon)
+
+	// In a tiebreak the whole tiebreak goes, and with it the set
+	score = engine.InitialScore()
+	for i := 0; i < 6; i++ {
+		score, _ = play(t, engine, score, append(games(a, 1), games(b, 1)...)...)
+	}
+	score, _ = play(t, engine, score, b, b)
+	require.True(t, score.Sets[0].IsTiebreakActive)
+	score, result, err = engine.AwardGame(score, a)
+	require.NoError(t, err)
+	assert.True(t, result.SetCompleted)
+	assert.Equal(t, 1, SetsWon(score, a))
+
+	// A default decides the match where the score stands
+	score, _ = play(t, engine, score, a, b)
+	score, result, err = engine.AwardMatch(score, b)
+	require.NoError(t, err)
+	assert.True(t, result.MatchCompleted)
+	assert.False(t, result.GameCompleted)
+	assert.True(t, score.IsMatchComplete)
+	assert.Equal(t, model.InGameScoreFifteen, SideScore(CurrentSet(score), b).InGameScore)
+
+	_, _, err = engine.AwardMatch(score, a)
+	assert.Error(t, err)
+}
+
+func TestResolveShot(t *testing.T) {
+	serve := func(outcome model.ShotOutcome) *model.MatchUpShot {
+		return &model.MatchUpShot{ShotType: model.ShotTypeServe, HitterSide: a, ShotOutcome: outcome}
+	}
+	firstFault := serve(model.ShotOutcomeFirstFault)
+	firstFault.MatchStateAfterShot = &model.MatchStateSnapshot{PointCompleted: false}
+
+	t.Run("ace", func(t *testing.T) {
+		resolution := ResolveShot(serve(model.ShotOutcomeWonPoint), nil)
+		assert.True(t, resolution.PointCompleted)
+		assert.Equal(t, a, *resolution.PointWinner)
+		assert.Equal(t, model.PointWinReasonAce, *resolution.PointWinReason)
+	})
+
+	t.Run("first fault keeps the point alive", func(t *testing.T) {
+		resolution := ResolveShot(serve(model.ShotOutcomeFirstFault), nil)
+		assert.False(t, resolution.PointCompleted)
+	})
+
+	t.Run("double fault", func(t *testing.T) {
+		resolution := ResolveShot(serve(model.ShotOutcomeFirstFault), firstFault)
+		assert.True(t, resolution.PointCompleted)
+		assert.Equal(t, b, *resolution.PointWinner)
+		assert.Equal(t, model.PointWinReasonDoubleFault, *resolution.PointWinReason)
+	})
+
+	t.Run("rally error", func(t *testing.T) {
+		shot := &model.MatchUpShot{ShotType: model.ShotTypeGroundStroke, HitterSide: b, ShotOutcome: model.ShotOutcomeError}
+		resolution := ResolveShot(shot, nil)
+		assert.Equal(t, a, *resolution.PointWinner)
+		assert.Equal(t, model.PointWinReasonUnforcedError, *resolution.PointWinReason)
+	})
+}
+
+func TestValidateSequence(t *testing.T) {
+	rally := &model.MatchUpShot{
+		ShotType:            model.ShotTypeServe,
+		HitterSide:          a,
+		ShotOutcome:         model.ShotOutcomeContinuedRally,
+		MatchStateAfterShot: &model.MatchStateSnapshot{PointCompleted: false},
+	}
+
+	assert.Error(t, ValidateSequence(&model.MatchUpShot{ShotType: model.ShotTypeVolley, HitterSide: b}, nil))
+	assert.Error(t, ValidateSequence(&model.MatchUpShot{ShotType: model.ShotTypeServe, HitterSide: a}, rally))
+	assert.Error(t, ValidateSequence(&model.MatchUpShot{ShotType: model.ShotTypeGroundStroke, HitterSide: a}, rally))
+	assert.NoError(t, ValidateSequence(&model.MatchUpShot{ShotType: model.ShotTypeGroundStroke, HitterSide: b}, rally))
+
+	// A let is replayed, so only a serve can follow it, and a penalty can come at any time
+	letType := model.MatchEventTypeLet
+	secondServe := model.ServeNumberSecondServe
+	let := &model.MatchUpShot{
+		ShotType:            model.ShotTypeMatchEvent,
+		MatchEvent:          &letType,
+		ServeNumber:         &secondServe,
+		HitterSide:          a,
+		ShotOutcome:         model.ShotOutcomeContinuedRally,
+		MatchStateAfterShot: &model.MatchStateSnapshot{PointCompleted: false},
+	}
+	assert.Error(t, ValidateSequence(let, rally))
+	assert.Error(t, ValidateSequence(&model.MatchUpShot{ShotType: model.ShotTypeGroundStroke, HitterSide: b}, let))
+	assert.NoError(t, ValidateSequence(&model.MatchUpShot{ShotType: model.ShotTypeServe, HitterSide: a, ServeNumber: &secondServe}, let))
+	assert.True(t, FaultPending(let))
+
+	penalty := model.MatchEventTypePointPenalty
+	assert.NoError(t, ValidateSequence(&model.MatchUpShot{ShotType: model.ShotTypeMatchEvent, MatchEvent: &penalty, HitterSide: b}, rally))
+}
+
+func TestPointImportance(t *testing.T) {
+	standard := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+	noAd := NewEngine(testFormat(3, 6, model.DeuceTypeSuddenDeath, intPtr(6)))
+
+	// 5-5 in the first set, and 6-0 5-0 to TEAM_A
+	fiveAll := sixAll()[:40]
+	setUp := append(games(a, 6), games(a, 5)...)
+
+	tests := []struct {
+		name     string
+		engine   *Engine
+		points   []model.TeamSide
+		server   model.TeamSide
+		expected model.PointImportance
+	}{
+		{"first point", standard, nil, a, model.PointImportanceRegular},
+		{"server at 40-15", standard, []model.TeamSide{a, a, a, b}, a, model.PointImportanceGamePoint},
+		{"receiver at 15-40", standard, []model.TeamSide{a, b, b, b}, a, model.PointImportanceBreakPoint},
+		{"advantage receiver", standard, append(deuce(), b), a, model.PointImportanceBreakPoint},
+		{"no-ad deciding point", noAd, deuce(), a, model.PointImportanceBreakPoint},
+		{"serving for the set", standard, append(append(fiveAll, games(a, 1)...), a, a, a), a, model.PointImportanceSetPoint},
+		{"tiebreak at 6-5", standard, append(sixAll(), append(repeat(a, 6), repeat(b, 5)...)...), b, model.PointImportanceSetPoint},
+		{"tiebreak at 3-3", standard, append(sixAll(), a, b, a, b, a, b), a, model.PointImportanceRegular},
+		{"serving for the match", standard, append(setUp, a, a, a), a, model.PointImportanceMatchPoint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, _ := play(t, tt.engine, tt.engine.InitialScore(), tt.points...)
+			assert.Equal(t, tt.expected, tt.engine.PointImportance(score, tt.server))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package scoring
+
+import (
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+)
+
+// importanceRank orders point importance so the highest stake wins when
+// both sides could close something out on the same point
+var importanceRank = map[model.PointImportance]int{
+	model.PointImportanceRegular:    0,
+	model.PointImportanceGamePoint:  1,
+	model.PointImportanceBreakPoint: 2,
+	model.PointImportanceSetPoint:   3,
+	model.PointImportanceMatchPoint: 4,
+}
+
+// PointImportance classifies the next point from the score before it is
+// played. Each side is tried as the winner and the biggest thing either one
+// could close out decides the result, so a no-ad deciding point on serve is
+// reported as a break point and a tiebreak point that wins the set as a set point.
+func (e *Engine) PointImportance(score *model.MatchUpScore, serverSide model.TeamSide) model.PointImportance {
+	importance := model.PointImportanceRegular
+	if score == nil || score.IsMatchComplete {
+		return importance
+	}
+
+	for _, side := range []model.TeamSide{model.TeamSideTeamA, model.TeamSideTeamB} {
+		_, result, err := e.AwardPoint(score, side)
+		if err != nil {
+			continue
+		}
+
+		candidate := model.PointImportanceRegular
+		switch {
+		case result.MatchCompleted:
+			candidate = model.PointImportanceMatchPoint
+		case result.SetCompleted:
+			candidate = model.PointImportanceSetPoint
+		case result.GameCompleted && side != serverSide:
+			candidate = model.PointImportanceBreakPoint
+		case result.GameCompleted:
+			candidate = model.PointImportanceGamePoint
+		}
+		if importanceRank[candidate] > importanceRank[importance] {
+			importance = candidate
+		}
+	}
+	return importance
+}
+
+// IsBreakPoint reports whether the receiving side would win the server's
+// game by winning the next point. Tiebreak points are never break points.
+func (e *Engine) IsBreakPoint(score *model.MatchUpScore, serverSide model.TeamSide) bool {
+	set := CurrentSet(score)
+	if set == nil || set.IsTiebreakActive {
+		return false
+	}
+	_, result, err := e.AwardPoint(score, Opponent(serverSide))
+	return err == nil && result.GameCompleted
+}
@@ -0,0 +1,159 @@
+package scoring
+
+import (
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+)
+
+// ShotResolution describes what a single shot did to the point being played
+type ShotResolution struct {
+	PointCompleted bool
+	PointWinner    *model.TeamSide
+	PointWinReason *model.PointWinReason
+}
+
+// PointInProgress reports whether the previous shot left a point unfinished
+func PointInProgress(prev *model.MatchUpShot) bool {
+	return prev != nil && prev.MatchStateAfterShot != nil && !prev.MatchStateAfterShot.PointCompleted
+}
+
+// FaultPending reports whether the next shot has to be a second serve: after
+// a first fault, or a let on a second serve
+func FaultPending(prev *model.MatchUpShot) bool {
+	if !PointInProgress(prev) {
+		return false
+	}
+	if IsLet(prev) {
+		return prev.ServeNumber != nil && *prev.ServeNumber == model.ServeNumberSecondServe
+	}
+	return prev.ShotOutcome == model.ShotOutcomeFirstFault
+}
+
+// ServeDue reports whether the next shot has to be a serve, and which one.
+// A serve is due at the start of a point and after a first fault or a let.
+func ServeDue(prev *model.MatchUpShot) (model.ServeNumber, bool) {
+	switch {
+	case FaultPending(prev):
+		return model.ServeNumberSecondServe, true
+	case !PointInProgress(prev), IsLet(prev):
+		return model.ServeNumberFirstServe, true
+	}
+	return "", false
+}
+
+// IsLet reports whether a shot records a let, which replays the serve
+func IsLet(shot *model.MatchUpShot) bool {
+	return shot.ShotType == model.ShotTypeMatchEvent && shot.MatchEvent != nil && *shot.MatchEvent == model.MatchEventTypeLet
+}
+
+// ValidateSequence checks that a shot can legally follow the previous one.
+// The shot must already carry its hitter side.
+func ValidateSequence(shot *model.MatchUpShot, prev *model.MatchUpShot) error {
+	inProgress := PointInProgress(prev)
+	faultPending := FaultPending(prev)
+	serveNumber, serveDue := ServeDue(prev)
+
+	if shot.ShotType == model.ShotTypePoint {
+		if !serveDue || serveNumber != model.ServeNumberFirstServe {
+			return internalErrors.NewInvalidShotSequenceError("a point can only be recorded on its own once the rally in progress has ended")
+		}
+		return nil
+	}
+
+	// Penalties can be given at any time and end the point being played
+	if shot.ShotType == model.ShotTypeMatchEvent && !IsLet(shot) {
+		return nil
+	}
+
+	if shot.ShotType == model.ShotTypeServe || IsLet(shot) {
+		if !serveDue {
+			return internalErrors.NewInvalidShotSequenceError("a serve can only start a point or follow a first fault or a let")
+		}
+		if inProgress && shot.HitterID != prev.HitterID {
+			return internalErrors.NewInvalidShotSequenceError("serves within a point must be hit by the same server")
+		}
+		if shot.ServeNumber != nil && *shot.ServeNumber != serveNumber {
+			return internalErrors.NewInvalidShotSequenceError("expected " + serveNumber.String())
+		}
+		if !faultPending && shot.ShotOutcome == model.ShotOutcomeError {
+			return internalErrors.NewInvalidShotSequenceError("a missed first serve must be recorded as FIRST_FAULT")
+		}
+		return nil
+	}
+
+	if !inProgress {
+		return internalErrors.NewInvalidShotSequenceError("a point must start with a serve")
+	}
+	if faultPending {
+		return internalErrors.NewInvalidShotSequenceError("a first fault must be followed by a second serve")
+	}
+	if IsLet(prev) {
+		return internalErrors.NewInvalidShotSequenceError("a let must be followed by the serve again")
+	}
+	if shot.HitterSide == prev.HitterSide {
+		return internalErrors.NewInvalidShotSequenceError("shots in a rally must alternate between sides")
+	}
+	return nil
+}
+
+// ResolveShot works out whether a shot ended the point, who won it and why.
+// prev is the shot recorded immediately before, or nil at the start of the match.
+func ResolveShot(shot *model.MatchUpShot, prev *model.MatchUpShot) ShotResolution {
+	var resolution ShotResolution
+
+	// A point recorded on its own keeps its reason, which may be unknown
+	if shot.ShotType == model.ShotTypePoint {
+		winner := shot.HitterSide
+		if shot.ShotOutcome != model.ShotOutcomeWonPoint {
+			winner = Opponent(shot.HitterSide)
+		}
+		return ShotResolution{
+			PointCompleted: true,
+			PointWinner:    &winner,
+			PointWinReason: shot.PointWinReason,
+		}
+	}
+
+	// A let decides nothing, a penalty gives the point to the other side
+	if shot.ShotType == model.ShotTypeMatchEvent {
+		if IsLet(shot) {
+			return resolution
+		}
+		return pointTo(Opponent(shot.HitterSide), model.PointWinReasonPenalty)
+	}
+
+	switch shot.ShotOutcome {
+	case model.ShotOutcomeWonPoint:
+		reason := model.PointWinReasonWinner
+		if shot.ShotType == model.ShotTypeServe {
+			reason = model.PointWinReasonAce
+		}
+		if shot.PointWinReason != nil {
+			reason = *shot.PointWinReason
+		}
+		resolution = pointTo(shot.HitterSide, reason)
+	case model.ShotOutcomeError:
+		reason := model.PointWinReasonUnforcedError
+		if shot.ShotType == model.ShotTypeServe {
+			reason = model.PointWinReasonDoubleFault
+		} else if shot.PointWinReason != nil {
+			reason = *shot.PointWinReason
+		}
+		resolution = pointTo(Opponent(shot.HitterSide), reason)
+	case model.ShotOutcomeFirstFault:
+		if FaultPending(prev) {
+			resolution = pointTo(Opponent(shot.HitterSide), model.PointWinReasonDoubleFault)
+		}
+	}
+
+	return resolution
+}
+
+// pointTo builds a resolution for a completed point
+func pointTo(winner model.TeamSide, reason model.PointWinReason) ShotResolution {
+	return ShotResolution{
+		PointCompleted: true,
+		PointWinner:    &winner,
+		PointWinReason: &reason,
+	}
+}
@@ -0,0 +1,241 @@
+package scoring
+
+import (
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+)
+
+// inGameIndex orders the point scores of a regular game
+var inGameIndex = map[model.InGameScore]int{
+	model.InGameScoreZero:    0,
+	model.InGameScoreFifteen: 1,
+	model.InGameScoreThirty:  2,
+	model.InGameScoreForty:   3,
+	model.InGameScoreAdv:     4,
+}
+
+// stateKey identifies a score by everything that decides how the rest of the
+// match can go. Finished sets only matter through how many each side won.
+type stateKey struct {
+	setsA, setsB     int
+	gamesA, gamesB   int
+	pointsA, pointsB int
+	tiebreak         bool
+	deuces           int
+	opener           model.TeamSide
+}
+
+// WinModel is a Markov model of a match under one format. Every point is
+// independent and won by the server with a fixed probability for their side,
+// so the chance of team A winning depends only on the score and who serves.
+type WinModel struct {
+	engine *Engine
+	serve  map[model.TeamSide]float64
+	memo   map[stateKey]float64
+}
+
+// NewWinModel creates a model for a format, given the probability of each
+// side winning a point on its own serve
+func NewWinModel(format *model.MatchUpFormat, serveA, serveB float64) *WinModel {
+	return &WinModel{
+		engine: NewEngine(format),
+		serve: map[model.TeamSide]float64{
+			model.TeamSideTeamA: serveA,
+			model.TeamSideTeamB: serveB,
+		},
+		memo: make(map[stateKey]float64),
+	}
+}
+
+// WinProbability returns the probability that team A wins the match from a
+// score, given the side serving the next point
+func (m *WinModel) WinProbability(score *model.MatchUpScore, server model.TeamSide) float64 {
+	// Sides take turns serving within a set, so the serving side and the
+	// number of turns played give the side that opened the set
+	opener := server
+	if ServingSlot(score)%2 == 1 {
+		opener = Opponent(server)
+	}
+	return m.value(score, opener)
+}
+
+// value is WinProbability for a score and the side that served first in its current set
+func (m *WinModel) value(score *model.MatchUpScore, opener model.TeamSide) float64 {
+	if score.IsMatchComplete {
+		if MatchWinner(score) == model.TeamSideTeamA {
+			return 1
+		}
+		return 0
+	}
+
+	key := m.key(score, opener)
+	if v, ok := m.memo[key]; ok {
+		return v
+	}
+
+	var v float64
+	switch {
+	case m.pointCycle(score):
+		v = m.resolveCycle(score, opener, m.pointProbability, m.afterPoint)
+	case m.gameCycle(score):
+		v = m.resolveCycle(score, opener, m.gameProbability, m.afterGame)
+	default:
+		p := m.pointProbability(score, opener)
+		v = p*m.value(m.afterPoint(score, opener, model.TeamSideTeamA)) +
+			(1-p)*m.value(m.afterPoint(score, opener, model.TeamSideTeamB))
+	}
+	m.memo[key] = v
+	return v
+}
+
+// resolveCycle values a tied score that two more units, points or games,
+// either settle or tie again. Splitting the two units leads back to the same
+// situation, so only the chances of one side taking both matter.
+func (m *WinModel) resolveCycle(
+	score *model.MatchUpScore,
+	opener model.TeamSide,
+	probability func(*model.MatchUpScore, model.TeamSide) float64,
+	advance func(*model.MatchUpScore, model.TeamSide, model.TeamSide) (*model.MatchUpScore, model.TeamSide),
+) float64 {
+	afterA, openerA := advance(score, opener, model.TeamSideTeamA)
+	afterB, openerB := advance(score, opener, model.TeamSideTeamB)
+	bothA := probability(score, opener) * probability(afterA, openerA)
+	bothB := (1 - probability(score, opener)) * (1 - probability(afterB, openerB))
+	if bothA+bothB == 0 {
+		return 0.5
+	}
+
+	wonA, wonOpenerA := advance(afterA, openerA, model.TeamSideTeamA)
+	wonB, wonOpenerB := advance(afterB, openerB, model.TeamSideTeamB)
+	return (bothA*m.value(wonA, wonOpenerA) + bothB*m.value(wonB, wonOpenerB)) / (bothA + bothB)
+}
+
+// afterPoint returns the score after a side wins the next point, and the
+// side that opens the set then being played
+func (m *WinModel) afterPoint(score *model.MatchUpScore, opener, winner model.TeamSide) (*model.MatchUpScore, model.TeamSide) {
+	next, result, err := m.engine.AwardPoint(score, winner)
+	if err != nil {
+		return score, opener
+	}
+	if result.SetCompleted && !result.MatchCompleted {
+		// The set that follows opens with whoever's turn it would have been
+		if GamesPlayed(next.Sets[len(next.Sets)-2])%2 == 1 {
+			opener = Opponent(opener)
+		}
+	}
+	return next, opener
+}
+
+// afterGame returns the score after a side wins every point of the game being played
+func (m *WinModel) afterGame(score *model.MatchUpScore, opener, winner model.TeamSide) (*model.MatchUpScore, model.TeamSide) {
+	games := GamesPlayed(CurrentSet(score))
+	set := CurrentSet(score).SetIndex
+	for !score.IsMatchComplete && CurrentSet(score).SetIndex == set && GamesPlayed(CurrentSet(score)) == games {
+		score, opener = m.afterPoint(score, opener, winner)
+	}
+	return score, opener
+}
+
+// server returns the side serving the next point
+func (m *WinModel) server(score *model.MatchUpScore, opener model.TeamSide) model.TeamSide {
+	if ServingSlot(score)%2 == 0 {
+		return opener
+	}
+	return Opponent(opener)
+}
+
+// pointProbability returns the probability that team A wins the next point
+func (m *WinModel) pointProbability(score *model.MatchUpScore, opener model.TeamSide) float64 {
+	server := m.server(score, opener)
+	if server == model.TeamSideTeamA {
+		return m.serve[server]
+	}
+	return 1 - m.serve[server]
+}
+
+// gameProbability returns the probability that team A wins a game about to start
+func (m *WinModel) gameProbability(score *model.MatchUpScore, opener model.TeamSide) float64 {
+	server := m.server(score, opener)
+	setFormat := m.engine.SetFormatFor(CurrentSet(score).SetIndex)
+	hold := HoldProbability(m.serve[server], setFormat.DeuceType)
+	if server == model.TeamSideTeamA {
+		return hold
+	}
+	return 1 - hold
+}
+
+// pointCycle reports whether the score is a tie that repeats point by point:
+// deuce with advantage, or a tiebreak tied at game point that must be won by two
+func (m *WinModel) pointCycle(score *model.MatchUpScore) bool {
+	set := CurrentSet(score)
+	setFormat := m.engine.SetFormatFor(set.SetIndex)
+	a, b := SideScore(set, model.TeamSideTeamA), SideScore(set, model.TeamSideTeamB)
+
+	if set.IsTiebreakActive {
+		tiebreak := setFormat.TiebreakFormat
+		return tiebreak != nil && tiebreak.MustWinByTwo &&
+			TiebreakPoints(a) == TiebreakPoints(b) && TiebreakPoints(a) >= int(tiebreak.Points)-1
+	}
+	return setFormat.DeuceType == model.DeuceTypeNormalDeuce &&
+		a.InGameScore == model.InGameScoreForty && b.InGameScore == model.InGameScoreForty
+}
+
+// gameCycle reports whether the score is the start of a game in an advantage
+// set tied late enough that the set can only be won by two clear games
+func (m *WinModel) gameCycle(score *model.MatchUpScore) bool {
+	set := CurrentSet(score)
+	setFormat := m.engine.SetFormatFor(set.SetIndex)
+	if set.IsTiebreakActive || setFormat.TiebreakFormat != nil || !setFormat.MustWinByTwo {
+		return false
+	}
+	a, b := SideScore(set, model.TeamSideTeamA), SideScore(set, model.TeamSideTeamB)
+	return a.InGameScore == model.InGameScoreZero && b.InGameScore == model.InGameScoreZero &&
+		a.GamesWon == b.GamesWon && a.GamesWon >= int(setFormat.NumberOfGames)-1
+}
+
+// key builds the memo key for a score
+func (m *WinModel) key(score *model.MatchUpScore, opener model.TeamSide) stateKey {
+	set := CurrentSet(score)
+	a, b := SideScore(set, model.TeamSideTeamA), SideScore(set, model.TeamSideTeamB)
+	key := stateKey{
+		setsA:    SetsWon(score, model.TeamSideTeamA),
+		setsB:    SetsWon(score, model.TeamSideTeamB),
+		gamesA:   a.GamesWon,
+		gamesB:   b.GamesWon,
+		tiebreak: set.IsTiebreakActive,
+		opener:   opener,
+	}
+	if set.IsTiebreakActive {
+		key.pointsA, key.pointsB = TiebreakPoints(a), TiebreakPoints(b)
+		return key
+	}
+	key.pointsA, key.pointsB = inGameIndex[a.InGameScore], inGameIndex[b.InGameScore]
+	// Only one deuce rule looks at how many deuces there have been
+	if m.engine.SetFormatFor(set.SetIndex).DeuceType == model.DeuceTypeOneDeuce {
+		key.deuces = min(set.DeuceCount, 2)
+	}
+	return key
+}
+
+// HoldProbability returns the probability that a server who wins each point
+// with probability p wins a game under a deuce rule
+func HoldProbability(p float64, deuceType model.DeuceType) float64 {
+	q := 1 - p
+	// Winning to love, fifteen or thirty, then from deuce
+	beforeDeuce := p * p * p * p * (1 + 4*q + 10*q*q)
+	reachDeuce := 20 * p * p * p * q * q * q
+
+	var fromDeuce float64
+	switch deuceType {
+	case model.DeuceTypeSuddenDeath:
+		fromDeuce = p
+	case model.DeuceTypeOneDeuce:
+		// One advantage, then a deciding point at the second deuce
+		fromDeuce = p*p + 2*p*q*p
+	default:
+		if p*p+q*q == 0 {
+			return 0.5
+		}
+		fromDeuce = p * p / (p*p + q*q)
+	}
+	return beforeDeuce + reachDeuce*fromDeuce
+}
@@ -0,0 +1,89 @@
+package scoring
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// simulate plays matches point by point with the engine and returns the
+// share team A won, serving in turns as the model assumes
+func simulate(format *model.MatchUpFormat, serveA, serveB float64, matches int) float64 {
+	engine := NewEngine(format)
+	rng := rand.New(rand.NewSource(1))
+	serve := map[model.TeamSide]float64{a: serveA, b: serveB}
+
+	won := 0
+	for i := 0; i < matches; i++ {
+		score, opener := engine.InitialScore(), a
+		for !score.IsMatchComplete {
+			server := opener
+			if ServingSlot(score)%2 == 1 {
+				server = Opponent(server)
+			}
+			winner := server
+			if rng.Float64() >= serve[server] {
+				winner = Opponent(server)
+			}
+			next, result, _ := engine.AwardPoint(score, winner)
+			if result.SetCompleted && GamesPlayed(CurrentSet(score))%2 == 0 {
+				opener = Opponent(opener)
+			}
+			score = next
+		}
+		if MatchWinner(score) == a {
+			won++
+		}
+	}
+	return float64(won) / float64(matches)
+}
+
+func TestHoldProbability(t *testing.T) {
+	assert.InDelta(t, 0.5, HoldProbability(0.5, model.DeuceTypeNormalDeuce), 1e-9)
+	assert.InDelta(t, 0.5, HoldProbability(0.5, model.DeuceTypeSuddenDeath), 1e-9)
+	assert.InDelta(t, 0.7357, HoldProbability(0.6, model.DeuceTypeNormalDeuce), 1e-4)
+	assert.InDelta(t, 1, HoldProbability(1, model.DeuceTypeNormalDeuce), 1e-9)
+
+	// Deciding points favour the receiver compared with playing advantage
+	assert.Less(t, HoldProbability(0.6, model.DeuceTypeSuddenDeath), HoldProbability(0.6, model.DeuceTypeOneDeuce))
+	assert.Less(t, HoldProbability(0.6, model.DeuceTypeOneDeuce), HoldProbability(0.6, model.DeuceTypeNormalDeuce))
+}
+
+func TestWinProbabilityEvenMatch(t *testing.T) {
+	winModel := NewWinModel(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)), 0.62, 0.62)
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+	assert.InDelta(t, 0.5, winModel.WinProbability(engine.InitialScore(), a), 1e-6)
+
+	// Breaking serve at the start of the match helps the breaker
+	score, _ := play(t, engine, engine.InitialScore(), games(b, 1)...)
+	assert.Less(t, winModel.WinProbability(score, b), 0.5)
+}
+
+func TestWinProbabilityMatchesSimulation(t *testing.T) {
+	formats := map[string]*model.MatchUpFormat{
+		// Deuce with advantage and tiebreaks won by two
+		"tiebreak sets": testFormat(3, 4, model.DeuceTypeNormalDeuce, intPtr(4)),
+		// Advantage sets without a tiebreak
+		"advantage set": testFormat(1, 4, model.DeuceTypeOneDeuce, nil),
+	}
+	for name, format := range formats {
+		t.Run(name, func(t *testing.T) {
+			winModel := NewWinModel(format, 0.58, 0.52)
+			expected := winModel.WinProbability(NewEngine(format).InitialScore(), a)
+			assert.Greater(t, expected, 0.5)
+			assert.InDelta(t, expected, simulate(format, 0.58, 0.52, 20000), 0.015)
+		})
+	}
+}
+
+func TestWinProbabilityOfDecidedMatch(t *testing.T) {
+	format := testFormat(1, 1, model.DeuceTypeSuddenDeath, nil)
+	engine := NewEngine(format)
+	winModel := NewWinModel(format, 0.6, 0.6)
+
+	score, result := play(t, engine, engine.InitialScore(), games(a, 2)...)
+	assert.True(t, result.MatchCompleted)
+	assert.Equal(t, 1.0, winModel.WinProbability(score, b))
+}
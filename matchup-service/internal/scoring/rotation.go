@@ -0,0 +1,214 @@
+package scoring
+
+import (
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// pointsPerEndChange is how many tiebreak points are played between changes of ends
+const pointsPerEndChange = 6
+
+// InitialServingOrder builds the serving rotation for the first set: the
+// initial server, then the first opponent, then their partners in the order
+// the participants were listed
+func InitialServingOrder(participants []*model.Participant, initialServer primitive.ObjectID) []primitive.ObjectID {
+	var serverSide model.TeamSide
+	for _, participant := range participants {
+		if participant.ID == initialServer {
+			serverSide = participant.TeamSide
+		}
+	}
+
+	servers := []primitive.ObjectID{initialServer}
+	var receivers []primitive.ObjectID
+	for _, participant := range participants {
+		switch {
+		case participant.ID == initialServer:
+		case participant.TeamSide == serverSide:
+			servers = append(servers, participant.ID)
+		default:
+			receivers = append(receivers, participant.ID)
+		}
+	}
+
+	order := make([]primitive.ObjectID, 0, len(participants))
+	for i := 0; i < len(servers) || i < len(receivers); i++ {
+		if i < len(servers) {
+			order = append(order, servers[i])
+		}
+		if i < len(receivers) {
+			order = append(order, receivers[i])
+		}
+	}
+	return order
+}
+
+// ServingSlot returns the position in the serving order of whoever serves the
+// next point. Each game is one slot; in a tiebreak the first point is one slot
+// and every following pair of points is another (1 then 2-2).
+func ServingSlot(score *model.MatchUpScore) int {
+	set := CurrentSet(score)
+	if set == nil {
+		return 0
+	}
+	slot := GamesPlayed(set)
+	if set.IsTiebreakActive {
+		slot += (tiebreakPointsPlayed(set) + 1) / 2
+	}
+	return slot
+}
+
+// NextServer returns the player due to serve the next point
+func NextServer(order []primitive.ObjectID, score *model.MatchUpScore) primitive.ObjectID {
+	if len(order) == 0 {
+		return primitive.NilObjectID
+	}
+	return order[ServingSlot(score)%len(order)]
+}
+
+// ChooseServer checks a serve starting a point against the serving order and
+// returns the order to use from now on. In doubles a team may pick which
+// partner serves first in each set, so on the first point of a team's first
+// turn the partner is accepted and the order is swapped to match. The very
+// first turn of the match is fixed by the initial server.
+func ChooseServer(order []primitive.ObjectID, score *model.MatchUpScore, hitter primitive.ObjectID) ([]primitive.ObjectID, bool) {
+	if NextServer(order, score) == hitter {
+		return order, true
+	}
+	if len(order) != 4 {
+		return order, false
+	}
+
+	set := CurrentSet(score)
+	slot := ServingSlot(score)
+	if slot >= 2 || (set.SetIndex == 1 && slot == 0) || !turnStarting(set) {
+		return order, false
+	}
+	partner := (slot + 2) % len(order)
+	if order[partner] != hitter {
+		return order, false
+	}
+
+	swapped := append([]primitive.ObjectID(nil), order...)
+	swapped[slot], swapped[partner] = swapped[partner], swapped[slot]
+	return swapped, true
+}
+
+// NextSetServingOrder rotates the serving order at the end of a set so the
+// next set opens with whoever's turn it would have been
+func NextSetServingOrder(order []primitive.ObjectID, completed *model.SetScore) []primitive.ObjectID {
+	if len(order) == 0 {
+		return order
+	}
+	shift := GamesPlayed(completed) % len(order)
+	return append(append([]primitive.ObjectID(nil), order[shift:]...), order[:shift]...)
+}
+
+// ServiceBox returns the box the next point is served into. Points alternate
+// between the deuce and ad boxes, starting on the deuce side in every game and tiebreak.
+func ServiceBox(score *model.MatchUpScore) model.ServiceBoxSide {
+	set := CurrentSet(score)
+	if set == nil {
+		return model.ServiceBoxSideDeuceSide
+	}
+
+	played := 0
+	if set.IsTiebreakActive {
+		played = tiebreakPointsPlayed(set)
+	} else {
+		for _, side := range set.Sides {
+			played += gamePoints(side.InGameScore)
+		}
+	}
+	if played%2 == 1 {
+		return model.ServiceBoxSideAdSide
+	}
+	return model.ServiceBoxSideDeuceSide
+}
+
+// CourtSide returns the end a team side plays the next point from. TEAM_A
+// starts at the north end; ends change after every odd game of a set and
+// every six points of a tiebreak.
+func CourtSide(score *model.MatchUpScore, side model.TeamSide) model.PhysicalCourtSide {
+	north := side == model.TeamSideTeamA
+	if endChanges(score)%2 == 1 {
+		north = !north
+	}
+	if north {
+		return model.PhysicalCourtSideNorthSide
+	}
+	return model.PhysicalCourtSideSouthSide
+}
+
+// CourtSides returns the current end of both team sides
+func CourtSides(score *model.MatchUpScore) []*model.TeamCourtSide {
+	sides := []model.TeamSide{model.TeamSideTeamA, model.TeamSideTeamB}
+	courtSides := make([]*model.TeamCourtSide, len(sides))
+	for i, side := range sides {
+		courtSides[i] = &model.TeamCourtSide{TeamSide: side, CourtSide: CourtSide(score, side)}
+	}
+	return courtSides
+}
+
+// endChanges counts how many times the players have changed ends so far
+func endChanges(score *model.MatchUpScore) int {
+	if score == nil {
+		return 0
+	}
+
+	changes := 0
+	for _, set := range score.Sets {
+		// One change after each odd game, which includes the end of an odd set
+		changes += (GamesPlayed(set) + 1) / 2
+
+		tiebreakPoints := tiebreakPointsPlayed(set)
+		switch {
+		case set.IsTiebreakActive:
+			changes += tiebreakPoints / pointsPerEndChange
+		case tiebreakPoints > 0:
+			// The change after the last tiebreak point is the end-of-set change
+			changes += (tiebreakPoints - 1) / pointsPerEndChange
+		}
+	}
+	return changes
+}
+
+// turnStarting reports whether no point has been played yet in the current serving turn
+func turnStarting(set *model.SetScore) bool {
+	if set.IsTiebreakActive {
+		played := tiebreakPointsPlayed(set)
+		return played == 0 || played%2 == 1
+	}
+	for _, side := range set.Sides {
+		if side.InGameScore != model.InGameScoreZero {
+			return false
+		}
+	}
+	return true
+}
+
+// tiebreakPointsPlayed returns the total tiebreak points recorded in a set
+func tiebreakPointsPlayed(set *model.SetScore) int {
+	played := 0
+	for _, side := range set.Sides {
+		played += TiebreakPoints(side)
+	}
+	return played
+}
+
+// gamePoints converts a game score into points won, counting advantage as
+// one more than forty so deuce and advantage keep the right parity
+func gamePoints(score model.InGameScore) int {
+	switch score {
+	case model.InGameScoreFifteen:
+		return 1
+	case model.InGameScoreThirty:
+		return 2
+	case model.InGameScoreForty:
+		return 3
+	case model.InGameScoreAdv:
+		return 4
+	default:
+		return 0
+	}
+}
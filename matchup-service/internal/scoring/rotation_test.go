@@ -0,0 +1,154 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// doublesParticipants returns two players per side, TEAM_A first
+func doublesParticipants() []*model.Participant {
+	return []*model.Participant{
+		{ID: primitive.NewObjectID(), TeamSide: a},
+		{ID: primitive.NewObjectID(), TeamSide: a},
+		{ID: primitive.NewObjectID(), TeamSide: b},
+		{ID: primitive.NewObjectID(), TeamSide: b},
+	}
+}
+
+// sixAll returns the points that take a fresh set to 6-6
+func sixAll() []model.TeamSide {
+	var points []model.TeamSide
+	for i := 0; i < 6; i++ {
+		points = append(points, games(a, 1)...)
+		points = append(points, games(b, 1)...)
+	}
+	return points
+}
+
+func TestInitialServingOrder(t *testing.T) {
+	p := doublesParticipants()
+
+	order := InitialServingOrder(p, p[1].ID)
+	assert.Equal(t, []primitive.ObjectID{p[1].ID, p[2].ID, p[0].ID, p[3].ID}, order)
+
+	singles := InitialServingOrder(p[1:3], p[2].ID)
+	assert.Equal(t, []primitive.ObjectID{p[2].ID, p[1].ID}, singles)
+}
+
+func TestNextServerAlternatesGames(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+	p := doublesParticipants()
+	order := InitialServingOrder(p, p[0].ID)
+
+	score := engine.InitialScore()
+	for game := 0; game < 5; game++ {
+		assert.Equal(t, order[game%4], NextServer(order, score))
+		score, _ = play(t, engine, score, games(a, 1)...)
+	}
+}
+
+func TestTiebreakServingRotation(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+	p := doublesParticipants()
+	order := InitialServingOrder(p, p[0].ID)
+
+	score, _ := play(t, engine, engine.InitialScore(), sixAll()...)
+
+	// Game 13 is slot 12: first point, then two points each
+	expected := []int{0, 1, 1, 2, 2, 3, 3, 0}
+	for point, slot := range expected {
+		assert.Equal(t, order[slot], NextServer(order, score), "tiebreak point %d", point+1)
+		assert.Equal(t, point%2 == 1, ServiceBox(score) == model.ServiceBoxSideAdSide)
+		score, _ = play(t, engine, score, []model.TeamSide{a, b}[point%2])
+	}
+}
+
+func TestNextSetServingOrder(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+	p := doublesParticipants()
+	order := InitialServingOrder(p, p[0].ID)
+
+	// 6-1 is seven games, so the set-two opener is whoever would serve game eight
+	score, _ := play(t, engine, engine.InitialScore(), append(games(b, 1), games(a, 6)...)...)
+	next := NextSetServingOrder(order, score.Sets[0])
+	assert.Equal(t, order[3], next[0])
+	assert.Equal(t, next[0], NextServer(next, score))
+
+	// After a tiebreak the player who received first serves the next set
+	score, _ = play(t, engine, engine.InitialScore(), sixAll()...)
+	score, _ = play(t, engine, score, repeat(a, 7)...)
+	assert.Equal(t, order[1], NextSetServingOrder(order, score.Sets[0])[0])
+}
+
+func TestChooseServer(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+	p := doublesParticipants()
+	order := InitialServingOrder(p, p[0].ID)
+	score := engine.InitialScore()
+
+	// The initial server is fixed
+	_, ok := ChooseServer(order, score, p[1].ID)
+	assert.False(t, ok)
+
+	// The receiving team picks its first server
+	score, _ = play(t, engine, score, games(a, 1)...)
+	swapped, ok := ChooseServer(order, score, p[3].ID)
+	assert.True(t, ok)
+	assert.Equal(t, []primitive.ObjectID{p[0].ID, p[3].ID, p[1].ID, p[2].ID}, swapped)
+
+	// After that the order is fixed for the set
+	score, _ = play(t, engine, score, games(a, 1)...)
+	_, ok = ChooseServer(swapped, score, p[0].ID)
+	assert.False(t, ok)
+
+	// and nobody can change mid-game
+	score, _ = play(t, engine, engine.InitialScore(), append(games(a, 1), a)...)
+	_, ok = ChooseServer(order, score, p[3].ID)
+	assert.False(t, ok)
+}
+
+func TestServiceBoxAlternates(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+
+	score := engine.InitialScore()
+	assert.Equal(t, model.ServiceBoxSideDeuceSide, ServiceBox(score))
+	score, _ = play(t, engine, score, a)
+	assert.Equal(t, model.ServiceBoxSideAdSide, ServiceBox(score))
+
+	// Deuce goes to the deuce box, advantage to the ad box
+	score, _ = play(t, engine, engine.InitialScore(), deuce()...)
+	assert.Equal(t, model.ServiceBoxSideDeuceSide, ServiceBox(score))
+	score, _ = play(t, engine, score, b)
+	assert.Equal(t, model.ServiceBoxSideAdSide, ServiceBox(score))
+}
+
+func TestCourtSides(t *testing.T) {
+	engine := NewEngine(testFormat(3, 6, model.DeuceTypeNormalDeuce, intPtr(6)))
+
+	score := engine.InitialScore()
+	assert.Equal(t, model.PhysicalCourtSideNorthSide, CourtSide(score, a))
+	assert.Equal(t, model.PhysicalCourtSideSouthSide, CourtSide(score, b))
+
+	// Ends change after the first game, not after the second
+	score, _ = play(t, engine, score, games(a, 1)...)
+	assert.Equal(t, model.PhysicalCourtSideSouthSide, CourtSide(score, a))
+	score, _ = play(t, engine, score, games(a, 1)...)
+	assert.Equal(t, model.PhysicalCourtSideSouthSide, CourtSide(score, a))
+
+	// In a tiebreak ends change every six points
+	score, _ = play(t, engine, engine.InitialScore(), sixAll()...)
+	assert.Equal(t, model.PhysicalCourtSideNorthSide, CourtSide(score, a))
+	score, _ = play(t, engine, score, a, b, a, b, a)
+	assert.Equal(t, model.PhysicalCourtSideNorthSide, CourtSide(score, a))
+	score, _ = play(t, engine, score, b)
+	assert.Equal(t, model.PhysicalCourtSideSouthSide, CourtSide(score, a))
+
+	// Winning the tiebreak 7-3 makes eight changes in the set: six over the
+	// first twelve games, one within the tiebreak and one at the end
+	score, _ = play(t, engine, score, a, a, a, a)
+	assert.True(t, score.Sets[0].IsCompleted)
+	assert.Equal(t, model.PhysicalCourtSideNorthSide, CourtSide(score, a))
+}
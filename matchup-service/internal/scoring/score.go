@@ -0,0 +1,193 @@
+package scoring
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+)
+
+// CloneScore deep copies a score so it can be advanced without touching the original
+func CloneScore(score *model.MatchUpScore) *model.MatchUpScore {
+	if score == nil {
+		return nil
+	}
+
+	clone := &model.MatchUpScore{
+		Sets:            make([]*model.SetScore, len(score.Sets)),
+		IsMatchComplete: score.IsMatchComplete,
+	}
+	for i, set := range score.Sets {
+		setClone := *set
+		setClone.Sides = make([]*model.SideSetScore, len(set.Sides))
+		for j, side := range set.Sides {
+			sideClone := *side
+			if side.TiebreakPoints != nil {
+				points := *side.TiebreakPoints
+				sideClone.TiebreakPoints = &points
+			}
+			setClone.Sides[j] = &sideClone
+		}
+		clone.Sets[i] = &setClone
+	}
+	return clone
+}
+
+// CurrentSet returns the set being played, or the last set once the match is over
+func CurrentSet(score *model.MatchUpScore) *model.SetScore {
+	if score == nil || len(score.Sets) == 0 {
+		return nil
+	}
+	return score.Sets[len(score.Sets)-1]
+}
+
+// SideScore returns a side's score within a set
+func SideScore(set *model.SetScore, side model.TeamSide) *model.SideSetScore {
+	for _, s := range set.Sides {
+		if s.Side == side {
+			return s
+		}
+	}
+	return nil
+}
+
+// Opponent returns the other team side
+func Opponent(side model.TeamSide) model.TeamSide {
+	if side == model.TeamSideTeamA {
+		return model.TeamSideTeamB
+	}
+	return model.TeamSideTeamA
+}
+
+// SetsWon counts the completed sets a side has won
+func SetsWon(score *model.MatchUpScore, side model.TeamSide) int {
+	won := 0
+	for _, set := range score.Sets {
+		if !set.IsCompleted {
+			continue
+		}
+		if SetWinner(set) == side {
+			won++
+		}
+	}
+	return won
+}
+
+// MatchWinner returns the side that has won more sets
+func MatchWinner(score *model.MatchUpScore) model.TeamSide {
+	if SetsWon(score, model.TeamSideTeamB) > SetsWon(score, model.TeamSideTeamA) {
+		return model.TeamSideTeamB
+	}
+	return model.TeamSideTeamA
+}
+
+// SetWinner returns the side that won a completed set
+func SetWinner(set *model.SetScore) model.TeamSide {
+	a, b := SideScore(set, model.TeamSideTeamA), SideScore(set, model.TeamSideTeamB)
+	if a.GamesWon > b.GamesWon {
+		return model.TeamSideTeamA
+	}
+	return model.TeamSideTeamB
+}
+
+// TiebreakPoints returns a side's tiebreak points, treating nil as zero
+func TiebreakPoints(side *model.SideSetScore) int {
+	if side.TiebreakPoints == nil {
+		return 0
+	}
+	return *side.TiebreakPoints
+}
+
+// GamesPlayed returns how many games have been completed in a set
+func GamesPlayed(set *model.SetScore) int {
+	games := 0
+	for _, side := range set.Sides {
+		games += side.GamesWon
+	}
+	return games
+}
+
+// Scoreline writes a score from one side's point of view, set by set, like
+// "6-4 6-7(5) [10-8]". A set decided in a tiebreak shows the loser's
+// tiebreak points, and a set played as a single tiebreak shows its points in
+// brackets. Sets with nothing played yet are left out.
+func Scoreline(score *model.MatchUpScore, side model.TeamSide) string {
+	if score == nil {
+		return ""
+	}
+
+	sets := make([]string, 0, len(score.Sets))
+	for _, set := range score.Sets {
+		ours, theirs := SideScore(set, side), SideScore(set, Opponent(side))
+		if ours == nil || theirs == nil {
+			continue
+		}
+		ourPoints, theirPoints := TiebreakPoints(ours), TiebreakPoints(theirs)
+		tiebreak := ourPoints+theirPoints > 0
+
+		switch {
+		case GamesPlayed(set) == 0 && !tiebreak:
+			continue
+		case tiebreak && GamesPlayed(set) <= 1 && set.IsCompleted:
+			sets = append(sets, fmt.Sprintf("[%d-%d]", ourPoints, theirPoints))
+		case tiebreak && set.IsCompleted:
+			sets = append(sets, fmt.Sprintf("%d-%d(%d)", ours.GamesWon, theirs.GamesWon, min(ourPoints, theirPoints)))
+		default:
+			sets = append(sets, fmt.Sprintf("%d-%d", ours.GamesWon, theirs.GamesWon))
+		}
+	}
+	return strings.Join(sets, " ")
+}
+
+// SameScore reports whether two scores are the same, treating missing
+// tiebreak points as zero
+func SameScore(x, y *model.MatchUpScore) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.IsMatchComplete != y.IsMatchComplete || len(x.Sets) != len(y.Sets) {
+		return false
+	}
+	for i, setX := range x.Sets {
+		setY := y.Sets[i]
+		if setX.SetIndex != setY.SetIndex || setX.IsCompleted != setY.IsCompleted ||
+			setX.IsTiebreakActive != setY.IsTiebreakActive || setX.DeuceCount != setY.DeuceCount {
+			return false
+		}
+		for _, side := range []model.TeamSide{model.TeamSideTeamA, model.TeamSideTeamB} {
+			sideX, sideY := SideScore(setX, side), SideScore(setY, side)
+			if sideX == nil || sideY == nil {
+				if sideX != sideY {
+					return false
+				}
+				continue
+			}
+			if sideX.GamesWon != sideY.GamesWon || sideX.InGameScore != sideY.InGameScore ||
+				TiebreakPoints(sideX) != TiebreakPoints(sideY) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SameSnapshot reports whether two match states recorded after a shot agree
+func SameSnapshot(x, y *model.MatchStateSnapshot) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	if x.PointCompleted != y.PointCompleted || x.GameCompleted != y.GameCompleted ||
+		x.SetCompleted != y.SetCompleted || x.MatchCompleted != y.MatchCompleted ||
+		x.CurrentServer != y.CurrentServer || len(x.ServingOrder) != len(y.ServingOrder) {
+		return false
+	}
+	if (x.PointWinner == nil) != (y.PointWinner == nil) || (x.PointWinner != nil && *x.PointWinner != *y.PointWinner) {
+		return false
+	}
+	for i := range x.ServingOrder {
+		if x.ServingOrder[i] != y.ServingOrder[i] {
+			return false
+		}
+	}
+	return SameScore(x.Score, y.Score)
+}
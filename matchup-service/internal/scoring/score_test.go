@@ -0,0 +1,71 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// setScore builds a set score with team A's games and tiebreak points first
+func setScore(completed bool, gamesA, gamesB int, tiebreak ...int) *model.SetScore {
+	sideA := &model.SideSetScore{Side: a, GamesWon: gamesA}
+	sideB := &model.SideSetScore{Side: b, GamesWon: gamesB}
+	if len(tiebreak) == 2 {
+		sideA.TiebreakPoints = intPtr(tiebreak[0])
+		sideB.TiebreakPoints = intPtr(tiebreak[1])
+	}
+	return &model.SetScore{IsCompleted: completed, Sides: []*model.SideSetScore{sideA, sideB}}
+}
+
+func TestScoreline(t *testing.T) {
+	score := &model.MatchUpScore{
+		IsMatchComplete: true,
+		Sets: []*model.SetScore{
+			setScore(true, 6, 4),
+			setScore(true, 6, 7, 5, 7),
+			setScore(true, 1, 0, 10, 8),
+		},
+	}
+	assert.Equal(t, "6-4 6-7(5) [10-8]", Scoreline(score, a))
+	assert.Equal(t, "4-6 7-6(5) [8-10]", Scoreline(score, b))
+
+	// A retirement mid set keeps the games played, but not a set not yet begun
+	score = &model.MatchUpScore{
+		Sets: []*model.SetScore{
+			setScore(true, 7, 6, 9, 7),
+			setScore(false, 2, 1),
+			setScore(false, 0, 0),
+		},
+	}
+	assert.Equal(t, "7-6(7) 2-1", Scoreline(score, a))
+	assert.Equal(t, "", Scoreline(nil, a))
+}
+
+func TestSameScore(t *testing.T) {
+	score := &model.MatchUpScore{
+		Sets: []*model.SetScore{
+			setScore(true, 6, 4),
+			setScore(false, 6, 6, 0, 0),
+		},
+	}
+	same := CloneScore(score)
+	// Tiebreak points that were never stored count as zero
+	same.Sets[1].Sides[0].TiebreakPoints = nil
+	assert.True(t, SameScore(score, same))
+
+	different := CloneScore(score)
+	different.Sets[1].Sides[1].InGameScore = model.InGameScoreFifteen
+	assert.False(t, SameScore(score, different))
+	different = CloneScore(score)
+	different.Sets = different.Sets[:1]
+	assert.False(t, SameScore(score, different))
+
+	winner := a
+	snapshot := &model.MatchStateSnapshot{Score: score, PointCompleted: true, PointWinner: &winner}
+	other := *snapshot
+	other.Score = same
+	assert.True(t, SameSnapshot(snapshot, &other))
+	other.PointWinner = nil
+	assert.False(t, SameSnapshot(snapshot, &other))
+}
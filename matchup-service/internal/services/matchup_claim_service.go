@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/factory"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SendGuestClaim invites a user to claim a guest participant of one of the
+// current user's matches
+func (s *MatchUpService) SendGuestClaim(ctx context.Context, input model.SendGuestClaimInput) (*model.GuestClaim, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matchUp, err := s.findMatchUp(ctx, input.MatchUpID)
+	if err != nil {
+		return nil, err
+	}
+	if matchUp.Owner != userID {
+		return nil, internalErrors.NewGuestClaimNotOwnerError()
+	}
+
+	guest := findParticipant(matchUp, input.GuestID)
+	if guest == nil || !guest.IsGuest {
+		return nil, internalErrors.NewNotAGuestError()
+	}
+	if findParticipant(matchUp, input.Claimant) != nil {
+		return nil, internalErrors.NewClaimantAlreadyPlayedError()
+	}
+
+	pending, err := s.claimsRepo.FindPendingByGuest(ctx, guest.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, internalErrors.NewGuestClaimPendingError()
+	}
+
+	factory := factory.NewMatchUpFactory()
+	claim := factory.CreateGuestClaim(userID, input, guest)
+
+	return s.claimsRepo.Insert(ctx, claim)
+}
+
+// AcceptGuestClaim hands the guest over to the current user. The guest's ID is
+// replaced with the user's in every match its owner recorded with the guest,
+// in the participants, the serving fields and each shot, and in the entries
+// of the owner's tournaments the guest was entered in, all in one
+// transaction. Draw slots refer to entries rather than players, so they carry
+// over as they are. Decided matches left without guests are rated then, from
+// the claimant's current rating; a rebuild replays them in the order played.
+func (s *MatchUpService) AcceptGuestClaim(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return inTransaction(ctx, s.transactor, func(ctx context.Context) (*model.GuestClaim, error) {
+		claim, err := s.findPendingGuestClaim(ctx, userID, id)
+		if err != nil {
+			return nil, err
+		}
+		if claim.Claimant != userID {
+			return nil, internalErrors.NewGuestClaimNotAddressedToError()
+		}
+
+		matchUps, err := s.guestMatchUps(ctx, claim)
+		if err != nil {
+			return nil, err
+		}
+		for _, matchUp := range matchUps {
+			if findParticipant(matchUp, userID) != nil {
+				return nil, internalErrors.NewClaimantAlreadyPlayedError()
+			}
+		}
+		tournaments, err := s.guestTournaments(ctx, claim)
+		if err != nil {
+			return nil, err
+		}
+		for _, tournament := range tournaments {
+			for _, entry := range tournament.Entries {
+				if entryHasPlayer(entry, userID) {
+					return nil, internalErrors.NewPlayerAlreadyEnteredError()
+				}
+			}
+		}
+
+		claimed := make([]primitive.ObjectID, 0, len(matchUps))
+		for _, matchUp := range matchUps {
+			replacePlayer(matchUp, claim.GuestID, userID)
+			if _, err := s.matchupsRepo.Update(ctx, matchUp); err != nil {
+				return nil, err
+			}
+			if _, err := s.shotsRepo.ReassignPlayer(ctx, matchUp.ID, claim.GuestID, userID); err != nil {
+				return nil, err
+			}
+			if isDecided(matchUp.MatchUpStatus) {
+				if err := s.rateMatchUp(ctx, matchUp); err != nil {
+					return nil, err
+				}
+			}
+			claimed = append(claimed, matchUp.ID)
+		}
+
+		now := time.Now()
+		for _, tournament := range tournaments {
+			for _, entry := range tournament.Entries {
+				for _, player := range entry.Players {
+					if player.ID == claim.GuestID {
+						player.ID = userID
+					}
+				}
+			}
+			tournament.LastUpdated = now
+			if _, err := s.tournamentsRepo.Update(ctx, tournament); err != nil {
+				return nil, err
+			}
+		}
+
+		claim.Status = model.GuestClaimStatusAccepted
+		claim.ClaimedMatchUpIds = claimed
+		claim.UpdatedAt = now
+		return s.claimsRepo.Update(ctx, claim)
+	})
+}
+
+// DeclineGuestClaim turns down an invitation sent to the current user
+func (s *MatchUpService) DeclineGuestClaim(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claim, err := s.findPendingGuestClaim(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Claimant != userID {
+		return nil, internalErrors.NewGuestClaimNotAddressedToError()
+	}
+
+	claim.Status = model.GuestClaimStatusDeclined
+	claim.UpdatedAt = time.Now()
+	return s.claimsRepo.Update(ctx, claim)
+}
+
+// CancelGuestClaim withdraws an invitation the current user sent
+func (s *MatchUpService) CancelGuestClaim(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claim, err := s.findPendingGuestClaim(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Owner != userID {
+		return nil, internalErrors.NewGuestClaimNotOwnerError()
+	}
+
+	claim.Status = model.GuestClaimStatusCancelled
+	claim.UpdatedAt = time.Now()
+	return s.claimsRepo.Update(ctx, claim)
+}
+
+// GetMyGuestClaims retrieves the invitations sent to the current user
+func (s *MatchUpService) GetMyGuestClaims(ctx context.Context, status *model.GuestClaimStatus, limit *int, offset *int) ([]*model.GuestClaim, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.claimsRepo.FindByClaimant(ctx, userID, status, limit, offset)
+}
+
+// findPendingGuestClaim loads a claim that is still open. Claims the user
+// neither sent nor received are reported as not found rather than forbidden.
+func (s *MatchUpService) findPendingGuestClaim(ctx context.Context, userID, id primitive.ObjectID) (*model.GuestClaim, error) {
+	claim, err := s.claimsRepo.FindByID(ctx, id)
+	if err != nil {
+		if sharedErrors.IsNotFoundError(err) {
+			return nil, internalErrors.NewGuestClaimNotFoundError()
+		}
+		return nil, err
+	}
+	if claim.Owner != userID && claim.Claimant != userID {
+		return nil, internalErrors.NewGuestClaimNotFoundError()
+	}
+	if claim.Status != model.GuestClaimStatusPending {
+		return nil, internalErrors.NewGuestClaimNotPendingError()
+	}
+	return claim, nil
+}
+
+// guestMatchUps finds the matches the claim's owner recorded with the guest.
+// Imported matches keep their guests' IDs, so there can be more than one.
+// Draw matches list every entrant as registered, so those of the owner's
+// tournaments are taken whenever the guest plays in them.
+func (s *MatchUpService) guestMatchUps(ctx context.Context, claim *model.GuestClaim) ([]*model.MatchUp, error) {
+	candidates, err := s.matchupsRepo.FindByParticipant(ctx, claim.GuestID, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var matchUps []*model.MatchUp
+	for _, matchUp := range candidates {
+		guest := findParticipant(matchUp, claim.GuestID)
+		if matchUp.Owner == claim.Owner && guest != nil && (guest.IsGuest || matchUp.Tournament != nil) {
+			matchUps = append(matchUps, matchUp)
+		}
+	}
+	return matchUps, nil
+}
+
+// guestTournaments finds the tournaments the claim's owner runs that the
+// guest was entered in
+func (s *MatchUpService) guestTournaments(ctx context.Context, claim *model.GuestClaim) ([]*model.Tournament, error) {
+	candidates, err := s.tournamentsRepo.FindByUser(ctx, claim.GuestID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tournaments []*model.Tournament
+	for _, tournament := range candidates {
+		if tournament.Owner == claim.Owner {
+			tournaments = append(tournaments, tournament)
+		}
+	}
+	return tournaments, nil
+}
+
+// replacePlayer swaps a guest's ID for a user's wherever the matchup refers to
+// the player, and marks the participant as no longer a guest
+func replacePlayer(matchUp *model.MatchUp, guestID, userID primitive.ObjectID) {
+	for _, participant := range matchUp.Participants {
+		if participant.ID == guestID {
+			participant.ID = userID
+			participant.IsGuest = false
+		}
+	}
+	if matchUp.InitialServer == guestID {
+		matchUp.InitialServer = userID
+	}
+	if matchUp.CurrentServer == guestID {
+		matchUp.CurrentServer = userID
+	}
+	for i, server := range matchUp.ServingOrder {
+		if server == guestID {
+			matchUp.ServingOrder[i] = userID
+		}
+	}
+	if matchUp.MatchUpTracker == guestID {
+		matchUp.MatchUpTracker = userID
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/factory"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/validation"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddMatchEvent records a let, penalty or default. The event is stored in the
+// shot list and scored like a shot, so it can be undone and redone, and a
+// default that decides the match is reopened by undoing it.
+func (s *MatchUpService) AddMatchEvent(ctx context.Context, input model.AddMatchEventInput) (*model.MatchUpShot, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shotValidator := validation.NewShotValidator()
+	if err := shotValidator.ValidateAddMatchEventInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	return trackerTransaction(ctx, s, input.MatchUpID, func(ctx context.Context) (*model.MatchUpShot, error) {
+		return s.appendMatchEvent(ctx, input, userID)
+	})
+}
+
+// appendMatchEvent records a validated match event after the matchup's last
+// shot. It runs inside the caller's transaction, once the user is known to be
+// a tracker.
+func (s *MatchUpService) appendMatchEvent(ctx context.Context, input model.AddMatchEventInput, userID primitive.ObjectID) (*model.MatchUpShot, error) {
+	matchUp, prev, err := s.findRecordableMatchUp(ctx, input.MatchUpID)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := eventPlayer(matchUp, prev, input)
+	if err != nil {
+		return nil, err
+	}
+
+	factory := factory.NewMatchUpFactory()
+	shot := factory.CreateMatchUpShotFromAddMatchEventInput(input, player)
+
+	// A let replays whichever serve was due; when none is, the sequence
+	// check rejects it
+	if input.EventType == model.MatchEventTypeLet {
+		if serveNumber, ok := scoring.ServeDue(prev); ok {
+			shot.ServeNumber = &serveNumber
+		}
+	}
+
+	return s.recordShot(ctx, matchUp, shot, prev, userID)
+}
+
+// eventPlayer works out who a match event is charged to: the given player,
+// or for a let without one, the server of the point being played or of the
+// next point
+func eventPlayer(matchUp *model.MatchUp, prev *model.MatchUpShot, input model.AddMatchEventInput) (*model.Participant, error) {
+	playerID := matchUp.CurrentServer
+	switch {
+	case input.PlayerID != nil:
+		playerID = *input.PlayerID
+	case scoring.PointInProgress(prev):
+		playerID = prev.PointContext.ServerID
+	}
+
+	player := findParticipant(matchUp, playerID)
+	if player == nil {
+		return nil, internalErrors.NewEventPlayerNotParticipantError()
+	}
+	return player, nil
+}
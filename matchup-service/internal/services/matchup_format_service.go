@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/factory"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/formats"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/validation"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetMatchUpFormatPreset retrieves a built-in preset or one of the current user's presets
+func (s *MatchUpService) GetMatchUpFormatPreset(ctx context.Context, id primitive.ObjectID) (*model.MatchUpFormatPreset, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findFormatPreset(ctx, userID, id)
+}
+
+// GetMatchUpFormatPresets retrieves the built-in presets followed by the current
+// user's own presets, paginated as one list
+func (s *MatchUpService) GetMatchUpFormatPresets(ctx context.Context, limit *int, offset *int) ([]*model.MatchUpFormatPreset, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	builtIns := formats.BuiltInPresets()
+	skip := 0
+	if offset != nil && *offset > 0 {
+		skip = *offset
+	}
+
+	// Take what the page needs from the built-ins first
+	presets := builtIns[min(skip, len(builtIns)):]
+	if limit != nil && *limit >= 0 && len(presets) > *limit {
+		presets = presets[:*limit]
+	}
+
+	// then fill the rest of the page from the user's saved presets
+	var remaining *int
+	if limit != nil {
+		left := *limit - len(presets)
+		if left <= 0 {
+			return presets, nil
+		}
+		remaining = &left
+	}
+	savedOffset := max(skip-len(builtIns), 0)
+
+	saved, err := s.presetsRepo.FindByOwner(ctx, userID, remaining, &savedOffset)
+	if err != nil {
+		return nil, err
+	}
+	return append(presets, saved...), nil
+}
+
+// CreateMatchUpFormatPreset saves a new format preset for the current user
+func (s *MatchUpService) CreateMatchUpFormatPreset(ctx context.Context, input model.CreateMatchUpFormatPresetInput) (*model.MatchUpFormatPreset, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	formatValidator := validation.NewFormatValidator()
+	if err := formatValidator.ValidateCreateMatchUpFormatPresetInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	factory := factory.NewMatchUpFactory()
+	preset := factory.CreateMatchUpFormatPresetFromInput(userID, input)
+
+	return s.presetsRepo.Insert(ctx, preset)
+}
+
+// DeleteMatchUpFormatPreset deletes one of the current user's presets
+func (s *MatchUpService) DeleteMatchUpFormatPreset(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := formats.FindBuiltInPreset(id); ok {
+		return false, internalErrors.NewBuiltInPresetLockedError()
+	}
+	if _, err := s.findFormatPreset(ctx, userID, id); err != nil {
+		return false, err
+	}
+
+	return s.presetsRepo.Delete(ctx, id)
+}
+
+// findFormatPreset loads a preset the user may use. Presets saved by other
+// users are reported as not found rather than forbidden.
+func (s *MatchUpService) findFormatPreset(ctx context.Context, userID, id primitive.ObjectID) (*model.MatchUpFormatPreset, error) {
+	if preset, ok := formats.FindBuiltInPreset(id); ok {
+		return preset, nil
+	}
+
+	preset, err := s.presetsRepo.FindByID(ctx, id)
+	if err != nil {
+		if sharedErrors.IsNotFoundError(err) {
+			return nil, internalErrors.NewFormatPresetNotFoundError()
+		}
+		return nil, err
+	}
+	if preset.Owner == nil || *preset.Owner != userID {
+		return nil, internalErrors.NewFormatPresetNotFoundError()
+	}
+	return preset, nil
+}
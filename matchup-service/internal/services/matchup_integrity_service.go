@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/integrity"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CheckShotChains walks the shots of every matchup from its first shot and
+// reports what is wrong with them. With repair set, problems are fixed where
+// the shots can still be scored: links are rewritten, orphaned shots deleted,
+// out of order timestamps moved, snapshots recomputed and the matchup moved
+// to where its replayed last shot leaves it, the way undo and redo move it,
+// so a match the replay decides is completed, rated and advanced in its draw.
+// It backs the admin command rather than the API, so there is no current
+// user to check, and status changes it makes are recorded with a zero
+// changedBy.
+func (s *MatchUpService) CheckShotChains(ctx context.Context, repair bool) ([]*integrity.Report, error) {
+	matchUps, err := s.matchupsRepo.GetMatchups(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*integrity.Report, 0, len(matchUps))
+	for _, matchUp := range matchUps {
+		report, err := s.checkShotChain(ctx, matchUp, repair)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// checkShotChain checks, and optionally repairs, the shots of one matchup
+func (s *MatchUpService) checkShotChain(ctx context.Context, matchUp *model.MatchUp, repair bool) (*integrity.Report, error) {
+	shots, err := s.shotsRepo.FindByMatchUpID(ctx, matchUp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := integrity.Walk(matchUp, shots)
+	report := &integrity.Report{MatchUpID: matchUp.ID, Problems: chain.Problems}
+
+	// Score the chain again from the start, undone shots included since
+	// redoing them restores their snapshots
+	replayed, err := s.replayShots(matchUp, chain.Shots)
+	if err != nil {
+		var shotID *primitive.ObjectID
+		if len(replayed) < len(chain.Shots) {
+			shotID = &chain.Shots[len(replayed)].ID
+		}
+		report.Problems = append(report.Problems, integrity.Problem{Kind: integrity.Unreplayable, ShotID: shotID, Detail: err.Error()})
+		return report, nil
+	}
+
+	var stale []*replayedShot
+	for _, shot := range replayed {
+		if !shot.consistent() {
+			stale = append(stale, shot)
+			report.Problems = append(report.Problems, integrity.Problem{
+				Kind:   integrity.StaleSnapshot,
+				ShotID: &shot.stored.ID,
+				Detail: "matchStateAfterShot differs from the replay",
+			})
+		}
+	}
+
+	// The matchup should stand where its last active shot leaves it
+	var last *model.MatchUpShot
+	if chain.Active > 0 {
+		last = replayed[chain.Active-1].replayed
+	}
+	expected := *matchUp
+	applyReplayedState(&expected, chain, last)
+	if fields := staleMatchUpFields(matchUp, &expected); len(fields) > 0 {
+		report.Problems = append(report.Problems, integrity.Problem{
+			Kind:   integrity.StaleMatchUp,
+			Detail: strings.Join(fields, ", ") + " differ from the replay",
+		})
+	}
+
+	if !repair || len(report.Problems) == 0 {
+		return report, nil
+	}
+
+	_, err = inTransaction(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		changed := make(map[primitive.ObjectID]*model.MatchUpShot)
+		for _, shot := range chain.Relink() {
+			changed[shot.ID] = shot
+		}
+		for _, shot := range stale {
+			shot.stored.PointContext = shot.replayed.PointContext
+			shot.stored.PointImportance = shot.replayed.PointImportance
+			shot.stored.MatchStateAfterShot = shot.replayed.MatchStateAfterShot
+			changed[shot.stored.ID] = shot.stored
+		}
+		for _, shot := range chain.Shots {
+			if changed[shot.ID] == nil {
+				continue
+			}
+			if _, err := s.shotsRepo.Update(ctx, shot); err != nil {
+				return false, err
+			}
+		}
+
+		if len(chain.Orphans) > 0 {
+			ids := make([]primitive.ObjectID, len(chain.Orphans))
+			for i, orphan := range chain.Orphans {
+				ids[i] = orphan.ID
+			}
+			if _, err := s.shotsRepo.DeleteByIDs(ctx, ids); err != nil {
+				return false, err
+			}
+		}
+
+		matchUp.FirstShot = chain.First()
+		if err := s.moveToShot(ctx, matchUp, last, primitive.NilObjectID); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Repaired = true
+	return report, nil
+}
+
+// applyReplayedState moves a matchup to the state its replayed last shot
+// leaves it in
+func applyReplayedState(matchUp *model.MatchUp, chain *integrity.Chain, last *model.MatchUpShot) {
+	matchUp.FirstShot = chain.First()
+	applyShotState(matchUp, last)
+}
+
+// staleMatchUpFields names the fields a matchup has out of step with the
+// state it is expected to be in
+func staleMatchUpFields(matchUp, expected *model.MatchUp) []string {
+	var fields []string
+	if !integrity.SameID(matchUp.FirstShot, expected.FirstShot) {
+		fields = append(fields, "firstShot")
+	}
+	if !integrity.SameID(matchUp.LastShot, expected.LastShot) {
+		fields = append(fields, "lastShot")
+	}
+	if !scoring.SameScore(matchUp.CurrentScore, expected.CurrentScore) {
+		fields = append(fields, "currentScore")
+	}
+	if (matchUp.Winner == nil) != (expected.Winner == nil) || (matchUp.Winner != nil && *matchUp.Winner != *expected.Winner) {
+		fields = append(fields, "winner")
+	}
+	return fields
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/interchange"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/validation"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExportMatchUp writes a match up and its active shots to a file format
+func (s *MatchUpService) ExportMatchUp(ctx context.Context, matchUpID primitive.ObjectID, format model.MatchUpExportFormat) (*model.MatchUpExport, error) {
+	if _, err := middleware.GetMongoIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	matchUp, err := s.findMatchUp(ctx, matchUpID)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.activeShots(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+
+	return interchange.Export(matchUp, shots, format)
+}
+
+// ImportMatchUp creates a match up from an exported file. The match is
+// started and every shot is replayed the way AddShot records it, so the
+// imported match goes through exactly the same validation and scoring as a
+// tracked one. The match, its shots and any ratings a decided match gets are
+// saved in one transaction, so nothing is kept if any shot fails to replay.
+// A file can only stand in for a match its other players never agreed to when
+// they are all guests.
+func (s *MatchUpService) ImportMatchUp(ctx context.Context, input model.ImportMatchUpInput) (*model.MatchUp, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := interchange.Import(input.Format, input.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Explicit match details win over the setup carried by the file
+	setup, guests := input.MatchUp, []primitive.ObjectID(nil)
+	if setup == nil {
+		if document.Setup == nil {
+			return nil, internalErrors.NewRequiredFieldError("matchUp")
+		}
+		setup, guests = document.Setup, document.GuestIDs
+		setup.MatchUpTracker = userID
+	}
+	if input.Format == model.MatchUpExportFormatCharting && setup.MatchUpType != model.MatchUpTypeSingles {
+		return nil, internalErrors.NewChartingSinglesOnlyError()
+	}
+
+	// The importer owns the new match, so nothing needs the access checker
+	// until the match is saved
+	matchUp, err := inTransaction(ctx, s.transactor, func(ctx context.Context) (*model.MatchUp, error) {
+		matchUp, err := s.createMatchUp(ctx, userID, *setup)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.replayImport(ctx, matchUp, guests, document.Shots, userID); err != nil {
+			return nil, err
+		}
+		return s.findMatchUp(ctx, matchUp.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := grantTrackerRoles(ctx, matchUp); err != nil {
+		return nil, err
+	}
+	return matchUp, nil
+}
+
+// replayImport starts an imported match and adds its shots in order. It runs
+// inside the import's transaction.
+func (s *MatchUpService) replayImport(ctx context.Context, matchUp *model.MatchUp, guests []primitive.ObjectID, shots []*interchange.ShotRecord, userID primitive.ObjectID) error {
+	// Guests keep their IDs from the file so hitters still match
+	if len(guests) > 0 {
+		for _, participant := range matchUp.Participants {
+			for _, guest := range guests {
+				participant.IsGuest = participant.IsGuest || participant.ID == guest
+			}
+		}
+		if _, err := s.matchupsRepo.Update(ctx, matchUp); err != nil {
+			return err
+		}
+	}
+
+	// The file records a match that was already played, so guests aren't
+	// waiting to be asked. Registered players still have to accept.
+	override := onlyGuestsBesides(matchUp, userID)
+	if _, err := s.changeMatchUpStatus(ctx, model.UpdateMatchUpStatusInput{
+		MatchUpID:           matchUp.ID,
+		Status:              model.MatchUpStatusInProgress,
+		OverrideInvitations: &override,
+	}, userID); err != nil {
+		return err
+	}
+
+	shotValidator := validation.NewShotValidator()
+	var server primitive.ObjectID
+	for i, record := range shots {
+		// Notations that only name the server's role follow the rotation
+		startsPoint := record.ShotType == model.ShotTypePoint ||
+			record.ServeNumber != nil && *record.ServeNumber == model.ServeNumberFirstServe
+		if record.Role == interchange.RoleServer && startsPoint {
+			current, err := s.findMatchUp(ctx, matchUp.ID)
+			if err != nil {
+				return err
+			}
+			server = current.CurrentServer
+		}
+
+		hitterID, ok := importedHitter(matchUp, record, server)
+		if !ok {
+			return internalErrors.NewInvalidImportContentError("shot " + strconv.Itoa(i+1) + " has a hitter who is not a participant")
+		}
+
+		// Points recorded without their rally are replayed as points, lets
+		// and penalties as match events
+		var err error
+		switch record.ShotType {
+		case model.ShotTypePoint:
+			point := record.AddPointInput(matchUp.ID, findParticipant(matchUp, hitterID))
+			if err = shotValidator.ValidateAddPointInput(ctx, point); err == nil {
+				_, err = s.appendPoint(ctx, point, userID)
+			}
+		case model.ShotTypeMatchEvent:
+			event := record.AddMatchEventInput(matchUp.ID, hitterID)
+			if err = shotValidator.ValidateAddMatchEventInput(ctx, event); err == nil {
+				_, err = s.appendMatchEvent(ctx, event, userID)
+			}
+		default:
+			shot := record.AddShotInput(matchUp.ID, hitterID)
+			if err = shotValidator.ValidateAddShotInput(ctx, shot); err == nil {
+				_, err = s.appendShot(ctx, shot, userID)
+			}
+		}
+		if err != nil {
+			return internalErrors.NewInvalidImportContentError("shot " + strconv.Itoa(i+1) + " could not be replayed: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// onlyGuestsBesides reports whether every participant other than the user
+// is a guest
+func onlyGuestsBesides(matchUp *model.MatchUp, userID primitive.ObjectID) bool {
+	for _, participant := range matchUp.Participants {
+		if participant.ID != userID && !participant.IsGuest {
+			return false
+		}
+	}
+	return true
+}
+
+// importedHitter matches a shot record to a participant: by role for
+// notations that don't name hitters, otherwise by ID and then by display name
+func importedHitter(matchUp *model.MatchUp, record *interchange.ShotRecord, server primitive.ObjectID) (primitive.ObjectID, bool) {
+	switch record.Role {
+	case interchange.RoleServer:
+		return server, findParticipant(matchUp, server) != nil
+	case interchange.RoleReceiver:
+		serving := findParticipant(matchUp, server)
+		for _, participant := range matchUp.Participants {
+			if serving != nil && participant.TeamSide != serving.TeamSide {
+				return participant.ID, true
+			}
+		}
+		return primitive.NilObjectID, false
+	}
+
+	if record.HitterID != nil && findParticipant(matchUp, *record.HitterID) != nil {
+		return *record.HitterID, true
+	}
+	for _, participant := range matchUp.Participants {
+		if record.HitterName != "" && strings.EqualFold(strings.TrimSpace(participant.DisplayName), record.HitterName) {
+			return participant.ID, true
+		}
+	}
+	return primitive.NilObjectID, false
+}
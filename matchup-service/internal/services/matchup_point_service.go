@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/factory"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/validation"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddPoint records a whole point without its rally, for matchups tracked at
+// BEGINNER. The point is stored in the shot list like any other shot, so it
+// is scored, undone and redone in the same way.
+func (s *MatchUpService) AddPoint(ctx context.Context, input model.AddPointInput) (*model.MatchUpShot, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shotValidator := validation.NewShotValidator()
+	if err := shotValidator.ValidateAddPointInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	return trackerTransaction(ctx, s, input.MatchUpID, func(ctx context.Context) (*model.MatchUpShot, error) {
+		return s.appendPoint(ctx, input, userID)
+	})
+}
+
+// appendPoint records a validated point after the matchup's last shot. It
+// runs inside the caller's transaction, once the user is known to be a tracker.
+func (s *MatchUpService) appendPoint(ctx context.Context, input model.AddPointInput, userID primitive.ObjectID) (*model.MatchUpShot, error) {
+	matchUp, prev, err := s.findRecordableMatchUp(ctx, input.MatchUpID)
+	if err != nil {
+		return nil, err
+	}
+	if !validation.AllowsPointOnly(matchUp.TrackingStyle) {
+		return nil, internalErrors.NewPointOnlyNotAllowedError()
+	}
+
+	hitter, err := pointHitter(matchUp, input)
+	if err != nil {
+		return nil, err
+	}
+
+	factory := factory.NewMatchUpFactory()
+	shot := factory.CreateMatchUpShotFromAddPointInput(input, hitter)
+
+	return s.recordShot(ctx, matchUp, shot, prev, userID)
+}
+
+// pointHitter works out who a point recorded on its own is attributed to:
+// the server for aces, double faults and points without a reason, otherwise
+// the player who hit the winner or made the error
+func pointHitter(matchUp *model.MatchUp, input model.AddPointInput) (*model.Participant, error) {
+	server := findParticipant(matchUp, matchUp.CurrentServer)
+	if server == nil {
+		return nil, internalErrors.NewWrongServerError()
+	}
+	if input.PointWinReason == nil {
+		return server, nil
+	}
+
+	// The side whose shot ended the point
+	endingSide := input.PointWinner
+	switch *input.PointWinReason {
+	case model.PointWinReasonAce, model.PointWinReasonDoubleFault:
+		serverWon := input.PointWinner == server.TeamSide
+		if serverWon != (*input.PointWinReason == model.PointWinReasonAce) {
+			return nil, internalErrors.NewInvalidPointReasonError(input.PointWinReason.String() + " is decided by the server")
+		}
+		if input.PlayerID != nil && *input.PlayerID != server.ID {
+			return nil, internalErrors.NewInvalidPointPlayerError("it must be the server")
+		}
+		return server, nil
+	case model.PointWinReasonForcedError, model.PointWinReasonUnforcedError:
+		endingSide = scoring.Opponent(input.PointWinner)
+	}
+
+	if input.PlayerID != nil {
+		player := findParticipant(matchUp, *input.PlayerID)
+		if player == nil || player.TeamSide != endingSide {
+			return nil, internalErrors.NewInvalidPointPlayerError("the player must be on " + endingSide.String())
+		}
+		return player, nil
+	}
+
+	// In singles there is only one player who can have hit the ending shot
+	var candidates []*model.Participant
+	for _, participant := range matchUp.Participants {
+		if participant.TeamSide == endingSide {
+			candidates = append(candidates, participant)
+		}
+	}
+	if len(candidates) != 1 {
+		return nil, internalErrors.NewPointPlayerRequiredError()
+	}
+	return candidates[0], nil
+}
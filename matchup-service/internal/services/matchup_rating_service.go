@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/rating"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetRating returns a player's current rating, the one their latest rating
+// change left them on, or nil if they have never been rated
+func (s *MatchUpService) GetRating(ctx context.Context, playerID primitive.ObjectID) (*int, error) {
+	latest, err := s.ratingsRepo.FindLatest(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	rounded := rating.Rounded(latest.RatingAfter)
+	return &rounded, nil
+}
+
+// GetRatingHistory retrieves the changes to a player's rating, newest first.
+// Changes from matches the current user can't see are left out, so the page
+// is taken after filtering.
+func (s *MatchUpService) GetRatingHistory(ctx context.Context, playerID primitive.ObjectID, limit *int, offset *int) ([]*model.RatingChange, error) {
+	viewerID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.ratingsRepo.FindByUser(ctx, playerID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*model.RatingChange, 0, len(changes))
+	for _, change := range changes {
+		matchUp, err := s.findMatchUp(ctx, change.MatchUpID)
+		if err != nil {
+			return nil, err
+		}
+		canView, err := canViewMatchUp(ctx, viewerID, matchUp)
+		if err != nil {
+			return nil, err
+		}
+		if canView {
+			visible = append(visible, change)
+		}
+	}
+	return paginate(visible, limit, offset), nil
+}
+
+// RebuildRatings throws away every rating and replays all decided matches in
+// the order they ended, returning how many were rated. It backs the admin
+// command rather than the API, so there is no current user to check. The
+// rebuild runs in one transaction, so a failure leaves the old history in place.
+func (s *MatchUpService) RebuildRatings(ctx context.Context) (int, error) {
+	return inTransaction(ctx, s.transactor, func(ctx context.Context) (int, error) {
+		matchUps, err := s.matchupsRepo.FindDecided(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := s.ratingsRepo.DeleteAll(ctx); err != nil {
+			return 0, err
+		}
+
+		// Ratings are carried forward in memory rather than read back per match
+		current := make(map[primitive.ObjectID]*model.RatingChange)
+		var changes []*model.RatingChange
+		rated := 0
+		for _, matchUp := range matchUps {
+			matchChanges := rating.Match(matchUp, current, ratedAt(matchUp))
+			if len(matchChanges) == 0 {
+				continue
+			}
+			for _, change := range matchChanges {
+				current[change.UserID] = change
+			}
+			changes = append(changes, matchChanges...)
+			rated++
+		}
+
+		if err := s.ratingsRepo.InsertMany(ctx, changes); err != nil {
+			return 0, err
+		}
+		return rated, nil
+	})
+}
+
+// syncRatings keeps ratings in step with a matchup whose status has just
+// changed from previous: deciding it rates the match and reopening it takes
+// the rating back
+func (s *MatchUpService) syncRatings(ctx context.Context, previous model.MatchUpStatus, matchUp *model.MatchUp) error {
+	wasDecided, isDecided := isDecided(previous), isDecided(matchUp.MatchUpStatus)
+	switch {
+	case isDecided && !wasDecided:
+		return s.rateMatchUp(ctx, matchUp)
+	case wasDecided && !isDecided:
+		return s.unrateMatchUp(ctx, matchUp)
+	default:
+		return nil
+	}
+}
+
+// rateMatchUp records the rating changes of a decided match. A match that was
+// rated before is left alone.
+func (s *MatchUpService) rateMatchUp(ctx context.Context, matchUp *model.MatchUp) error {
+	existing, err := s.ratingsRepo.FindByMatchUp(ctx, matchUp.ID)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	current := make(map[primitive.ObjectID]*model.RatingChange)
+	for _, participant := range matchUp.Participants {
+		if participant.IsGuest {
+			continue
+		}
+		latest, err := s.ratingsRepo.FindLatest(ctx, participant.ID)
+		if err != nil {
+			return err
+		}
+		if latest != nil {
+			current[participant.ID] = latest
+		}
+	}
+
+	changes := rating.Match(matchUp, current, ratedAt(matchUp))
+	if len(changes) == 0 {
+		return nil
+	}
+	return s.ratingsRepo.InsertMany(ctx, changes)
+}
+
+// unrateMatchUp takes back the rating changes of a reopened match. That is
+// only done while they are still each player's latest change. Once a player
+// has been rated in a later match, the changes are kept so the history stays
+// consistent, and a rebuild puts the ratings right.
+func (s *MatchUpService) unrateMatchUp(ctx context.Context, matchUp *model.MatchUp) error {
+	changes, err := s.ratingsRepo.FindByMatchUp(ctx, matchUp.ID)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		latest, err := s.ratingsRepo.FindLatest(ctx, change.UserID)
+		if err != nil {
+			return err
+		}
+		if latest == nil || latest.ID != change.ID {
+			return nil
+		}
+	}
+
+	_, err = s.ratingsRepo.DeleteByMatchUp(ctx, matchUp.ID)
+	return err
+}
+
+// isDecided reports whether a status means the match has a result to rate
+func isDecided(status model.MatchUpStatus) bool {
+	return status == model.MatchUpStatusCompleted || status == model.MatchUpStatusRetired
+}
+
+// ratedAt returns when a decided match ended, which orders it in the rating history
+func ratedAt(matchUp *model.MatchUp) time.Time {
+	if matchUp.EndTime != nil {
+		return *matchUp.EndTime
+	}
+	return matchUp.LastUpdated
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ScheduleMatchUp sets when and where a matchup that has not started will be
+// played. Fields left out are kept, and fields set to null are cleared.
+func (s *MatchUpService) ScheduleMatchUp(ctx context.Context, input model.ScheduleMatchUpInput) (*model.MatchUp, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return inTransaction(ctx, s.transactor, func(ctx context.Context) (*model.MatchUp, error) {
+		matchUp, err := s.findMatchUp(ctx, input.MatchUpID)
+		if err != nil {
+			return nil, err
+		}
+		if matchUp.Owner != userID {
+			return nil, internalErrors.NewScheduleNotOwnerError()
+		}
+		if !notStarted(matchUp) {
+			return nil, internalErrors.NewMatchUpAlreadyStartedError()
+		}
+
+		if startTime, ok := input.ScheduledStartTime.ValueOK(); ok {
+			matchUp.ScheduledStartTime = startTime
+		}
+		if courtID, ok := input.CourtID.ValueOK(); ok {
+			matchUp.Court = nil
+			if courtID != nil {
+				matchUp.Court = &model.TennisCourt{ID: *courtID}
+			}
+		}
+		matchUp.LastUpdated = time.Now()
+		return s.matchupsRepo.Update(ctx, matchUp)
+	})
+}
+
+// AcceptMatchUpInvitation records that the current user agreed to play
+func (s *MatchUpService) AcceptMatchUpInvitation(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUp, error) {
+	return s.answerInvitation(ctx, matchUpID, model.InvitationStatusAccepted)
+}
+
+// DeclineMatchUpInvitation records that the current user turned the match down
+func (s *MatchUpService) DeclineMatchUpInvitation(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUp, error) {
+	return s.answerInvitation(ctx, matchUpID, model.InvitationStatusDeclined)
+}
+
+// GetMyMatchUpInvitations retrieves the upcoming matchups the current user is invited to
+func (s *MatchUpService) GetMyMatchUpInvitations(ctx context.Context, status *model.InvitationStatus, limit *int, offset *int) ([]*model.MatchUp, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.matchupsRepo.FindByInvitee(ctx, userID, status, limit, offset)
+}
+
+// answerInvitation sets the current user's answer. Participants can change
+// their mind until the matchup starts.
+func (s *MatchUpService) answerInvitation(ctx context.Context, matchUpID primitive.ObjectID, answer model.InvitationStatus) (*model.MatchUp, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return inTransaction(ctx, s.transactor, func(ctx context.Context) (*model.MatchUp, error) {
+		matchUp, err := s.findMatchUp(ctx, matchUpID)
+		if err != nil {
+			return nil, err
+		}
+		participant := findParticipant(matchUp, userID)
+		if participant == nil || participant.InvitationStatus == nil {
+			return nil, internalErrors.NewInvitationNotFoundError()
+		}
+		if !notStarted(matchUp) {
+			return nil, internalErrors.NewInvitationClosedError()
+		}
+
+		now := time.Now()
+		participant.InvitationStatus = &answer
+		participant.InvitationRespondedAt = &now
+		matchUp.LastUpdated = now
+		return s.matchupsRepo.Update(ctx, matchUp)
+	})
+}
+
+// notStarted reports whether a matchup is still waiting to be played
+func notStarted(matchUp *model.MatchUp) bool {
+	return matchUp.MatchUpStatus == model.MatchUpStatusRequested ||
+		matchUp.MatchUpStatus == model.MatchUpStatusScheduled
+}
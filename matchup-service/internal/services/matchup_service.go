@@ -3,12 +3,20 @@ package services
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
 	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/factory"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/lifecycle"
 	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/repository"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/statistics"
 	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/validation"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/access"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
 	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	sharedRepo "github.com/CourtIQ/courtiq-backend/shared/pkg/repository"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -18,29 +26,64 @@ var (
 
 // MatchUpService implements the MatchUpServiceIntf interface
 type MatchUpService struct {
-	matchupsRepo repository.MatchupsRepository
-	shotsRepo    repository.ShotsRepository
+	matchupsRepo    repository.MatchupsRepository
+	shotsRepo       repository.ShotsRepository
+	presetsRepo     repository.FormatPresetsRepository
+	claimsRepo      repository.GuestClaimsRepository
+	ratingsRepo     repository.RatingsRepository
+	tournamentsRepo repository.TournamentsRepository
+	transactor      sharedRepo.Transactor
 }
 
 // NewMatchUpService creates a new instance of MatchUpService
 func NewMatchUpService(
 	matchupsRepo repository.MatchupsRepository,
 	shotsRepo repository.ShotsRepository,
+	presetsRepo repository.FormatPresetsRepository,
+	claimsRepo repository.GuestClaimsRepository,
+	ratingsRepo repository.RatingsRepository,
+	tournamentsRepo repository.TournamentsRepository,
+	transactor sharedRepo.Transactor,
 ) *MatchUpService {
 	return &MatchUpService{
-		matchupsRepo: matchupsRepo,
-		shotsRepo:    shotsRepo,
+		matchupsRepo:    matchupsRepo,
+		shotsRepo:       shotsRepo,
+		presetsRepo:     presetsRepo,
+		claimsRepo:      claimsRepo,
+		ratingsRepo:     ratingsRepo,
+		tournamentsRepo: tournamentsRepo,
+		transactor:      transactor,
 	}
 }
 
-// GetMatchUpFormats retrieves all match up formats with pagination
-func (s *MatchUpService) GetMatchUpFormats(ctx context.Context, limit *int, offset *int) ([]*model.MatchUpFormat, error) {
-	return nil, ErrNotImplemented
+// inTransaction runs fn in a transaction and returns its result. Writes that
+// span documents, like appending a shot and moving the matchup to it, go
+// through here so a failure or a concurrent tracker can't leave them half done.
+func inTransaction[T any](ctx context.Context, transactor sharedRepo.Transactor, fn func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
 }
 
-// CreateMatchUpFormat creates a new match up format
-func (s *MatchUpService) CreateMatchUpFormat(ctx context.Context, input model.MatchUpFormatInput) (*model.MatchUpFormat, error) {
-	return nil, ErrNotImplemented
+// trackerTransaction checks that the current user may track a matchup and
+// then runs fn in a transaction. The check comes first because the access
+// checker has its own MongoDB client, which can't take part in the
+// transaction's session; the owner and roles it looks at don't change with
+// the writes fn makes.
+func trackerTransaction[T any](ctx context.Context, s *MatchUpService, matchUpID primitive.ObjectID, fn func(ctx context.Context) (T, error)) (T, error) {
+	if _, err := s.findTrackableMatchUp(ctx, matchUpID); err != nil {
+		var zero T
+		return zero, err
+	}
+	return inTransaction(ctx, s.transactor, fn)
 }
 
 // InitiateMatchUp starts a new match up
@@ -51,59 +94,882 @@ func (s *MatchUpService) InitiateMatchUp(ctx context.Context, input model.Initia
 		return nil, err
 	}
 
+	createdMatchUp, err := s.createMatchUp(ctx, ownerID, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := grantTrackerRoles(ctx, createdMatchUp); err != nil {
+		return nil, err
+	}
+	return createdMatchUp, nil
+}
+
+// createMatchUp validates a new match up and saves it for its owner
+func (s *MatchUpService) createMatchUp(ctx context.Context, ownerID primitive.ObjectID, input model.InitiateMatchUpInput) (*model.MatchUp, error) {
 	// Validate input using the matchup validator
 	matchupValidator := validation.NewMatchUpValidator()
 	if err := matchupValidator.ValidateInitiateMatchUpInput(ctx, input); err != nil {
 		return nil, err
 	}
 
-	// If matchUpFormat is provided, validate it using the format validator
+	// Create a match factory to build the format and the match from the input
+	factory := factory.NewMatchUpFactory()
+
+	// The format is either given inline, validated with the format validator,
+	// or copied from a preset so later changes to the preset don't affect the match
+	var format *model.MatchUpFormat
 	if input.MatchUpFormat != nil {
 		formatValidator := validation.NewFormatValidator()
 		if err := formatValidator.ValidateMatchUpFormatInput(ctx, *input.MatchUpFormat); err != nil {
 			return nil, err
 		}
+		format = factory.ConvertMatchUpFormat(input.MatchUpFormat)
+	} else {
+		preset, err := s.findFormatPreset(ctx, ownerID, *input.MatchUpFormatPresetID)
+		if err != nil {
+			return nil, err
+		}
+		format = preset.MatchUpFormat
 	}
 
-	// Create a match factory and create a new match from the input
-	factory := factory.NewMatchUpFactory()
-	matchUp := factory.CreateMatchUpFromInitiateMatchUpInput(ownerID, input)
+	matchUp := factory.CreateMatchUpFromInitiateMatchUpInput(ownerID, input, format)
 
 	// Save the match to the database
-	createdMatchUp, err := s.matchupsRepo.Insert(ctx, matchUp)
+	return s.matchupsRepo.Insert(ctx, matchUp)
+}
+
+// grantTrackerRoles gives a new match up's trackers the role that lets them
+// record shots. It asks the access checker, so it can't be called inside a
+// transaction.
+func grantTrackerRoles(ctx context.Context, matchUp *model.MatchUp) error {
+	checker, ok := middleware.GetAccessChecker(ctx)
+	if !ok {
+		return nil
+	}
+	for _, tracker := range matchUp.Trackers {
+		if err := checker.GrantRole(ctx, tracker.Hex(), matchUp.ID.Hex(), access.RoleMatchTracker, matchUp.Owner.Hex()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMyMatchUps retrieves the match ups the current user took part in, with optional filters and pagination
+func (s *MatchUpService) GetMyMatchUps(ctx context.Context, filter *model.MatchUpFilterInput, limit *int, offset *int) ([]*model.MatchUp, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return createdMatchUp, nil
-}
+	matchupValidator := validation.NewMatchUpValidator()
+	if err := matchupValidator.ValidateMatchUpFilterInput(ctx, filter); err != nil {
+		return nil, err
+	}
 
-// GetMatchUps retrieves all match ups with pagination
-func (s *MatchUpService) GetMatchUps(ctx context.Context, limit *int, offset *int) ([]*model.MatchUp, error) {
-	return nil, ErrNotImplemented
+	return s.matchupsRepo.FindByParticipant(ctx, userID, filter, limit, offset)
 }
 
 // GetMatchUpById retrieves a match up by its ID
 func (s *MatchUpService) GetMatchUpById(ctx context.Context, id primitive.ObjectID) (*model.MatchUp, error) {
-	return nil, ErrNotImplemented
+	if _, err := middleware.GetMongoIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.findMatchUp(ctx, id)
 }
 
-// UpdateMatchUp updates a match up's status
-func (s *MatchUpService) UpdateMatchUp(ctx context.Context, id primitive.ObjectID, status model.MatchUpStatus) (*model.MatchUp, error) {
-	return nil, ErrNotImplemented
+// UpdateMatchUpStatus moves a match up through its lifecycle, recording who
+// made the change. Only the owner and the match's trackers can change it.
+func (s *MatchUpService) UpdateMatchUpStatus(ctx context.Context, input model.UpdateMatchUpStatusInput) (*model.MatchUp, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matchupValidator := validation.NewMatchUpValidator()
+	if err := matchupValidator.ValidateUpdateMatchUpStatusInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	return trackerTransaction(ctx, s, input.MatchUpID, func(ctx context.Context) (*model.MatchUp, error) {
+		return s.changeMatchUpStatus(ctx, input, userID)
+	})
 }
 
-// AddShot adds a new shot to a match up
+// changeMatchUpStatus applies a validated status change. It runs inside the
+// caller's transaction, once the user is known to be a tracker.
+func (s *MatchUpService) changeMatchUpStatus(ctx context.Context, input model.UpdateMatchUpStatusInput, userID primitive.ObjectID) (*model.MatchUp, error) {
+	matchUp, err := s.findMatchUp(ctx, input.MatchUpID)
+	if err != nil {
+		return nil, err
+	}
+
+	override := input.OverrideInvitations != nil && *input.OverrideInvitations
+	if override && matchUp.Owner != userID {
+		return nil, internalErrors.NewOverrideNotOwnerError()
+	}
+
+	previous := matchUp.MatchUpStatus
+	now := time.Now()
+	if err := lifecycle.Apply(matchUp, lifecycle.Change{
+		To:                  input.Status,
+		RetiringSide:        input.RetiringSide,
+		Reason:              input.Reason,
+		ChangedBy:           userID,
+		ChangedAt:           now,
+		OverrideInvitations: override,
+	}); err != nil {
+		return nil, err
+	}
+	matchUp.LastUpdated = now
+
+	updated, err := s.matchupsRepo.Update(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.syncRatings(ctx, previous, updated); err != nil {
+		return nil, err
+	}
+	if err := s.syncDraw(ctx, previous, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// AddShot adds a new shot to a match up, scoring the point if the shot ended it
 func (s *MatchUpService) AddShot(ctx context.Context, input model.AddShotInput) (*model.MatchUpShot, error) {
-	return nil, ErrNotImplemented
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the shot details on their own
+	shotValidator := validation.NewShotValidator()
+	if err := shotValidator.ValidateAddShotInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	// A retried shot returns what was recorded the first time
+	if input.ClientShotID != nil {
+		existing, err := s.shotsRepo.FindByClientShotID(ctx, input.MatchUpID, *input.ClientShotID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	return s.addShot(ctx, input, userID)
+}
+
+// addShot records a validated shot that the matchup doesn't have yet
+func (s *MatchUpService) addShot(ctx context.Context, input model.AddShotInput, userID primitive.ObjectID) (*model.MatchUpShot, error) {
+	return trackerTransaction(ctx, s, input.MatchUpID, func(ctx context.Context) (*model.MatchUpShot, error) {
+		return s.appendShot(ctx, input, userID)
+	})
+}
+
+// appendShot records a validated shot after the matchup's last shot. It runs
+// inside the caller's transaction, once the user is known to be a tracker.
+func (s *MatchUpService) appendShot(ctx context.Context, input model.AddShotInput, userID primitive.ObjectID) (*model.MatchUpShot, error) {
+	matchUp, prev, err := s.findRecordableMatchUp(ctx, input.MatchUpID)
+	if err != nil {
+		return nil, err
+	}
+
+	hitter := findParticipant(matchUp, input.HitterID)
+	if hitter == nil {
+		return nil, internalErrors.NewHitterNotParticipantError()
+	}
+
+	// The details required depend on how closely the match is tracked
+	shotValidator := validation.NewShotValidator()
+	if err := shotValidator.ValidateTrackingStyle(ctx, input, matchUp.TrackingStyle); err != nil {
+		return nil, err
+	}
+
+	factory := factory.NewMatchUpFactory()
+	shot := factory.CreateMatchUpShotFromAddShotInput(input, hitter.TeamSide)
+
+	return s.recordShot(ctx, matchUp, shot, prev, userID)
+}
+
+// findRecordableMatchUp loads a matchup that shots can be added to, along with
+// its last shot, which tells us whether a point or a second serve is pending.
+// Whether the user may track it is checked by trackerTransaction.
+func (s *MatchUpService) findRecordableMatchUp(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUp, *model.MatchUpShot, error) {
+	matchUp, err := s.findMatchUp(ctx, matchUpID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if matchUp.MatchUpStatus != model.MatchUpStatusInProgress {
+		return nil, nil, internalErrors.NewMatchUpNotInProgressError()
+	}
+	if matchUp.CurrentScore.IsMatchComplete {
+		return nil, nil, internalErrors.NewMatchUpAlreadyDecidedError()
+	}
+
+	var prev *model.MatchUpShot
+	if matchUp.LastShot != nil {
+		prev, err = s.shotsRepo.FindByID(ctx, *matchUp.LastShot)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return matchUp, prev, nil
+}
+
+// recordShot scores a new shot and appends it to the matchup's linked list
+func (s *MatchUpService) recordShot(ctx context.Context, matchUp *model.MatchUp, shot *model.MatchUpShot, prev *model.MatchUpShot, userID primitive.ObjectID) (*model.MatchUpShot, error) {
+	if err := scoring.ValidateSequence(shot, prev); err != nil {
+		return nil, err
+	}
+
+	if err := s.scoreShot(matchUp, shot, prev); err != nil {
+		return nil, err
+	}
+
+	// A new shot replaces anything that was undone after the current last shot
+	if err := s.discardRedoBranch(ctx, matchUp, prev); err != nil {
+		return nil, err
+	}
+
+	// Append the shot to the linked list
+	shot.PrevShotID = matchUp.LastShot
+	createdShot, err := s.shotsRepo.Insert(ctx, shot)
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil {
+		prev.NextShotID = &createdShot.ID
+		if _, err := s.shotsRepo.Update(ctx, prev); err != nil {
+			return nil, err
+		}
+	} else {
+		matchUp.FirstShot = &createdShot.ID
+	}
+
+	if err := s.moveToShot(ctx, matchUp, createdShot, userID); err != nil {
+		return nil, err
+	}
+
+	return createdShot, nil
+}
+
+// scoreShot works out who is serving, fills in the point context and records
+// the match state after the shot
+func (s *MatchUpService) scoreShot(matchUp *model.MatchUp, shot *model.MatchUpShot, prev *model.MatchUpShot) error {
+	// A serve or let starting a point has to come from whoever's turn it is. A
+	// point recorded on its own, or a penalty, is served by whoever's turn it is.
+	served := shot.ShotType == model.ShotTypeServe || scoring.IsLet(shot)
+	servingOrder := matchUp.ServingOrder
+	if !scoring.PointInProgress(prev) && served {
+		order, ok := scoring.ChooseServer(matchUp.ServingOrder, matchUp.CurrentScore, shot.HitterID)
+		if !ok {
+			return internalErrors.NewWrongServerError()
+		}
+		servingOrder = order
+	}
+
+	engine := scoring.NewEngine(matchUp.MatchUpFormat)
+	shot.PointContext = buildPointContext(matchUp, shot, prev)
+	shot.PointImportance = engine.PointImportance(matchUp.CurrentScore, shot.PointContext.ServerSide)
+	if served {
+		if shot.ServiceBoxSide != nil && *shot.ServiceBoxSide != shot.PointContext.ServiceBoxSide {
+			return internalErrors.NewWrongServiceBoxError(shot.PointContext.ServiceBoxSide.String())
+		}
+		shot.ServiceBoxSide = &shot.PointContext.ServiceBoxSide
+	}
+
+	resolution := scoring.ResolveShot(shot, prev)
+	shot.PointWinReason = resolution.PointWinReason
+
+	snapshot := &model.MatchStateSnapshot{
+		Score:          scoring.CloneScore(matchUp.CurrentScore),
+		PointCompleted: resolution.PointCompleted,
+		PointWinner:    resolution.PointWinner,
+	}
+	if resolution.PointCompleted {
+		// Penalties can award more than the point
+		award := engine.AwardPoint
+		if shot.MatchEvent != nil {
+			switch *shot.MatchEvent {
+			case model.MatchEventTypeGamePenalty:
+				award = engine.AwardGame
+			case model.MatchEventTypeDefault:
+				award = engine.AwardMatch
+			}
+		}
+		score, result, err := award(matchUp.CurrentScore, *resolution.PointWinner)
+		if err != nil {
+			return err
+		}
+		snapshot.Score = score
+		snapshot.GameCompleted = result.GameCompleted
+		snapshot.SetCompleted = result.SetCompleted
+		snapshot.MatchCompleted = result.MatchCompleted
+
+		if result.SetCompleted && !result.MatchCompleted {
+			servingOrder = scoring.NextSetServingOrder(servingOrder, score.Sets[len(score.Sets)-2])
+		}
+	}
+	snapshot.ServingOrder = servingOrder
+	snapshot.CurrentServer = scoring.NextServer(servingOrder, snapshot.Score)
+	shot.MatchStateAfterShot = snapshot
+
+	return nil
+}
+
+// UndoLastShot moves the matchup back to the state before its last shot.
+// The undone shot stays in the linked list so it can be redone.
+func (s *MatchUpService) UndoLastShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return trackerTransaction(ctx, s, matchUpID, func(ctx context.Context) (*model.MatchUpShot, error) {
+		matchUp, err := s.findMatchUp(ctx, matchUpID)
+		if err != nil {
+			return nil, err
+		}
+		// A completed match can be reopened by undoing its deciding shot
+		if matchUp.MatchUpStatus != model.MatchUpStatusInProgress && matchUp.MatchUpStatus != model.MatchUpStatusCompleted {
+			return nil, internalErrors.NewMatchUpNotInProgressError()
+		}
+		if matchUp.LastShot == nil {
+			return nil, internalErrors.NewNothingToUndoError()
+		}
+
+		last, err := s.shotsRepo.FindByID(ctx, *matchUp.LastShot)
+		if err != nil {
+			return nil, err
+		}
+
+		var prev *model.MatchUpShot
+		if last.PrevShotID != nil {
+			prev, err = s.shotsRepo.FindByID(ctx, *last.PrevShotID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.moveToShot(ctx, matchUp, prev, userID); err != nil {
+			return nil, err
+		}
+
+		return prev, nil
+	})
+}
+
+// RedoShot re-applies the first shot that was undone after the current last shot
+func (s *MatchUpService) RedoShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return trackerTransaction(ctx, s, matchUpID, func(ctx context.Context) (*model.MatchUpShot, error) {
+		matchUp, err := s.findMatchUp(ctx, matchUpID)
+		if err != nil {
+			return nil, err
+		}
+		if matchUp.MatchUpStatus != model.MatchUpStatusInProgress {
+			return nil, internalErrors.NewMatchUpNotInProgressError()
+		}
+
+		// With every shot undone the redo branch starts at the head of the list
+		next := matchUp.FirstShot
+		if matchUp.LastShot != nil {
+			last, err := s.shotsRepo.FindByID(ctx, *matchUp.LastShot)
+			if err != nil {
+				return nil, err
+			}
+			next = last.NextShotID
+		}
+		if next == nil {
+			return nil, internalErrors.NewNothingToRedoError()
+		}
+
+		shot, err := s.shotsRepo.FindByID(ctx, *next)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.moveToShot(ctx, matchUp, shot, userID); err != nil {
+			return nil, err
+		}
+
+		return shot, nil
+	})
+}
+
+// discardRedoBranch deletes the shots that were undone after prev, or the
+// whole list when prev is nil, so a new shot can take their place
+func (s *MatchUpService) discardRedoBranch(ctx context.Context, matchUp *model.MatchUp, prev *model.MatchUpShot) error {
+	next := matchUp.FirstShot
+	if prev != nil {
+		next = prev.NextShotID
+	}
+
+	var ids []primitive.ObjectID
+	for next != nil {
+		shot, err := s.shotsRepo.FindByID(ctx, *next)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, shot.ID)
+		next = shot.NextShotID
+	}
+
+	if _, err := s.shotsRepo.DeleteByIDs(ctx, ids); err != nil {
+		return err
+	}
+	if prev != nil {
+		prev.NextShotID = nil
+	} else {
+		matchUp.FirstShot = nil
+	}
+	return nil
+}
+
+// moveToShot applies a shot's state to the matchup, keeps the lifecycle
+// status, the players' ratings and any tournament draw in step with the score
+// and saves the matchup
+func (s *MatchUpService) moveToShot(ctx context.Context, matchUp *model.MatchUp, shot *model.MatchUpShot, userID primitive.ObjectID) error {
+	now := time.Now()
+	previous := matchUp.MatchUpStatus
+	applyShotState(matchUp, shot)
+
+	// Deciding the score completes the match, and undoing the deciding shot reopens it
+	decided := matchUp.CurrentScore.IsMatchComplete
+	change := lifecycle.Change{ChangedBy: userID, ChangedAt: now, Winner: matchUp.Winner}
+	switch {
+	case decided && matchUp.MatchUpStatus == model.MatchUpStatusInProgress:
+		change.To = model.MatchUpStatusCompleted
+	case !decided && matchUp.MatchUpStatus == model.MatchUpStatusCompleted:
+		change.To = model.MatchUpStatusInProgress
+		change.Reopen = true
+	}
+	if change.To != "" {
+		if err := lifecycle.Apply(matchUp, change); err != nil {
+			return err
+		}
+	}
+
+	matchUp.LastUpdated = now
+	if _, err := s.matchupsRepo.Update(ctx, matchUp); err != nil {
+		return err
+	}
+	if err := s.syncRatings(ctx, previous, matchUp); err != nil {
+		return err
+	}
+	return s.syncDraw(ctx, previous, matchUp)
+}
+
+// applyShotState moves the matchup to the state recorded after a shot,
+// or back to the start of the match when shot is nil. A retirement decides
+// the match whatever the score.
+func applyShotState(matchUp *model.MatchUp, shot *model.MatchUpShot) {
+	matchUp.Winner = nil
+	matchUp.Loser = nil
+
+	if shot == nil {
+		matchUp.LastShot = nil
+		matchUp.CurrentScore = scoring.NewEngine(matchUp.MatchUpFormat).InitialScore()
+		matchUp.CurrentServer = matchUp.InitialServer
+		matchUp.ServingOrder = scoring.InitialServingOrder(matchUp.Participants, matchUp.InitialServer)
+	} else {
+		state := shot.MatchStateAfterShot
+		matchUp.LastShot = &shot.ID
+		matchUp.CurrentScore = state.Score
+		matchUp.CurrentServer = state.CurrentServer
+		matchUp.ServingOrder = state.ServingOrder
+		if state.MatchCompleted && state.PointWinner != nil {
+			winner := *state.PointWinner
+			loser := scoring.Opponent(winner)
+			matchUp.Winner = &winner
+			matchUp.Loser = &loser
+		}
+	}
+	if matchUp.MatchUpStatus == model.MatchUpStatusRetired && matchUp.RetiringSide != nil {
+		winner := scoring.Opponent(*matchUp.RetiringSide)
+		loser := *matchUp.RetiringSide
+		matchUp.Winner = &winner
+		matchUp.Loser = &loser
+	}
+
+	// Box, ends and what is at stake follow from the score
+	matchUp.CurrentServiceBoxSide = scoring.ServiceBox(matchUp.CurrentScore)
+	matchUp.CourtSides = scoring.CourtSides(matchUp.CurrentScore)
+	matchUp.NextPointImportance = model.PointImportanceRegular
+	if server := findParticipant(matchUp, matchUp.CurrentServer); server != nil {
+		engine := scoring.NewEngine(matchUp.MatchUpFormat)
+		matchUp.NextPointImportance = engine.PointImportance(matchUp.CurrentScore, server.TeamSide)
+	}
+}
+
+// buildPointContext locates the shot within the match structure, using the
+// score before the shot and the previous shot to carry over point details
+func buildPointContext(matchUp *model.MatchUp, shot *model.MatchUpShot, prev *model.MatchUpShot) *model.PointContext {
+	set := scoring.CurrentSet(matchUp.CurrentScore)
+	pointContext := &model.PointContext{
+		SetNumber:      set.SetIndex,
+		GameNumber:     scoring.GamesPlayed(set) + 1,
+		PointNumber:    1,
+		ServiceBoxSide: scoring.ServiceBox(matchUp.CurrentScore),
+	}
+
+	switch {
+	case scoring.PointInProgress(prev):
+		pointContext.PointNumber = prev.PointContext.PointNumber
+		pointContext.ServerID = prev.PointContext.ServerID
+		pointContext.ServerSide = prev.PointContext.ServerSide
+	case prev != nil && !prev.MatchStateAfterShot.GameCompleted:
+		pointContext.PointNumber = prev.PointContext.PointNumber + 1
+	}
+
+	// A serve or let starting a point names the server, a point recorded on
+	// its own or a penalty between points is served by the current server
+	switch {
+	case scoring.PointInProgress(prev):
+		// The point's server was carried over above
+	case shot.ShotType == model.ShotTypeServe || scoring.IsLet(shot):
+		pointContext.ServerID = shot.HitterID
+		pointContext.ServerSide = shot.HitterSide
+	case shot.ShotType == model.ShotTypePoint || shot.ShotType == model.ShotTypeMatchEvent:
+		pointContext.ServerID = matchUp.CurrentServer
+		if server := findParticipant(matchUp, matchUp.CurrentServer); server != nil {
+			pointContext.ServerSide = server.TeamSide
+		}
+	}
+	pointContext.ServerCourtSide = scoring.CourtSide(matchUp.CurrentScore, pointContext.ServerSide)
+
+	return pointContext
+}
+
+// findMatchUp loads a matchup, mapping a missing document to a matchup error
+func (s *MatchUpService) findMatchUp(ctx context.Context, id primitive.ObjectID) (*model.MatchUp, error) {
+	matchUp, err := s.matchupsRepo.FindByID(ctx, id)
+	if err != nil {
+		if sharedErrors.IsNotFoundError(err) {
+			return nil, internalErrors.NewMatchUpNotFoundError()
+		}
+		return nil, err
+	}
+	return matchUp, nil
+}
+
+// findShot loads a shot, mapping a missing document to a shot error
+func (s *MatchUpService) findShot(ctx context.Context, id primitive.ObjectID) (*model.MatchUpShot, error) {
+	shot, err := s.shotsRepo.FindByID(ctx, id)
+	if err != nil {
+		if sharedErrors.IsNotFoundError(err) {
+			return nil, internalErrors.NewShotNotFoundError()
+		}
+		return nil, err
+	}
+	return shot, nil
+}
+
+// findTrackableMatchUp loads a matchup the current user may record shots for.
+// It asks the access checker, so it can't be called inside a transaction.
+func (s *MatchUpService) findTrackableMatchUp(ctx context.Context, id primitive.ObjectID) (*model.MatchUp, error) {
+	matchUp, err := s.findMatchUp(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	canTrack, err := canTrackMatchUp(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+	if !canTrack {
+		return nil, internalErrors.NewNotTrackerError()
+	}
+	return matchUp, nil
+}
+
+// canTrackMatchUp reports whether the current user may record shots for a
+// match. Its owner always can, anyone else needs the MATCH_TRACKER role on the
+// match. Without an access checker in the context nobody else can.
+func canTrackMatchUp(ctx context.Context, matchUp *model.MatchUp) (bool, error) {
+	if middleware.CheckOwnerAccess(ctx, matchUp.Owner.Hex()) {
+		return true, nil
+	}
+	if _, ok := middleware.GetAccessChecker(ctx); !ok {
+		return false, nil
+	}
+	return middleware.CheckRoleBasedAccess(ctx, matchUp.ID.Hex(), []access.Role{access.RoleMatchTracker})
+}
+
+// canViewMatchUp reports whether a user may see a match at its visibility.
+// Its owner, participants and trackers always can. Without an access checker
+// in the context access is allowed, as it is by the @accessControl directive.
+func canViewMatchUp(ctx context.Context, viewerID primitive.ObjectID, matchUp *model.MatchUp) (bool, error) {
+	checker, ok := middleware.GetAccessChecker(ctx)
+	if !ok {
+		return true, nil
+	}
+	return access.CheckResource(ctx, checker, matchUp, viewerID.Hex())
+}
+
+// findParticipant returns the participant with the given ID, or nil
+func findParticipant(matchUp *model.MatchUp, id primitive.ObjectID) *model.Participant {
+	for _, participant := range matchUp.Participants {
+		if participant.ID == id {
+			return participant
+		}
+	}
+	return nil
+}
+
+// GetMatchStatistics aggregates the recorded shots of a match up into match and team statistics
+func (s *MatchUpService) GetMatchStatistics(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchStatistics, error) {
+	if _, err := middleware.GetMongoIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	matchUp, err := s.findMatchUp(ctx, matchUpID)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.activeShots(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+
+	return statistics.Aggregate(matchUp, shots).MatchStatistics(), nil
+}
+
+// GetPlayerStatistics aggregates the recorded shots of a match up into per player statistics
+func (s *MatchUpService) GetPlayerStatistics(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.PlayerStatistics, error) {
+	if _, err := middleware.GetMongoIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	matchUp, err := s.findMatchUp(ctx, matchUpID)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.activeShots(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+
+	return statistics.Aggregate(matchUp, shots).PlayerStatistics(), nil
+}
+
+// GetMatchMomentum works out the win probability after every point of a match up
+func (s *MatchUpService) GetMatchMomentum(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchMomentum, error) {
+	if _, err := middleware.GetMongoIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	matchUp, err := s.findMatchUp(ctx, matchUpID)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.activeShots(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+
+	return statistics.Momentum(matchUp, shots), nil
 }
 
-// GetMatchUpShots retrieves all shots for a match up with pagination
+// GetShotHeatmaps counts where the shots of a match up bounced and were hit from
+func (s *MatchUpService) GetShotHeatmaps(ctx context.Context, matchUpID primitive.ObjectID, filter *model.ShotHeatmapFilterInput) ([]*model.ShotHeatmap, error) {
+	if _, err := middleware.GetMongoIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	matchUp, err := s.findMatchUp(ctx, matchUpID)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.activeShots(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+
+	return statistics.Heatmaps(matchUp, shots, filter), nil
+}
+
+// GetCareerStats adds up a player's statistics over every decided match they
+// took part in, optionally narrowed to a date range or an opponent. Matches
+// the current user can't see are left out.
+func (s *MatchUpService) GetCareerStats(ctx context.Context, playerID primitive.ObjectID, filter *model.CareerStatsFilterInput) (*model.CareerStatistics, error) {
+	viewerID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matchUpFilter := &model.MatchUpFilterInput{
+		Status: []model.MatchUpStatus{model.MatchUpStatusCompleted, model.MatchUpStatusRetired},
+	}
+	if filter != nil {
+		matchUpFilter.StartTimeFrom = filter.StartTimeFrom
+		matchUpFilter.StartTimeTo = filter.StartTimeTo
+		matchUpFilter.OpponentID = filter.OpponentID
+	}
+	matchupValidator := validation.NewMatchUpValidator()
+	if err := matchupValidator.ValidateMatchUpFilterInput(ctx, matchUpFilter); err != nil {
+		return nil, err
+	}
+
+	matchUps, err := s.matchupsRepo.FindByParticipant(ctx, playerID, matchUpFilter, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	career := statistics.NewCareer(playerID)
+	for _, matchUp := range matchUps {
+		visible, err := canViewMatchUp(ctx, viewerID, matchUp)
+		if err != nil {
+			return nil, err
+		}
+		if !visible {
+			continue
+		}
+
+		shots, err := s.activeShots(ctx, matchUp)
+		if err != nil {
+			return nil, err
+		}
+		career.Add(matchUp, shots)
+	}
+	return career.Statistics(), nil
+}
+
+// GetHeadToHead adds up the record between two players over the decided
+// matches they played against each other. Matches the current user can't see
+// are left out.
+func (s *MatchUpService) GetHeadToHead(ctx context.Context, userA, userB primitive.ObjectID) (*model.HeadToHead, error) {
+	viewerID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if userA == userB {
+		return nil, internalErrors.NewSamePlayerError()
+	}
+
+	matchUps, err := s.matchupsRepo.FindByParticipant(ctx, userA, &model.MatchUpFilterInput{
+		Status:     []model.MatchUpStatus{model.MatchUpStatusCompleted, model.MatchUpStatusRetired},
+		OpponentID: &userB,
+	}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	headToHead := statistics.NewHeadToHead(userA, userB)
+	for _, matchUp := range matchUps {
+		visible, err := canViewMatchUp(ctx, viewerID, matchUp)
+		if err != nil {
+			return nil, err
+		}
+		if visible {
+			headToHead.Add(matchUp)
+		}
+	}
+	return headToHead.Record(), nil
+}
+
+// activeShots returns the shots from the head of the linked list up to the
+// matchup's last shot, leaving out anything that has been undone
+func (s *MatchUpService) activeShots(ctx context.Context, matchUp *model.MatchUp) ([]*model.MatchUpShot, error) {
+	if matchUp.LastShot == nil {
+		return []*model.MatchUpShot{}, nil
+	}
+
+	shots, err := s.shotsRepo.FindByMatchUpID(ctx, matchUp.ID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]*model.MatchUpShot, len(shots))
+	for _, shot := range shots {
+		byID[shot.ID] = shot
+	}
+
+	active := make([]*model.MatchUpShot, 0, len(shots))
+	for next := matchUp.FirstShot; next != nil; {
+		shot, ok := byID[*next]
+		if !ok {
+			return nil, internalErrors.NewShotNotFoundError()
+		}
+		active = append(active, shot)
+		if shot.ID == *matchUp.LastShot {
+			break
+		}
+		next = shot.NextShotID
+	}
+	return active, nil
+}
+
+// GetLastShot retrieves the most recent shot of a match up, or nil before
+// its first shot or once every shot has been undone
+func (s *MatchUpService) GetLastShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error) {
+	matchUp, err := s.findMatchUp(ctx, matchUpID)
+	if err != nil {
+		return nil, err
+	}
+	if matchUp.LastShot == nil {
+		return nil, nil
+	}
+	return s.findShot(ctx, *matchUp.LastShot)
+}
+
+// GetShotByID retrieves a shot by its ID
+func (s *MatchUpService) GetShotByID(ctx context.Context, shotID primitive.ObjectID) (*model.MatchUpShot, error) {
+	return s.findShot(ctx, shotID)
+}
+
+// GetMatchUpShots retrieves the shots of a match up in the order they were
+// played, with pagination. Shots that have been undone are left out.
 func (s *MatchUpService) GetMatchUpShots(ctx context.Context, matchUpId primitive.ObjectID, limit *int, offset *int) ([]*model.MatchUpShot, error) {
-	return nil, ErrNotImplemented
+	matchUp, err := s.findMatchUp(ctx, matchUpId)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.activeShots(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(shots, limit, offset), nil
+}
+
+// paginate applies limit and offset to a list that is already in order
+func paginate[T any](items []T, limit *int, offset *int) []T {
+	if offset != nil && *offset > 0 {
+		items = items[min(*offset, len(items)):]
+	}
+	if limit != nil && *limit >= 0 && len(items) > *limit {
+		items = items[:*limit]
+	}
+	return items
 }
 
-// GetShotsByGame retrieves all shots for a specific game in a match up
+// GetShotsByGame retrieves the shots played in one game of a match up, in
+// order. Shots that have been undone are left out.
 func (s *MatchUpService) GetShotsByGame(ctx context.Context, matchUpId primitive.ObjectID, setNumber int, gameNumber int) ([]*model.MatchUpShot, error) {
-	return nil, ErrNotImplemented
+	matchUp, err := s.findMatchUp(ctx, matchUpId)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.activeShots(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+
+	game := []*model.MatchUpShot{}
+	for _, shot := range shots {
+		if shot.PointContext == nil {
+			continue
+		}
+		if shot.PointContext.SetNumber == setNumber && shot.PointContext.GameNumber == gameNumber {
+			game = append(game, shot)
+		}
+	}
+	return game, nil
 }
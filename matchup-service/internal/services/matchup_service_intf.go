@@ -4,23 +4,83 @@ import (
 	"context"
 
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/integrity"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MatchUpServiceIntf defines the interface for matchup service operations
 type MatchUpServiceIntf interface {
 	// MatchUp format operations
-	GetMatchUpFormats(ctx context.Context, limit *int, offset *int) ([]*model.MatchUpFormat, error)
-	CreateMatchUpFormat(ctx context.Context, input model.MatchUpFormatInput) (*model.MatchUpFormat, error)
+	GetMatchUpFormatPreset(ctx context.Context, id primitive.ObjectID) (*model.MatchUpFormatPreset, error)
+	GetMatchUpFormatPresets(ctx context.Context, limit *int, offset *int) ([]*model.MatchUpFormatPreset, error)
+	CreateMatchUpFormatPreset(ctx context.Context, input model.CreateMatchUpFormatPresetInput) (*model.MatchUpFormatPreset, error)
+	DeleteMatchUpFormatPreset(ctx context.Context, id primitive.ObjectID) (bool, error)
 	
 	// MatchUp operations
 	InitiateMatchUp(ctx context.Context, input model.InitiateMatchUpInput) (*model.MatchUp, error)
-	GetMatchUps(ctx context.Context, limit *int, offset *int) ([]*model.MatchUp, error)
+	GetMyMatchUps(ctx context.Context, filter *model.MatchUpFilterInput, limit *int, offset *int) ([]*model.MatchUp, error)
 	GetMatchUpById(ctx context.Context, id primitive.ObjectID) (*model.MatchUp, error)
-	UpdateMatchUp(ctx context.Context, id primitive.ObjectID, status model.MatchUpStatus) (*model.MatchUp, error)
+	UpdateMatchUpStatus(ctx context.Context, input model.UpdateMatchUpStatusInput) (*model.MatchUp, error)
+	ExportMatchUp(ctx context.Context, matchUpID primitive.ObjectID, format model.MatchUpExportFormat) (*model.MatchUpExport, error)
+	ImportMatchUp(ctx context.Context, input model.ImportMatchUpInput) (*model.MatchUp, error)
+
+	// Scheduling and invitation operations
+	ScheduleMatchUp(ctx context.Context, input model.ScheduleMatchUpInput) (*model.MatchUp, error)
+	AcceptMatchUpInvitation(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUp, error)
+	DeclineMatchUpInvitation(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUp, error)
+	GetMyMatchUpInvitations(ctx context.Context, status *model.InvitationStatus, limit *int, offset *int) ([]*model.MatchUp, error)
+
+	// Tracker operations
+	GrantMatchUpTracker(ctx context.Context, matchUpID, userID primitive.ObjectID) (*model.MatchUp, error)
+	RevokeMatchUpTracker(ctx context.Context, matchUpID, userID primitive.ObjectID) (*model.MatchUp, error)
+
+	// Visibility operations
+	UpdateMatchUpVisibility(ctx context.Context, matchUpID primitive.ObjectID, visibility model.MatchUpVisibility) (*model.MatchUp, error)
+
+	// Tournament operations
+	CreateTournament(ctx context.Context, input model.CreateTournamentInput) (*model.Tournament, error)
+	AddTournamentEntry(ctx context.Context, input model.AddTournamentEntryInput) (*model.Tournament, error)
+	CreateDraw(ctx context.Context, input model.CreateDrawInput) (*model.Draw, error)
+	AwardDrawWalkover(ctx context.Context, input model.AwardDrawWalkoverInput) (*model.Draw, error)
+	GetTournament(ctx context.Context, id primitive.ObjectID) (*model.Tournament, error)
+	GetMyTournaments(ctx context.Context, limit *int, offset *int) ([]*model.Tournament, error)
+	GetTournamentDraw(ctx context.Context, tournamentID, drawID primitive.ObjectID) (*model.Draw, error)
+	GetOrderOfPlay(ctx context.Context, tournamentID primitive.ObjectID) ([]*model.OrderOfPlayEntry, error)
+
+	// Guest claim operations
+	SendGuestClaim(ctx context.Context, input model.SendGuestClaimInput) (*model.GuestClaim, error)
+	AcceptGuestClaim(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error)
+	DeclineGuestClaim(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error)
+	CancelGuestClaim(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error)
+	GetMyGuestClaims(ctx context.Context, status *model.GuestClaimStatus, limit *int, offset *int) ([]*model.GuestClaim, error)
 	
 	// MatchUp shot operations
 	AddShot(ctx context.Context, input model.AddShotInput) (*model.MatchUpShot, error)
+	AddPoint(ctx context.Context, input model.AddPointInput) (*model.MatchUpShot, error)
+	AddMatchEvent(ctx context.Context, input model.AddMatchEventInput) (*model.MatchUpShot, error)
+	SyncShots(ctx context.Context, matchUpID primitive.ObjectID, shots []*model.AddShotInput, baseShotID *primitive.ObjectID) (*model.ShotSyncResult, error)
+	UndoLastShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error)
+	RedoShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error)
+	GetLastShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error)
+	GetShotByID(ctx context.Context, shotID primitive.ObjectID) (*model.MatchUpShot, error)
 	GetMatchUpShots(ctx context.Context, matchUpId primitive.ObjectID, limit *int, offset *int) ([]*model.MatchUpShot, error)
 	GetShotsByGame(ctx context.Context, matchUpId primitive.ObjectID, setNumber int, gameNumber int) ([]*model.MatchUpShot, error)
+	GetMatchStateAt(ctx context.Context, matchUpID primitive.ObjectID, shotID primitive.ObjectID) (*model.MatchStateAt, error)
+	GetMatchTimeline(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.MatchTimelineEntry, error)
+
+	// MatchUp statistics operations
+	GetMatchStatistics(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchStatistics, error)
+	GetPlayerStatistics(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.PlayerStatistics, error)
+	GetMatchMomentum(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchMomentum, error)
+	GetShotHeatmaps(ctx context.Context, matchUpID primitive.ObjectID, filter *model.ShotHeatmapFilterInput) ([]*model.ShotHeatmap, error)
+	GetCareerStats(ctx context.Context, playerID primitive.ObjectID, filter *model.CareerStatsFilterInput) (*model.CareerStatistics, error)
+	GetHeadToHead(ctx context.Context, userA, userB primitive.ObjectID) (*model.HeadToHead, error)
+
+	// Player rating operations
+	GetRating(ctx context.Context, playerID primitive.ObjectID) (*int, error)
+	GetRatingHistory(ctx context.Context, playerID primitive.ObjectID, limit *int, offset *int) ([]*model.RatingChange, error)
+	RebuildRatings(ctx context.Context) (int, error)
+
+	// Maintenance operations
+	CheckShotChains(ctx context.Context, repair bool) ([]*integrity.Report, error)
 }
\ No newline at end of file
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/validation"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SyncShots applies a batch of shots recorded offline on top of baseShotID,
+// the last shot the client had seen. Shots an earlier attempt already
+// uploaded are skipped by their idempotency key; after them the matchup's
+// last shot has to be the client's base, otherwise the batch was recorded
+// against a match that has moved on and is rejected as a conflict. The
+// check and the whole batch run in one transaction, so another tracker's
+// shot can't slip in between and a shot that fails leaves none recorded.
+func (s *MatchUpService) SyncShots(ctx context.Context, matchUpID primitive.ObjectID, shots []*model.AddShotInput, baseShotID *primitive.ObjectID) (*model.ShotSyncResult, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shotValidator := validation.NewShotValidator()
+	if err := shotValidator.ValidateShotBatch(ctx, matchUpID, shots); err != nil {
+		return nil, err
+	}
+
+	return trackerTransaction(ctx, s, matchUpID, func(ctx context.Context) (*model.ShotSyncResult, error) {
+		matchUp, err := s.findMatchUp(ctx, matchUpID)
+		if err != nil {
+			return nil, err
+		}
+		active, err := s.activeShots(ctx, matchUp)
+		if err != nil {
+			return nil, err
+		}
+
+		// Find the base in the active shots; -1 stands for the start of the match
+		position := -1
+		if baseShotID != nil {
+			position = shotIndex(active, *baseShotID)
+			if position < 0 {
+				return nil, internalErrors.NewShotSyncConflictError(matchUp.LastShot)
+			}
+		}
+
+		// Shots uploaded before follow the base in the order they were sent
+		result := &model.ShotSyncResult{Shots: make([]*model.MatchUpShot, 0, len(shots))}
+		for _, input := range shots {
+			next := position + 1
+			if next >= len(active) || !sameClientShot(active[next], input) {
+				break
+			}
+			result.Shots = append(result.Shots, active[next])
+			position = next
+		}
+		result.Skipped = len(result.Shots)
+
+		if position != len(active)-1 {
+			return nil, internalErrors.NewShotSyncConflictError(matchUp.LastShot)
+		}
+
+		for _, input := range shots[result.Skipped:] {
+			if err := shotValidator.ValidateAddShotInput(ctx, *input); err != nil {
+				return nil, err
+			}
+
+			// Any other known key means the shot was recorded and has since been
+			// undone or moved, which the client's batch doesn't account for
+			existing, err := s.shotsRepo.FindByClientShotID(ctx, matchUpID, *input.ClientShotID)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				return nil, internalErrors.NewShotSyncConflictError(matchUp.LastShot)
+			}
+
+			shot, err := s.appendShot(ctx, *input, userID)
+			if err != nil {
+				return nil, err
+			}
+			result.Shots = append(result.Shots, shot)
+		}
+
+		result.MatchUp, err = s.findMatchUp(ctx, matchUpID)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+}
+
+// shotIndex returns the position of a shot in a list, or -1
+func shotIndex(shots []*model.MatchUpShot, id primitive.ObjectID) int {
+	for i, shot := range shots {
+		if shot.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameClientShot reports whether a recorded shot was uploaded with the input's key
+func sameClientShot(shot *model.MatchUpShot, input *model.AddShotInput) bool {
+	return shot.ClientShotID != nil && *shot.ClientShotID == *input.ClientShotID
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/interchange"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// replayedShot is a shot scored again from the start of the match
+type replayedShot struct {
+	// The shot as stored, and as the scoring rules work it out again
+	stored, replayed *model.MatchUpShot
+	// The matchup as it stands after the shot
+	matchUp *model.MatchUp
+}
+
+// consistent reports whether the state stored after the shot agrees with the replay
+func (r *replayedShot) consistent() bool {
+	return scoring.SameSnapshot(r.stored.MatchStateAfterShot, r.replayed.MatchStateAfterShot)
+}
+
+// replayShots scores shots again, in order from the first, on a copy of the
+// matchup reset to the start of the match. The shots passed in are left as
+// they are. It stops at the first shot the scoring rules don't accept.
+func (s *MatchUpService) replayShots(matchUp *model.MatchUp, shots []*model.MatchUpShot) ([]*replayedShot, error) {
+	state := *matchUp
+	applyShotState(&state, nil)
+
+	replayed := make([]*replayedShot, 0, len(shots))
+	var prev *model.MatchUpShot
+	for i, stored := range shots {
+		shot := *stored
+		shot.PointContext = nil
+		shot.MatchStateAfterShot = nil
+
+		err := scoring.ValidateSequence(&shot, prev)
+		if err == nil {
+			err = s.scoreShot(&state, &shot, prev)
+		}
+		if err != nil {
+			return replayed, internalErrors.NewShotReplayError("shot " + strconv.Itoa(i+1) + " " + stored.ID.Hex() + ": " + err.Error())
+		}
+
+		applyShotState(&state, &shot)
+		after := state
+		replayed = append(replayed, &replayedShot{stored: stored, replayed: &shot, matchUp: &after})
+		prev = &shot
+	}
+	return replayed, nil
+}
+
+// GetMatchStateAt works out the full state of a match up right after one of
+// its shots by replaying the shots up to it
+func (s *MatchUpService) GetMatchStateAt(ctx context.Context, matchUpID primitive.ObjectID, shotID primitive.ObjectID) (*model.MatchStateAt, error) {
+	if _, err := middleware.GetMongoIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	matchUp, err := s.findMatchUp(ctx, matchUpID)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.activeShots(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+
+	position := -1
+	for i, shot := range shots {
+		if shot.ID == shotID {
+			position = i
+			break
+		}
+	}
+	if position < 0 {
+		return nil, internalErrors.NewShotNotFoundError()
+	}
+
+	replayed, err := s.replayShots(matchUp, shots[:position+1])
+	if err != nil {
+		return nil, err
+	}
+	at := replayed[position]
+	state := at.replayed.MatchStateAfterShot
+	return &model.MatchStateAt{
+		MatchUpID:             matchUp.ID,
+		ShotID:                shotID,
+		ShotNumber:            position + 1,
+		Score:                 state.Score,
+		CurrentServer:         state.CurrentServer,
+		ServingOrder:          state.ServingOrder,
+		CurrentServiceBoxSide: at.matchUp.CurrentServiceBoxSide,
+		CourtSides:            at.matchUp.CourtSides,
+		PointContext:          at.replayed.PointContext,
+		PendingImportance:     at.matchUp.NextPointImportance,
+		PointCompleted:        state.PointCompleted,
+		GameCompleted:         state.GameCompleted,
+		SetCompleted:          state.SetCompleted,
+		MatchCompleted:        state.MatchCompleted,
+		PointWinner:           state.PointWinner,
+		MatchesStoredState:    at.consistent(),
+	}, nil
+}
+
+// GetMatchTimeline lists the completed points and games of a match up in the
+// order they were played, from the states stored with its shots
+func (s *MatchUpService) GetMatchTimeline(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.MatchTimelineEntry, error) {
+	if _, err := middleware.GetMongoIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	matchUp, err := s.findMatchUp(ctx, matchUpID)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.activeShots(ctx, matchUp)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := []*model.MatchTimelineEntry{}
+	for i, shot := range shots {
+		state := shot.MatchStateAfterShot
+		if state == nil || !state.PointCompleted || state.PointWinner == nil || shot.PointContext == nil {
+			continue
+		}
+
+		point := &model.MatchTimelineEntry{
+			Type:        model.MatchTimelineEntryTypePoint,
+			ShotID:      shot.ID,
+			ShotNumber:  i + 1,
+			SetNumber:   shot.PointContext.SetNumber,
+			GameNumber:  shot.PointContext.GameNumber,
+			PointNumber: shot.PointContext.PointNumber,
+			Winner:      *state.PointWinner,
+			ServerSide:  shot.PointContext.ServerSide,
+			Importance:  shot.PointImportance,
+			Score:       interchange.ScoreText(state.Score),
+		}
+		timeline = append(timeline, point)
+		if state.GameCompleted {
+			game := *point
+			game.Type = model.MatchTimelineEntryTypeGame
+			timeline = append(timeline, &game)
+		}
+	}
+	return timeline, nil
+}
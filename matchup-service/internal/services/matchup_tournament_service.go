@@ -0,0 +1,508 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/draws"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/factory"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/lifecycle"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/validation"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// drawReopenedReason is recorded on matches cancelled because the result
+// that put their players in the draw slot was reopened
+const drawReopenedReason = "draw result reopened"
+
+// CreateTournament creates a tournament owned by the current user
+func (s *MatchUpService) CreateTournament(ctx context.Context, input model.CreateTournamentInput) (*model.Tournament, error) {
+	ownerID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tournamentValidator := validation.NewTournamentValidator()
+	if err := tournamentValidator.ValidateCreateTournamentInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	// Like a match, the tournament keeps its own copy of a preset's format
+	var format *model.MatchUpFormat
+	if input.MatchUpFormat != nil {
+		formatValidator := validation.NewFormatValidator()
+		if err := formatValidator.ValidateMatchUpFormatInput(ctx, *input.MatchUpFormat); err != nil {
+			return nil, err
+		}
+		format = factory.NewMatchUpFactory().ConvertMatchUpFormat(input.MatchUpFormat)
+	} else {
+		preset, err := s.findFormatPreset(ctx, ownerID, *input.MatchUpFormatPresetID)
+		if err != nil {
+			return nil, err
+		}
+		format = preset.MatchUpFormat
+	}
+
+	trackingStyle := model.MatchUpTrackingStyleBeginner
+	if input.TrackingStyle != nil {
+		trackingStyle = *input.TrackingStyle
+	}
+	visibility := model.MatchUpVisibilityPrivate
+	if input.Visibility != nil {
+		visibility = *input.Visibility
+	}
+
+	now := time.Now()
+	return s.tournamentsRepo.Insert(ctx, &model.Tournament{
+		Owner:         ownerID,
+		Name:          input.Name,
+		MatchUpType:   input.MatchUpType,
+		MatchUpFormat: format,
+		TrackingStyle: trackingStyle,
+		Visibility:    visibility,
+		Entries:       []*model.TournamentEntry{},
+		Draws:         []*model.Draw{},
+		CreatedAt:     now,
+		LastUpdated:   now,
+	})
+}
+
+// AddTournamentEntry enters a player or doubles team in one of the current
+// user's tournaments
+func (s *MatchUpService) AddTournamentEntry(ctx context.Context, input model.AddTournamentEntryInput) (*model.Tournament, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return inTransaction(ctx, s.transactor, func(ctx context.Context) (*model.Tournament, error) {
+		tournament, err := s.findOwnedTournament(ctx, input.TournamentID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		tournamentValidator := validation.NewTournamentValidator()
+		if err := tournamentValidator.ValidateAddTournamentEntryInput(ctx, input, tournament.MatchUpType); err != nil {
+			return nil, err
+		}
+
+		entry := &model.TournamentEntry{ID: primitive.NewObjectID(), Seed: input.Seed}
+		for _, player := range input.Players {
+			entry.Players = append(entry.Players, &model.TournamentPlayer{ID: player.ID, DisplayName: player.DisplayedName})
+		}
+
+		for _, existing := range tournament.Entries {
+			if input.Seed != nil && existing.Seed != nil && *existing.Seed == *input.Seed {
+				return nil, internalErrors.NewSeedTakenError()
+			}
+			for _, player := range existing.Players {
+				if entryHasPlayer(entry, player.ID) {
+					return nil, internalErrors.NewPlayerAlreadyEnteredError()
+				}
+			}
+		}
+
+		tournament.Entries = append(tournament.Entries, entry)
+		tournament.LastUpdated = time.Now()
+		return s.tournamentsRepo.Update(ctx, tournament)
+	})
+}
+
+// CreateDraw places entries of one of the current user's tournaments in a new
+// draw, settles its byes and creates the matches that can be played straight away
+func (s *MatchUpService) CreateDraw(ctx context.Context, input model.CreateDrawInput) (*model.Draw, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tournamentValidator := validation.NewTournamentValidator()
+	if err := tournamentValidator.ValidateCreateDrawInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	return inTransaction(ctx, s.transactor, func(ctx context.Context) (*model.Draw, error) {
+		tournament, err := s.findOwnedTournament(ctx, input.TournamentID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := tournament.Entries
+		if input.EntryIds != nil {
+			entries = nil
+			for _, id := range input.EntryIds {
+				entry := findEntry(tournament, id)
+				if entry == nil {
+					return nil, internalErrors.NewDrawEntryNotFoundError()
+				}
+				entries = append(entries, entry)
+			}
+		}
+		if len(entries) < 2 {
+			return nil, internalErrors.NewDrawTooFewEntriesError()
+		}
+
+		seeded := draws.SeedingOrder(entries)
+		draw := &model.Draw{
+			ID:        primitive.NewObjectID(),
+			Name:      input.Name,
+			DrawType:  input.DrawType,
+			EntryIds:  seeded,
+			Slots:     draws.Build(input.DrawType, seeded),
+			Standings: []*model.DrawStanding{},
+			CreatedAt: time.Now(),
+		}
+		tournament.Draws = append(tournament.Draws, draw)
+
+		if err := s.scheduleDraw(ctx, tournament, draw); err != nil {
+			return nil, err
+		}
+		tournament.LastUpdated = time.Now()
+		if _, err := s.tournamentsRepo.Update(ctx, tournament); err != nil {
+			return nil, err
+		}
+		return draw, nil
+	})
+}
+
+// AwardDrawWalkover decides a slot of one of the current user's draws whose
+// match was cancelled or abandoned, moving the winner on and creating the
+// matches that became ready
+func (s *MatchUpService) AwardDrawWalkover(ctx context.Context, input model.AwardDrawWalkoverInput) (*model.Draw, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return inTransaction(ctx, s.transactor, func(ctx context.Context) (*model.Draw, error) {
+		tournament, err := s.findOwnedTournament(ctx, input.TournamentID, userID)
+		if err != nil {
+			return nil, err
+		}
+		draw := findDraw(tournament, input.DrawID)
+		if draw == nil {
+			return nil, internalErrors.NewDrawNotFoundError()
+		}
+		slot := draws.Find(draw.Slots, input.SlotID)
+		if slot == nil {
+			return nil, internalErrors.NewDrawSlotNotFoundError()
+		}
+		if slot.Status != model.DrawSlotStatusScheduled || slot.MatchUpID == nil {
+			return nil, internalErrors.NewWalkoverNotAllowedError()
+		}
+		matchUp, err := s.findMatchUp(ctx, *slot.MatchUpID)
+		if err != nil {
+			return nil, err
+		}
+		if matchUp.MatchUpStatus != model.MatchUpStatusCancelled && matchUp.MatchUpStatus != model.MatchUpStatusAbandoned {
+			return nil, internalErrors.NewWalkoverNotAllowedError()
+		}
+
+		draws.Walkover(draw.Slots, slot, input.Winner)
+		if err := s.scheduleDraw(ctx, tournament, draw); err != nil {
+			return nil, err
+		}
+		tournament.LastUpdated = time.Now()
+		if _, err := s.tournamentsRepo.Update(ctx, tournament); err != nil {
+			return nil, err
+		}
+		return draw, nil
+	})
+}
+
+// GetTournament retrieves a tournament by its ID
+func (s *MatchUpService) GetTournament(ctx context.Context, id primitive.ObjectID) (*model.Tournament, error) {
+	return s.findTournament(ctx, id)
+}
+
+// GetMyTournaments retrieves the tournaments the current user runs or is entered in
+func (s *MatchUpService) GetMyTournaments(ctx context.Context, limit *int, offset *int) ([]*model.Tournament, error) {
+	userID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.tournamentsRepo.FindByUser(ctx, userID, limit, offset)
+}
+
+// GetTournamentDraw retrieves the draw sheet of one of a tournament's draws
+func (s *MatchUpService) GetTournamentDraw(ctx context.Context, tournamentID, drawID primitive.ObjectID) (*model.Draw, error) {
+	tournament, err := s.findTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	draw := findDraw(tournament, drawID)
+	if draw == nil {
+		return nil, internalErrors.NewDrawNotFoundError()
+	}
+	return draw, nil
+}
+
+// GetOrderOfPlay lists a tournament's matches still to be finished, by
+// scheduled start time and then by round. Matches without a start time come
+// last, and matches due together keep their draw's order.
+func (s *MatchUpService) GetOrderOfPlay(ctx context.Context, tournamentID primitive.ObjectID) ([]*model.OrderOfPlayEntry, error) {
+	tournament, err := s.findTournament(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderOfPlay := []*model.OrderOfPlayEntry{}
+	for _, draw := range tournament.Draws {
+		for _, slot := range draw.Slots {
+			if slot.Status != model.DrawSlotStatusScheduled || slot.MatchUpID == nil {
+				continue
+			}
+			matchUp, err := s.findMatchUp(ctx, *slot.MatchUpID)
+			if err != nil {
+				return nil, err
+			}
+			if lifecycle.IsFinal(matchUp.MatchUpStatus) {
+				continue
+			}
+			orderOfPlay = append(orderOfPlay, &model.OrderOfPlayEntry{
+				DrawID:   draw.ID,
+				DrawName: draw.Name,
+				Slot:     slot,
+				MatchUp:  matchUp,
+			})
+		}
+	}
+
+	sort.SliceStable(orderOfPlay, func(i, j int) bool {
+		a, b := orderOfPlay[i].MatchUp.ScheduledStartTime, orderOfPlay[j].MatchUp.ScheduledStartTime
+		switch {
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.Before(*b)
+		case (a == nil) != (b == nil):
+			return a != nil
+		default:
+			return orderOfPlay[i].Slot.Round < orderOfPlay[j].Slot.Round
+		}
+	})
+	return orderOfPlay, nil
+}
+
+// syncDraw keeps a tournament's draw in step with one of its matches. A match
+// being decided moves its winner, and in compass and consolation draws its
+// loser, on to their next matches. Reopening it takes them back, as long as
+// none of those matches have started; otherwise the draw keeps the result.
+// A cancelled or abandoned match leaves its slot to the organiser, who
+// decides it with AwardDrawWalkover.
+func (s *MatchUpService) syncDraw(ctx context.Context, previous model.MatchUpStatus, matchUp *model.MatchUp) error {
+	if matchUp.Tournament == nil {
+		return nil
+	}
+	wasDecided, isDecided := isDecided(previous), isDecided(matchUp.MatchUpStatus)
+	if wasDecided == isDecided {
+		return nil
+	}
+
+	tournament, err := s.findTournament(ctx, matchUp.Tournament.TournamentID)
+	if err != nil {
+		return err
+	}
+	draw := findDraw(tournament, matchUp.Tournament.DrawID)
+	if draw == nil {
+		return internalErrors.NewDrawNotFoundError()
+	}
+	slot := draws.Find(draw.Slots, matchUp.Tournament.SlotID)
+	if slot == nil || slot.MatchUpID == nil || *slot.MatchUpID != matchUp.ID {
+		return nil
+	}
+
+	switch {
+	case isDecided && slot.Status == model.DrawSlotStatusScheduled && matchUp.Winner != nil:
+		draws.Decide(draw.Slots, slot, *matchUp.Winner)
+	case !isDecided && slot.Status == model.DrawSlotStatusCompleted:
+		reopened, err := s.reopenSlot(ctx, draw, slot, matchUp.LastUpdated)
+		if err != nil || !reopened {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	if err := s.scheduleDraw(ctx, tournament, draw); err != nil {
+		return err
+	}
+	tournament.LastUpdated = matchUp.LastUpdated
+	_, err = s.tournamentsRepo.Update(ctx, tournament)
+	return err
+}
+
+// reopenSlot takes back a slot's result when none of the matches its entries
+// moved on to have started, cancelling the ones already created. It reports
+// whether the slot was reopened.
+func (s *MatchUpService) reopenSlot(ctx context.Context, draw *model.Draw, slot *model.DrawSlot, now time.Time) (bool, error) {
+	scheduled, ok, err := s.unplayedDownstream(ctx, draw.Slots, slot)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	reason := drawReopenedReason
+	for _, matchUp := range scheduled {
+		if err := lifecycle.Apply(matchUp, lifecycle.Change{
+			To:        model.MatchUpStatusCancelled,
+			Reason:    &reason,
+			ChangedBy: matchUp.Owner,
+			ChangedAt: now,
+		}); err != nil {
+			return false, err
+		}
+		matchUp.LastUpdated = now
+		if _, err := s.matchupsRepo.Update(ctx, matchUp); err != nil {
+			return false, err
+		}
+	}
+
+	draws.Reopen(draw.Slots, slot)
+	return true, nil
+}
+
+// unplayedDownstream returns the matches created for the slots a decided
+// slot moved its entries on to, following walkovers. It reports false when
+// any of those slots has been played or its match has started.
+func (s *MatchUpService) unplayedDownstream(ctx context.Context, slots []*model.DrawSlot, slot *model.DrawSlot) ([]*model.MatchUp, bool, error) {
+	var scheduled []*model.MatchUp
+	for _, target := range draws.Downstream(slots, slot) {
+		switch target.Status {
+		case model.DrawSlotStatusWaiting:
+		case model.DrawSlotStatusScheduled:
+			matchUp, err := s.findMatchUp(ctx, *target.MatchUpID)
+			if err != nil {
+				return nil, false, err
+			}
+			if matchUp.MatchUpStatus != model.MatchUpStatusScheduled {
+				return nil, false, nil
+			}
+			scheduled = append(scheduled, matchUp)
+		case model.DrawSlotStatusWalkover:
+			more, ok, err := s.unplayedDownstream(ctx, slots, target)
+			if err != nil || !ok {
+				return nil, false, err
+			}
+			scheduled = append(scheduled, more...)
+		default:
+			return nil, false, nil
+		}
+	}
+	return scheduled, true, nil
+}
+
+// scheduleDraw settles a draw's byes, creates a match for every slot that
+// is ready to be played and brings round robin standings up to date
+func (s *MatchUpService) scheduleDraw(ctx context.Context, tournament *model.Tournament, draw *model.Draw) error {
+	for _, slot := range draws.Settle(draw.Slots) {
+		matchUp, err := s.matchupsRepo.Insert(ctx, newDrawMatchUp(tournament, draw, slot))
+		if err != nil {
+			return err
+		}
+		slot.MatchUpID = &matchUp.ID
+		slot.Status = model.DrawSlotStatusScheduled
+	}
+
+	if draw.DrawType == model.DrawTypeRoundRobin {
+		draw.Standings = draws.Standings(draw.EntryIds, draw.Slots)
+	}
+	return nil
+}
+
+// newDrawMatchUp builds the match for a ready slot under the tournament's
+// format. The owner tracks it, and the players agreed to play by entering,
+// so they aren't asked again.
+func newDrawMatchUp(tournament *model.Tournament, draw *model.Draw, slot *model.DrawSlot) *model.MatchUp {
+	var participants []*model.ParticipantInput
+	for _, side := range slot.Sides {
+		for _, player := range findEntry(tournament, *side.EntryID).Players {
+			id := player.ID
+			participants = append(participants, &model.ParticipantInput{
+				ID:            &id,
+				DisplayedName: player.DisplayName,
+				TeamSide:      side.TeamSide,
+			})
+		}
+	}
+
+	trackingStyle, visibility := tournament.TrackingStyle, tournament.Visibility
+	matchUp := factory.NewMatchUpFactory().CreateMatchUpFromInitiateMatchUpInput(tournament.Owner, model.InitiateMatchUpInput{
+		MatchUpType:    tournament.MatchUpType,
+		Participants:   participants,
+		MatchUpTracker: tournament.Owner,
+		InitialServer:  *participants[0].ID,
+		TrackingStyle:  &trackingStyle,
+		Visibility:     &visibility,
+	}, tournament.MatchUpFormat)
+
+	for _, participant := range matchUp.Participants {
+		if participant.InvitationStatus != nil {
+			accepted := model.InvitationStatusAccepted
+			participant.InvitationStatus = &accepted
+			participant.InvitationRespondedAt = &matchUp.CreatedAt
+		}
+	}
+	matchUp.Tournament = &model.MatchUpDrawSlot{
+		TournamentID: tournament.ID,
+		DrawID:       draw.ID,
+		SlotID:       slot.ID,
+	}
+	return matchUp
+}
+
+// findTournament loads a tournament, mapping a missing document to a not found error
+func (s *MatchUpService) findTournament(ctx context.Context, id primitive.ObjectID) (*model.Tournament, error) {
+	tournament, err := s.tournamentsRepo.FindByID(ctx, id)
+	if err != nil {
+		if sharedErrors.IsNotFoundError(err) {
+			return nil, internalErrors.NewTournamentNotFoundError()
+		}
+		return nil, err
+	}
+	return tournament, nil
+}
+
+// findOwnedTournament loads a tournament the user runs
+func (s *MatchUpService) findOwnedTournament(ctx context.Context, id, userID primitive.ObjectID) (*model.Tournament, error) {
+	tournament, err := s.findTournament(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Owner != userID {
+		return nil, internalErrors.NewTournamentNotOwnerError()
+	}
+	return tournament, nil
+}
+
+// findEntry returns a tournament's entry with the given ID, or nil
+func findEntry(tournament *model.Tournament, id primitive.ObjectID) *model.TournamentEntry {
+	for _, entry := range tournament.Entries {
+		if entry.ID == id {
+			return entry
+		}
+	}
+	return nil
+}
+
+// findDraw returns a tournament's draw with the given ID, or nil
+func findDraw(tournament *model.Tournament, id primitive.ObjectID) *model.Draw {
+	for _, draw := range tournament.Draws {
+		if draw.ID == id {
+			return draw
+		}
+	}
+	return nil
+}
+
+// entryHasPlayer reports whether a user plays for an entry
+func entryHasPlayer(entry *model.TournamentEntry, userID primitive.ObjectID) bool {
+	for _, player := range entry.Players {
+		if player.ID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/access"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GrantMatchUpTracker lets a user record shots for one of the current user's
+// matchups. The right is held as a MATCH_TRACKER role on the matchup, which
+// shot recording checks through the access checker. The checker has its own
+// MongoDB client, so the role is granted once the tracker list is saved;
+// granting again after a failure is harmless.
+func (s *MatchUpService) GrantMatchUpTracker(ctx context.Context, matchUpID, userID primitive.ObjectID) (*model.MatchUp, error) {
+	ownerID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checker, ok := middleware.GetAccessChecker(ctx)
+	if !ok {
+		return nil, internalErrors.NewAccessControlUnavailableError()
+	}
+
+	updated, err := inTransaction(ctx, s.transactor, func(ctx context.Context) (*model.MatchUp, error) {
+		matchUp, err := s.findTrackersMatchUp(ctx, ownerID, matchUpID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !containsID(matchUp.Trackers, userID) {
+			matchUp.Trackers = append(matchUp.Trackers, userID)
+		}
+		matchUp.LastUpdated = time.Now()
+		return s.matchupsRepo.Update(ctx, matchUp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checker.GrantRole(ctx, userID.Hex(), updated.ID.Hex(), access.RoleMatchTracker, ownerID.Hex()); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// RevokeMatchUpTracker takes tracking rights for one of the current user's
+// matchups away from a user. The role goes first, outside the transaction, so
+// a failure to save the tracker list never leaves the user able to track.
+func (s *MatchUpService) RevokeMatchUpTracker(ctx context.Context, matchUpID, userID primitive.ObjectID) (*model.MatchUp, error) {
+	ownerID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checker, ok := middleware.GetAccessChecker(ctx)
+	if !ok {
+		return nil, internalErrors.NewAccessControlUnavailableError()
+	}
+
+	matchUp, err := s.findTrackersMatchUp(ctx, ownerID, matchUpID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsID(matchUp.Trackers, userID) {
+		return nil, internalErrors.NewTrackerNotFoundError()
+	}
+	if err := checker.RevokeRole(ctx, userID.Hex(), matchUp.ID.Hex(), access.RoleMatchTracker); err != nil {
+		return nil, err
+	}
+
+	return inTransaction(ctx, s.transactor, func(ctx context.Context) (*model.MatchUp, error) {
+		matchUp, err := s.findMatchUp(ctx, matchUpID)
+		if err != nil {
+			return nil, err
+		}
+
+		trackers := make([]primitive.ObjectID, 0, len(matchUp.Trackers))
+		for _, tracker := range matchUp.Trackers {
+			if tracker != userID {
+				trackers = append(trackers, tracker)
+			}
+		}
+		matchUp.Trackers = trackers
+		matchUp.LastUpdated = time.Now()
+		return s.matchupsRepo.Update(ctx, matchUp)
+	})
+}
+
+// findTrackersMatchUp loads a matchup whose trackers the owner is changing.
+// The owner can always track, so they can't be granted or revoked.
+func (s *MatchUpService) findTrackersMatchUp(ctx context.Context, ownerID, matchUpID, userID primitive.ObjectID) (*model.MatchUp, error) {
+	matchUp, err := s.findMatchUp(ctx, matchUpID)
+	if err != nil {
+		return nil, err
+	}
+	if matchUp.Owner != ownerID {
+		return nil, internalErrors.NewTrackerNotOwnerError()
+	}
+	if userID == ownerID {
+		return nil, internalErrors.NewTrackerIsOwnerError()
+	}
+	return matchUp, nil
+}
+
+// containsID reports whether an ID is in the list
+func containsID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
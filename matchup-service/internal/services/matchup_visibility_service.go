@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/access"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateMatchUpVisibility changes who besides its owner, participants and
+// trackers can see one of the current user's matchups
+func (s *MatchUpService) UpdateMatchUpVisibility(ctx context.Context, matchUpID primitive.ObjectID, visibility model.MatchUpVisibility) (*model.MatchUp, error) {
+	ownerID, err := middleware.GetMongoIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return inTransaction(ctx, s.transactor, func(ctx context.Context) (*model.MatchUp, error) {
+		matchUp, err := s.findMatchUp(ctx, matchUpID)
+		if err != nil {
+			return nil, err
+		}
+		if matchUp.Owner != ownerID {
+			return nil, internalErrors.NewVisibilityNotOwnerError()
+		}
+
+		matchUp.Visibility = visibility
+		matchUp.LastUpdated = time.Now()
+		return s.matchupsRepo.Update(ctx, matchUp)
+	})
+}
+
+// LoadAccessResource loads a matchup, or a tournament, for the @accessControl
+// directive, which checks the viewer against its visibility
+func (s *MatchUpService) LoadAccessResource(ctx context.Context, id string) (access.Resource, error) {
+	resourceID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, internalErrors.NewMatchUpNotFoundError()
+	}
+
+	// Tournaments guard their draws; anything else is a matchup
+	matchUp, err := s.findMatchUp(ctx, resourceID)
+	if err == nil {
+		return matchUp, nil
+	}
+	if !sharedErrors.IsNotFoundError(err) {
+		return nil, err
+	}
+	tournament, err := s.findTournament(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return tournament, nil
+}
@@ -0,0 +1,109 @@
+package statistics
+
+import (
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Career adds up one player's statistics over many decided matchups
+type Career struct {
+	playerID primitive.ObjectID
+	stats    *model.CareerStatistics
+	records  map[model.MatchUpType]*model.MatchUpTypeRecord
+	// Player and side totals behind the rates
+	player counters
+	side   counters
+}
+
+// NewCareer starts an empty career for a player
+func NewCareer(playerID primitive.ObjectID) *Career {
+	return &Career{
+		playerID: playerID,
+		stats:    &model.CareerStatistics{Records: []*model.MatchUpTypeRecord{}},
+		records:  make(map[model.MatchUpType]*model.MatchUpTypeRecord),
+	}
+}
+
+// Add counts a decided matchup the player took part in, given its active
+// shots in order. Matchups without a winner or the player are ignored.
+func (c *Career) Add(matchUp *model.MatchUp, shots []*model.MatchUpShot) {
+	var side model.TeamSide
+	for _, participant := range matchUp.Participants {
+		if participant.ID == c.playerID {
+			side = participant.TeamSide
+		}
+	}
+	if side == "" || matchUp.Winner == nil {
+		return
+	}
+	opponent := scoring.Opponent(side)
+	won := *matchUp.Winner == side
+
+	c.stats.MatchesPlayed++
+	record := c.record(matchUp.MatchUpType)
+	record.Played++
+	if won {
+		c.stats.MatchesWon++
+		record.Won++
+	} else {
+		c.stats.MatchesLost++
+		record.Lost++
+	}
+
+	score := matchUp.CurrentScore
+	c.stats.SetsWon += scoring.SetsWon(score, side)
+	c.stats.SetsLost += scoring.SetsWon(score, opponent)
+	for _, set := range score.Sets {
+		ours, theirs := scoring.SideScore(set, side), scoring.SideScore(set, opponent)
+		c.stats.GamesWon += ours.GamesWon
+		c.stats.GamesLost += theirs.GamesWon
+
+		if !set.IsCompleted || scoring.TiebreakPoints(ours)+scoring.TiebreakPoints(theirs) == 0 {
+			continue
+		}
+		if ours.GamesWon > theirs.GamesWon {
+			c.stats.TiebreaksWon++
+		} else {
+			c.stats.TiebreaksLost++
+		}
+	}
+
+	a := Aggregate(matchUp, shots)
+	player, team := a.player(c.playerID), a.teams[side]
+	c.player.aces += player.aces
+	c.player.doubleFaults += player.doubleFaults
+	c.player.reasonedServicePoints += player.reasonedServicePoints
+	c.side.breakPointOpportunities += team.breakPointOpportunities
+	c.side.breakPointsConverted += team.breakPointsConverted
+}
+
+// record returns the record for a match type, creating it on first use
+func (c *Career) record(matchUpType model.MatchUpType) *model.MatchUpTypeRecord {
+	record, ok := c.records[matchUpType]
+	if !ok {
+		record = &model.MatchUpTypeRecord{MatchUpType: matchUpType}
+		c.records[matchUpType] = record
+	}
+	return record
+}
+
+// Statistics returns the career totals, with records in match type order
+func (c *Career) Statistics() *model.CareerStatistics {
+	stats := *c.stats
+	stats.Records = []*model.MatchUpTypeRecord{}
+	for _, matchUpType := range model.AllMatchUpType {
+		if record, ok := c.records[matchUpType]; ok {
+			stats.Records = append(stats.Records, record)
+		}
+	}
+
+	stats.Aces = c.player.aces
+	stats.DoubleFaults = c.player.doubleFaults
+	stats.AceRate = percentage(c.player.aces, c.player.reasonedServicePoints)
+	stats.DoubleFaultRate = percentage(c.player.doubleFaults, c.player.reasonedServicePoints)
+	stats.BreakPointOpportunities = c.side.breakPointOpportunities
+	stats.BreakPointsConverted = c.side.breakPointsConverted
+	stats.BreakPointConversion = percentage(c.side.breakPointsConverted, c.side.breakPointOpportunities)
+	return &stats
+}
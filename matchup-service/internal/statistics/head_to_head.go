@@ -0,0 +1,66 @@
+package statistics
+
+import (
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HeadToHead adds up the record between two players over the decided
+// matchups they played against each other
+type HeadToHead struct {
+	record *model.HeadToHead
+}
+
+// NewHeadToHead starts an empty record between two players
+func NewHeadToHead(userA, userB primitive.ObjectID) *HeadToHead {
+	return &HeadToHead{record: &model.HeadToHead{
+		UserA:    userA,
+		UserB:    userB,
+		MatchUps: []*model.MatchUp{},
+	}}
+}
+
+// Add counts a matchup, given most recent first. Matchups without a winner or
+// without the two players on opposite sides are ignored.
+func (h *HeadToHead) Add(matchUp *model.MatchUp) {
+	var sideA, sideB model.TeamSide
+	for _, participant := range matchUp.Participants {
+		switch participant.ID {
+		case h.record.UserA:
+			sideA = participant.TeamSide
+		case h.record.UserB:
+			sideB = participant.TeamSide
+		}
+	}
+	if sideA == "" || sideB == "" || sideA == sideB || matchUp.Winner == nil {
+		return
+	}
+
+	if len(h.record.MatchUps) == 0 {
+		scoreline := scoring.Scoreline(matchUp.CurrentScore, sideA)
+		h.record.LastScoreline = &scoreline
+		h.record.LastPlayedAt = matchUp.EndTime
+	}
+	h.record.MatchUps = append(h.record.MatchUps, matchUp)
+
+	h.record.MatchesPlayed++
+	if *matchUp.Winner == sideA {
+		h.record.UserAWins++
+	} else {
+		h.record.UserBWins++
+	}
+
+	score := matchUp.CurrentScore
+	h.record.UserASetsWon += scoring.SetsWon(score, sideA)
+	h.record.UserBSetsWon += scoring.SetsWon(score, sideB)
+	for _, set := range score.Sets {
+		h.record.UserAGamesWon += scoring.SideScore(set, sideA).GamesWon
+		h.record.UserBGamesWon += scoring.SideScore(set, sideB).GamesWon
+	}
+}
+
+// Record returns the record between the two players
+func (h *HeadToHead) Record() *model.HeadToHead {
+	return h.record
+}
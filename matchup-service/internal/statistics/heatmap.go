@@ -0,0 +1,162 @@
+package statistics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// zoneSize is the side of a heatmap zone, an eighth of the court
+	zoneSize = 0.125
+	// The grid covers the area around the court that positions are recorded for
+	gridMin   = -0.5
+	gridZones = 16
+)
+
+// heatmapKey identifies the heatmap a shot is counted in
+type heatmapKey struct {
+	playerID       primitive.ObjectID
+	shotType       model.ShotType
+	serviceBoxSide model.ServiceBoxSide
+	shotOutcome    model.ShotOutcome
+}
+
+// zone is the column and row of a square in the heatmap grid
+type zone struct {
+	column, row int
+}
+
+// heatmap counts the positions of one group of shots
+type heatmap struct {
+	shots  int
+	bounce map[zone]int
+	hitter map[zone]int
+}
+
+// Heatmaps counts where the shots of a matchup bounced and were hit from,
+// given its active shots, grouped by player, shot type, service box and
+// outcome. Shots without a court position are left out, and so are groups
+// the filter doesn't match.
+func Heatmaps(matchUp *model.MatchUp, shots []*model.MatchUpShot, filter *model.ShotHeatmapFilterInput) []*model.ShotHeatmap {
+	heatmaps := make(map[heatmapKey]*heatmap)
+	for _, shot := range shots {
+		if shot.BounceLocation == nil && shot.HitterPosition == nil {
+			continue
+		}
+		key := heatmapKey{playerID: shot.HitterID, shotType: shot.ShotType, shotOutcome: shot.ShotOutcome}
+		if shot.ShotType == model.ShotTypeServe && shot.ServiceBoxSide != nil {
+			key.serviceBoxSide = *shot.ServiceBoxSide
+		}
+		if !matchesFilter(filter, key) {
+			continue
+		}
+
+		h, ok := heatmaps[key]
+		if !ok {
+			h = &heatmap{bounce: make(map[zone]int), hitter: make(map[zone]int)}
+			heatmaps[key] = h
+		}
+		h.shots++
+		if shot.BounceLocation != nil {
+			h.bounce[zoneOf(shot.BounceLocation)]++
+		}
+		if shot.HitterPosition != nil {
+			h.hitter[zoneOf(shot.HitterPosition)]++
+		}
+	}
+
+	// Players in the order they take part in the matchup, then by shot details
+	order := make(map[primitive.ObjectID]int)
+	for i, participant := range matchUp.Participants {
+		order[participant.ID] = i
+	}
+	keys := make([]heatmapKey, 0, len(heatmaps))
+	for key := range heatmaps {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch {
+		case a.playerID != b.playerID:
+			return order[a.playerID] < order[b.playerID]
+		case a.shotType != b.shotType:
+			return a.shotType < b.shotType
+		case a.serviceBoxSide != b.serviceBoxSide:
+			return a.serviceBoxSide < b.serviceBoxSide
+		default:
+			return a.shotOutcome < b.shotOutcome
+		}
+	})
+
+	result := make([]*model.ShotHeatmap, len(keys))
+	for i, key := range keys {
+		h := heatmaps[key]
+		result[i] = &model.ShotHeatmap{
+			PlayerID:    key.playerID,
+			ShotType:    key.shotType,
+			ShotOutcome: key.shotOutcome,
+			Shots:       h.shots,
+			BounceZones: zones(h.bounce),
+			HitterZones: zones(h.hitter),
+		}
+		if key.serviceBoxSide != "" {
+			serviceBoxSide := key.serviceBoxSide
+			result[i].ServiceBoxSide = &serviceBoxSide
+		}
+	}
+	return result
+}
+
+// matchesFilter reports whether a heatmap passes the filter
+func matchesFilter(f *model.ShotHeatmapFilterInput, key heatmapKey) bool {
+	if f == nil {
+		return true
+	}
+	return (f.PlayerID == nil || *f.PlayerID == key.playerID) &&
+		(f.ShotType == nil || *f.ShotType == key.shotType) &&
+		(f.ServiceBoxSide == nil || *f.ServiceBoxSide == key.serviceBoxSide) &&
+		(f.ShotOutcome == nil || *f.ShotOutcome == key.shotOutcome)
+}
+
+// zoneOf returns the zone a position falls in. Positions beyond the grid are
+// counted in the zone at its edge.
+func zoneOf(position *model.CourtPosition) zone {
+	index := func(coordinate float64) int {
+		i := int(math.Floor((coordinate - gridMin) / zoneSize))
+		return min(max(i, 0), gridZones-1)
+	}
+	return zone{column: index(position.X), row: index(position.Y)}
+}
+
+// zones lists the counted zones of a heatmap from the hitter's baseline
+// onwards, left to right
+func zones(counts map[zone]int) []*model.HeatmapZone {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	result := make([]*model.HeatmapZone, 0, len(counts))
+	for z, count := range counts {
+		xMin := gridMin + float64(z.column)*zoneSize
+		yMin := gridMin + float64(z.row)*zoneSize
+		result = append(result, &model.HeatmapZone{
+			XMin:  xMin,
+			XMax:  xMin + zoneSize,
+			YMin:  yMin,
+			YMax:  yMin + zoneSize,
+			Count: count,
+			Share: float64(count) / float64(total),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].YMin != result[j].YMin {
+			return result[i].YMin < result[j].YMin
+		}
+		return result[i].XMin < result[j].XMin
+	})
+	return result
+}
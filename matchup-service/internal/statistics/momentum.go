@@ -0,0 +1,111 @@
+package statistics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// Serve point win rates start from servePrior, worth servePriorPoints
+	// service points, so a side isn't unbeatable after holding once
+	servePrior       = 0.6
+	servePriorPoints = 10
+	// biggestSwings is how many points are flagged as the biggest swings
+	biggestSwings = 5
+)
+
+// Momentum builds the win probability series of a matchup, given its active
+// shots in order from the first to the last
+func Momentum(matchUp *model.MatchUp, shots []*model.MatchUpShot) *model.MatchMomentum {
+	sides := make(map[primitive.ObjectID]model.TeamSide)
+	for _, participant := range matchUp.Participants {
+		sides[participant.ID] = participant.TeamSide
+	}
+
+	// Each side's share of its service points won over the whole match
+	served := map[model.TeamSide]int{}
+	held := map[model.TeamSide]int{}
+	var pointStart *model.MatchUpShot
+	for _, shot := range shots {
+		if pointStart == nil {
+			pointStart = shot
+		}
+		state := shot.MatchStateAfterShot
+		if state == nil || !state.PointCompleted {
+			continue
+		}
+		if pointStart.PointContext != nil && state.PointWinner != nil {
+			server := pointStart.PointContext.ServerSide
+			served[server]++
+			if *state.PointWinner == server {
+				held[server]++
+			}
+		}
+		pointStart = nil
+	}
+	serveA := serveRate(held[model.TeamSideTeamA], served[model.TeamSideTeamA])
+	serveB := serveRate(held[model.TeamSideTeamB], served[model.TeamSideTeamB])
+
+	winModel := scoring.NewWinModel(matchUp.MatchUpFormat, serveA, serveB)
+	initialServer, ok := sides[matchUp.InitialServer]
+	if !ok {
+		initialServer = model.TeamSideTeamA
+	}
+	probability := winModel.WinProbability(scoring.NewEngine(matchUp.MatchUpFormat).InitialScore(), initialServer)
+
+	momentum := &model.MatchMomentum{
+		MatchUpID:              matchUp.ID,
+		InitialWinProbability:  probability,
+		TeamAServePointWinRate: serveA,
+		TeamBServePointWinRate: serveB,
+		Points:                 []*model.MomentumPoint{},
+	}
+	for _, shot := range shots {
+		state := shot.MatchStateAfterShot
+		if state == nil || !state.PointCompleted || state.PointWinner == nil {
+			continue
+		}
+		server, ok := sides[state.CurrentServer]
+		if !ok {
+			server = model.TeamSideTeamA
+		}
+		next := winModel.WinProbability(state.Score, server)
+		momentum.Points = append(momentum.Points, &model.MomentumPoint{
+			ShotID:         shot.ID,
+			PointNumber:    len(momentum.Points) + 1,
+			PointWinner:    *state.PointWinner,
+			WinProbability: next,
+			Swing:          next - probability,
+		})
+		probability = next
+	}
+
+	flagBiggestSwings(momentum.Points)
+	return momentum
+}
+
+// serveRate returns the probability of winning a point on serve, pulled
+// towards servePrior while the side has served few points
+func serveRate(held, served int) float64 {
+	return (float64(held) + servePrior*servePriorPoints) / float64(served+servePriorPoints)
+}
+
+// flagBiggestSwings marks the points that moved the win probability the most
+func flagBiggestSwings(points []*model.MomentumPoint) {
+	ranked := make([]*model.MomentumPoint, 0, len(points))
+	for _, point := range points {
+		if point.Swing != 0 {
+			ranked = append(ranked, point)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return math.Abs(ranked[i].Swing) > math.Abs(ranked[j].Swing)
+	})
+	for i := 0; i < len(ranked) && i < biggestSwings; i++ {
+		ranked[i].IsBiggestSwing = true
+	}
+}
@@ -0,0 +1,288 @@
+package statistics
+
+import (
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// counters accumulates point and shot totals for a team or a single player
+type counters struct {
+	pointsWon               int
+	aces                    int
+	doubleFaults            int
+	winners                 int
+	unforcedErrors          int
+	forcedErrorsInduced     int
+	breakPointsFaced        int
+	breakPointsSaved        int
+	breakPointOpportunities int
+	breakPointsConverted    int
+	servicePoints           int
+	firstServesIn           int
+	secondServes            int
+	secondServesIn          int
+	// Service points that recorded how they ended, the base for ace and
+	// double fault rates
+	reasonedServicePoints int
+}
+
+// Aggregator builds match, team and player statistics from the shots of one matchup
+type Aggregator struct {
+	matchUp     *model.MatchUp
+	engine      *scoring.Engine
+	score       *model.MatchUpScore
+	totalPoints int
+	// Points that recorded how they ended; points tracked at BEGINNER may not
+	reasonedPoints int
+	teams          map[model.TeamSide]*counters
+	players        map[primitive.ObjectID]*counters
+}
+
+// Aggregate walks the shots of a matchup, which must be the active chain in
+// order from the first shot to the last, and returns the collected totals
+func Aggregate(matchUp *model.MatchUp, shots []*model.MatchUpShot) *Aggregator {
+	a := &Aggregator{
+		matchUp: matchUp,
+		engine:  scoring.NewEngine(matchUp.MatchUpFormat),
+		teams: map[model.TeamSide]*counters{
+			model.TeamSideTeamA: {},
+			model.TeamSideTeamB: {},
+		},
+		players: make(map[primitive.ObjectID]*counters),
+	}
+	a.score = a.engine.InitialScore()
+
+	var point []*model.MatchUpShot
+	for _, shot := range shots {
+		// A let only replays the serve
+		if scoring.IsLet(shot) {
+			continue
+		}
+		point = append(point, shot)
+		if shot.MatchStateAfterShot == nil || !shot.MatchStateAfterShot.PointCompleted {
+			continue
+		}
+		a.addPoint(point)
+		a.score = shot.MatchStateAfterShot.Score
+		point = nil
+	}
+
+	return a
+}
+
+// addPoint records a completed point, given every shot played in it
+func (a *Aggregator) addPoint(point []*model.MatchUpShot) {
+	last := point[len(point)-1]
+	winner := *last.MatchStateAfterShot.PointWinner
+	a.totalPoints++
+	a.teams[winner].pointsWon++
+
+	a.addServe(point, winner)
+
+	// A penalty, or a point recorded on its own without a reason, says nothing
+	// about who played it
+	if last.ShotType == model.ShotTypeMatchEvent || last.ShotType == model.ShotTypePoint && last.PointWinReason == nil {
+		return
+	}
+
+	var prev *model.MatchUpShot
+	if len(point) > 1 {
+		prev = point[len(point)-2]
+	}
+	// The player whose shot drew an error is credited with the point
+	if prev != nil && prev.HitterSide == winner && last.ShotOutcome == model.ShotOutcomeError {
+		a.player(prev.HitterID).pointsWon++
+	}
+	if last.ShotOutcome == model.ShotOutcomeWonPoint {
+		a.player(last.HitterID).pointsWon++
+	}
+
+	if last.PointWinReason == nil {
+		return
+	}
+	a.reasonedPoints++
+	team, player := a.teams[last.HitterSide], a.player(last.HitterID)
+	switch *last.PointWinReason {
+	case model.PointWinReasonAce:
+		team.aces++
+		player.aces++
+	case model.PointWinReasonWinner:
+		team.winners++
+		player.winners++
+	case model.PointWinReasonDoubleFault:
+		team.doubleFaults++
+		player.doubleFaults++
+	case model.PointWinReasonUnforcedError:
+		team.unforcedErrors++
+		player.unforcedErrors++
+	case model.PointWinReasonForcedError:
+		a.teams[winner].forcedErrorsInduced++
+		if prev != nil && prev.HitterSide == winner {
+			a.player(prev.HitterID).forcedErrorsInduced++
+		}
+	}
+}
+
+// addServe records serve percentages and break points for the point's
+// server. Serve percentages only count points recorded shot by shot.
+func (a *Aggregator) addServe(point []*model.MatchUpShot, winner model.TeamSide) {
+	first := point[0]
+	if first.PointContext == nil {
+		return
+	}
+	serverSide := first.PointContext.ServerSide
+	team, player := a.teams[serverSide], a.player(first.PointContext.ServerID)
+
+	if last := point[len(point)-1]; last.PointWinReason != nil && last.ShotType != model.ShotTypeMatchEvent {
+		team.reasonedServicePoints++
+		player.reasonedServicePoints++
+	}
+
+	if first.ShotType == model.ShotTypeServe {
+		team.servicePoints++
+		player.servicePoints++
+		if first.ShotOutcome != model.ShotOutcomeFirstFault {
+			team.firstServesIn++
+			player.firstServesIn++
+		} else if len(point) > 1 && point[1].ShotType == model.ShotTypeServe {
+			team.secondServes++
+			player.secondServes++
+			if second := point[1]; second.ShotOutcome != model.ShotOutcomeFirstFault && second.ShotOutcome != model.ShotOutcomeError {
+				team.secondServesIn++
+				player.secondServesIn++
+			}
+		}
+	}
+
+	if !a.engine.IsBreakPoint(a.score, serverSide) {
+		return
+	}
+	receiver := a.teams[scoring.Opponent(serverSide)]
+	team.breakPointsFaced++
+	player.breakPointsFaced++
+	receiver.breakPointOpportunities++
+	if winner == serverSide {
+		team.breakPointsSaved++
+		player.breakPointsSaved++
+	} else {
+		receiver.breakPointsConverted++
+	}
+}
+
+// player returns the counters for a player, creating them on first use
+func (a *Aggregator) player(id primitive.ObjectID) *counters {
+	c, ok := a.players[id]
+	if !ok {
+		c = &counters{}
+		a.players[id] = c
+	}
+	return c
+}
+
+// MatchStatistics returns the match totals and a breakdown for both team sides
+func (a *Aggregator) MatchStatistics() *model.MatchStatistics {
+	stats := &model.MatchStatistics{
+		TotalPoints:   a.totalPoints,
+		TotalSets:     setsPlayed(a.score),
+		TrackingStyle: a.matchUp.TrackingStyle,
+	}
+	if stats.TrackingStyle == "" {
+		stats.TrackingStyle = model.MatchUpTrackingStyleBeginner
+	}
+	for _, set := range a.score.Sets {
+		stats.TotalGames += scoring.GamesPlayed(set)
+	}
+	if a.matchUp.StartTime != nil && a.matchUp.EndTime != nil {
+		duration := int(a.matchUp.EndTime.Sub(*a.matchUp.StartTime).Milliseconds())
+		stats.DurationMillis = &duration
+	}
+
+	for _, side := range []model.TeamSide{model.TeamSideTeamA, model.TeamSideTeamB} {
+		c := a.teams[side]
+		teamStats := &model.TeamStatistics{
+			TeamSide:                side,
+			PointsWon:               c.pointsWon,
+			SetsWon:                 scoring.SetsWon(a.score, side),
+			Aces:                    a.reasonCount(c.aces),
+			DoubleFaults:            a.reasonCount(c.doubleFaults),
+			Winners:                 a.reasonCount(c.winners),
+			UnforcedErrors:          a.reasonCount(c.unforcedErrors),
+			ForcedErrorsInduced:     a.reasonCount(c.forcedErrorsInduced),
+			BreakPointsFaced:        c.breakPointsFaced,
+			BreakPointsSaved:        c.breakPointsSaved,
+			BreakPointOpportunities: c.breakPointOpportunities,
+			BreakPointsConverted:    c.breakPointsConverted,
+			FirstServePercentage:    percentage(c.firstServesIn, c.servicePoints),
+			SecondServePercentage:   percentage(c.secondServesIn, c.secondServes),
+		}
+		for _, set := range a.score.Sets {
+			teamStats.GamesWon += scoring.SideScore(set, side).GamesWon
+		}
+		stats.TeamStats = append(stats.TeamStats, teamStats)
+	}
+
+	return stats
+}
+
+// PlayerStatistics returns statistics for every participant, in participant order
+func (a *Aggregator) PlayerStatistics() []*model.PlayerStatistics {
+	stats := make([]*model.PlayerStatistics, 0, len(a.matchUp.Participants))
+	for _, participant := range a.matchUp.Participants {
+		c := a.player(participant.ID)
+		stats = append(stats, &model.PlayerStatistics{
+			PlayerID:              participant.ID,
+			TeamSide:              participant.TeamSide,
+			PointsWon:             c.pointsWon,
+			Aces:                  a.reasonCount(c.aces),
+			DoubleFaults:          a.reasonCount(c.doubleFaults),
+			Winners:               a.reasonCount(c.winners),
+			UnforcedErrors:        a.reasonCount(c.unforcedErrors),
+			ForcedErrorsInduced:   a.reasonCount(c.forcedErrorsInduced),
+			BreakPointsFaced:      c.breakPointsFaced,
+			BreakPointsSaved:      c.breakPointsSaved,
+			FirstServePercentage:  percentage(c.firstServesIn, c.servicePoints),
+			SecondServePercentage: percentage(c.secondServesIn, c.secondServes),
+		})
+	}
+	return stats
+}
+
+// setsPlayed counts the sets in which at least one point has been played
+func setsPlayed(score *model.MatchUpScore) int {
+	played := 0
+	for _, set := range score.Sets {
+		if set.IsCompleted || scoring.GamesPlayed(set) > 0 || setInProgress(set) {
+			played++
+		}
+	}
+	return played
+}
+
+// setInProgress reports whether a point has been won in the set's current game
+func setInProgress(set *model.SetScore) bool {
+	for _, side := range set.Sides {
+		if side.InGameScore != model.InGameScoreZero || scoring.TiebreakPoints(side) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// reasonCount returns a count that depends on how points ended, or nil when
+// points were played but none of them recorded it
+func (a *Aggregator) reasonCount(count int) *int {
+	if a.totalPoints > 0 && a.reasonedPoints == 0 {
+		return nil
+	}
+	return &count
+}
+
+// percentage returns part as a percentage of total, or nil when total is zero
+func percentage(part, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	value := float64(part) * 100 / float64(total)
+	return &value
+}
@@ -3,6 +3,7 @@ package validation
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
 	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
@@ -26,6 +27,8 @@ func (v *FormatValidator) Validate(ctx context.Context, input interface{}) error
 			return internalErrors.NewRequiredFieldError("matchUpFormat")
 		}
 		return v.ValidateMatchUpFormatInput(ctx, *typedInput)
+	case model.CreateMatchUpFormatPresetInput:
+		return v.ValidateCreateMatchUpFormatPresetInput(ctx, typedInput)
 	default:
 		return fmt.Errorf("unsupported input type for FormatValidator: %T", input)
 	}
@@ -53,6 +56,18 @@ func (v *FormatValidator) ValidateMatchUpFormatInput(ctx context.Context, input
 	return nil
 }
 
+// ValidateCreateMatchUpFormatPresetInput validates a preset before it is saved
+func (v *FormatValidator) ValidateCreateMatchUpFormatPresetInput(ctx context.Context, input model.CreateMatchUpFormatPresetInput) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return internalErrors.NewRequiredFieldError("name")
+	}
+	if input.MatchUpFormat == nil {
+		return internalErrors.NewRequiredFieldError("matchUpFormat")
+	}
+
+	return v.ValidateMatchUpFormatInput(ctx, *input.MatchUpFormat)
+}
+
 // ValidateSetFormat validates a set format input
 // Changed to accept a pointer to handle both pointer and non-pointer cases
 func (v *FormatValidator) ValidateSetFormat(format *model.SetFormatInput) error {
@@ -90,11 +105,12 @@ func (v *FormatValidator) ValidateTiebreakFormat(format *model.TiebreakFormatInp
 	// Handle the case where TiebreakAt is a pointer
 	tiebreakAt := format.TiebreakAt
 
-	// Validate that tiebreakAt is >= numberOfGames
-	if tiebreakAt < numberOfGames {
+	// Validate that tiebreakAt is 0 (a set played as a single tiebreak, e.g. a
+	// match tiebreak) or at least one game short of numberOfGames (e.g. 3-3 in Fast4)
+	if tiebreakAt != 0 && tiebreakAt < numberOfGames-1 {
 		return internalErrors.NewInvalidMatchFormatError(
-			fmt.Sprintf("tiebreakAt (%d) must be greater than or equal to numberOfGames (%d)",
-				tiebreakAt, numberOfGames))
+			fmt.Sprintf("tiebreakAt (%d) must be 0 or at least numberOfGames - 1 (%d)",
+				tiebreakAt, numberOfGames-1))
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 
 	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
 	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -26,6 +27,10 @@ func (v *MatchUpValidator) Validate(ctx context.Context, input interface{}) erro
 	switch typedInput := input.(type) {
 	case model.InitiateMatchUpInput:
 		return v.ValidateInitiateMatchUpInput(ctx, typedInput)
+	case model.UpdateMatchUpStatusInput:
+		return v.ValidateUpdateMatchUpStatusInput(ctx, typedInput)
+	case *model.MatchUpFilterInput:
+		return v.ValidateMatchUpFilterInput(ctx, typedInput)
 	default:
 		return errors.ErrUnsupported
 	}
@@ -33,6 +38,15 @@ func (v *MatchUpValidator) Validate(ctx context.Context, input interface{}) erro
 
 // ValidateInitiateMatchUpInput validates the input for initiating a match
 func (v *MatchUpValidator) ValidateInitiateMatchUpInput(ctx context.Context, input model.InitiateMatchUpInput) error {
+	// The format comes either inline or from a preset, never both
+	if (input.MatchUpFormat == nil) == (input.MatchUpFormatPresetID == nil) {
+		return internalErrors.NewFormatSourceRequiredError()
+	}
+
+	if input.TrackingStyle != nil && !input.TrackingStyle.IsValid() {
+		return sharedErrors.NewValidationError("trackingStyle", "invalid tracking style")
+	}
+
 	// Validate match type and participant count
 	if err := v.validateMatchTypeAndParticipants(input.MatchUpType, input.Participants); err != nil {
 		return err
@@ -51,6 +65,37 @@ func (v *MatchUpValidator) ValidateInitiateMatchUpInput(ctx context.Context, inp
 	return nil
 }
 
+// ValidateUpdateMatchUpStatusInput validates the input for a status change.
+// Whether the transition itself is legal depends on the stored matchup.
+func (v *MatchUpValidator) ValidateUpdateMatchUpStatusInput(ctx context.Context, input model.UpdateMatchUpStatusInput) error {
+	if !input.Status.IsValid() {
+		return internalErrors.NewRequiredFieldError("status")
+	}
+
+	if input.Status == model.MatchUpStatusRetired {
+		if input.RetiringSide == nil || !input.RetiringSide.IsValid() {
+			return internalErrors.NewRetiringSideRequiredError()
+		}
+	} else if input.RetiringSide != nil {
+		return internalErrors.NewRetiringSideNotAllowedError()
+	}
+
+	return nil
+}
+
+// ValidateMatchUpFilterInput validates the optional filter for listing matches
+func (v *MatchUpValidator) ValidateMatchUpFilterInput(ctx context.Context, filter *model.MatchUpFilterInput) error {
+	if filter == nil {
+		return nil
+	}
+
+	if filter.StartTimeFrom != nil && filter.StartTimeTo != nil && filter.StartTimeFrom.After(*filter.StartTimeTo) {
+		return internalErrors.NewInvalidDateRangeError()
+	}
+
+	return nil
+}
+
 // validateMatchTypeAndParticipants validates that the number of participants matches the match type
 func (v *MatchUpValidator) validateMatchTypeAndParticipants(matchType model.MatchUpType, participants []*model.ParticipantInput) error {
 	if len(participants) == 0 {
@@ -0,0 +1,216 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Court positions are normalized to the court, with room around it for
+// balls that land out and players standing behind the baseline
+const (
+	minCourtCoordinate = -0.5
+	maxCourtCoordinate = 1.5
+)
+
+// ShotValidator validates shot-related inputs
+type ShotValidator struct{}
+
+// NewShotValidator creates a new shot validator
+func NewShotValidator() *ShotValidator {
+	return &ShotValidator{}
+}
+
+// Validate implements the Validator interface
+func (v *ShotValidator) Validate(ctx context.Context, input interface{}) error {
+	switch typedInput := input.(type) {
+	case model.AddShotInput:
+		return v.ValidateAddShotInput(ctx, typedInput)
+	case *model.AddShotInput:
+		if typedInput == nil {
+			return internalErrors.NewRequiredFieldError("input")
+		}
+		return v.ValidateAddShotInput(ctx, *typedInput)
+	case model.AddPointInput:
+		return v.ValidateAddPointInput(ctx, typedInput)
+	case *model.AddPointInput:
+		if typedInput == nil {
+			return internalErrors.NewRequiredFieldError("input")
+		}
+		return v.ValidateAddPointInput(ctx, *typedInput)
+	case model.AddMatchEventInput:
+		return v.ValidateAddMatchEventInput(ctx, typedInput)
+	case *model.AddMatchEventInput:
+		if typedInput == nil {
+			return internalErrors.NewRequiredFieldError("input")
+		}
+		return v.ValidateAddMatchEventInput(ctx, *typedInput)
+	default:
+		return fmt.Errorf("unsupported input type for ShotValidator: %T", input)
+	}
+}
+
+// ValidateAddShotInput validates that the shot details are consistent with its type and outcome
+func (v *ShotValidator) ValidateAddShotInput(ctx context.Context, input model.AddShotInput) error {
+	if !input.ShotType.IsValid() {
+		return sharedErrors.NewValidationError("shotType", "invalid shot type")
+	}
+	if input.ShotType == model.ShotTypePoint {
+		return sharedErrors.NewValidationError("shotType", "POINT is recorded with addPoint")
+	}
+	if input.ShotType == model.ShotTypeMatchEvent {
+		return sharedErrors.NewValidationError("shotType", "MATCH_EVENT is recorded with addMatchEvent")
+	}
+	if !input.ShotOutcome.IsValid() {
+		return sharedErrors.NewValidationError("shotOutcome", "invalid shot outcome")
+	}
+	if input.ClientShotID != nil && strings.TrimSpace(*input.ClientShotID) == "" {
+		return sharedErrors.NewValidationError("clientShotId", "clientShotId must not be empty")
+	}
+
+	// Serve-only fields
+	if input.ShotType != model.ShotTypeServe {
+		if input.ServeStyle != nil || input.ServeNumber != nil || input.ServiceBoxSide != nil {
+			return sharedErrors.NewValidationError("shotType", "serve details are only allowed when shotType is SERVE")
+		}
+		if input.ShotOutcome == model.ShotOutcomeFirstFault {
+			return sharedErrors.NewValidationError("shotOutcome", "FIRST_FAULT is only allowed when shotType is SERVE")
+		}
+	}
+
+	// Ground stroke-only fields
+	if input.ShotType != model.ShotTypeGroundStroke {
+		if input.GroundStrokeType != nil || input.GroundStrokeStyle != nil {
+			return sharedErrors.NewValidationError("shotType", "ground stroke details are only allowed when shotType is GROUND_STROKE")
+		}
+	}
+
+	for field, position := range map[string]*model.CourtPositionInput{
+		"bounceLocation": input.BounceLocation,
+		"hitterPosition": input.HitterPosition,
+	} {
+		if err := validateCourtPosition(field, position); err != nil {
+			return err
+		}
+	}
+
+	return v.validatePointWinReason(input)
+}
+
+// validateCourtPosition checks that an optional position lies within the
+// area around the court that positions are recorded for
+func validateCourtPosition(field string, position *model.CourtPositionInput) error {
+	if position == nil {
+		return nil
+	}
+	for _, coordinate := range []float64{position.X, position.Y} {
+		if !(coordinate >= minCourtCoordinate && coordinate <= maxCourtCoordinate) {
+			return sharedErrors.NewValidationError(field, "court coordinates must be between -0.5 and 1.5")
+		}
+	}
+	return nil
+}
+
+// ValidateShotBatch validates a batch of offline shots as a whole: every shot
+// belongs to the matchup being synced and has its own idempotency key. Each
+// shot's details are validated when it is applied.
+func (v *ShotValidator) ValidateShotBatch(ctx context.Context, matchUpID primitive.ObjectID, shots []*model.AddShotInput) error {
+	keys := make(map[string]bool, len(shots))
+	for i, shot := range shots {
+		position := "shot " + strconv.Itoa(i+1)
+		if shot == nil {
+			return internalErrors.NewInvalidShotBatchError(position + " is missing")
+		}
+		if shot.MatchUpID != matchUpID {
+			return internalErrors.NewInvalidShotBatchError(position + " belongs to another matchup")
+		}
+		if shot.ClientShotID == nil || strings.TrimSpace(*shot.ClientShotID) == "" {
+			return internalErrors.NewInvalidShotBatchError(position + " has no clientShotId")
+		}
+		if keys[*shot.ClientShotID] {
+			return internalErrors.NewInvalidShotBatchError(position + " repeats clientShotId " + *shot.ClientShotID)
+		}
+		keys[*shot.ClientShotID] = true
+	}
+	return nil
+}
+
+// ValidateAddPointInput validates a point recorded without its rally. Whether
+// the reason fits the server and the attributed player is checked against
+// the matchup by the service.
+func (v *ShotValidator) ValidateAddPointInput(ctx context.Context, input model.AddPointInput) error {
+	if !input.PointWinner.IsValid() {
+		return sharedErrors.NewValidationError("pointWinner", "invalid team side")
+	}
+	if input.PointWinReason != nil && !input.PointWinReason.IsValid() {
+		return sharedErrors.NewValidationError("pointWinReason", "invalid point win reason")
+	}
+	if input.PointWinReason != nil && *input.PointWinReason == model.PointWinReasonPenalty {
+		return sharedErrors.NewValidationError("pointWinReason", "PENALTY is recorded with addMatchEvent")
+	}
+	if input.PlayerID != nil && input.PointWinReason == nil {
+		return sharedErrors.NewValidationError("playerId", "playerId is only allowed together with pointWinReason")
+	}
+	return nil
+}
+
+// ValidateAddMatchEventInput validates a let or a penalty. Whether a let can
+// be called, and on whom, is checked against the matchup by the service.
+func (v *ShotValidator) ValidateAddMatchEventInput(ctx context.Context, input model.AddMatchEventInput) error {
+	if !input.EventType.IsValid() {
+		return sharedErrors.NewValidationError("eventType", "invalid match event type")
+	}
+	if input.Violation != nil && !input.Violation.IsValid() {
+		return sharedErrors.NewValidationError("violation", "invalid violation type")
+	}
+	if input.EventType == model.MatchEventTypeLet {
+		if input.Violation != nil {
+			return sharedErrors.NewValidationError("violation", "a let is not a violation")
+		}
+		return nil
+	}
+	if input.PlayerID == nil {
+		return sharedErrors.NewValidationError("playerId", "playerId is required for "+input.EventType.String())
+	}
+	return nil
+}
+
+// validatePointWinReason validates that the reason matches the shot outcome
+func (v *ShotValidator) validatePointWinReason(input model.AddShotInput) error {
+	if input.PointWinReason == nil {
+		return nil
+	}
+
+	switch input.ShotOutcome {
+	case model.ShotOutcomeWonPoint:
+		switch *input.PointWinReason {
+		case model.PointWinReasonWinner:
+			return nil
+		case model.PointWinReasonAce:
+			if input.ShotType != model.ShotTypeServe {
+				return sharedErrors.NewValidationError("pointWinReason", "ACE is only allowed on a serve")
+			}
+			return nil
+		}
+	case model.ShotOutcomeError:
+		switch *input.PointWinReason {
+		case model.PointWinReasonForcedError, model.PointWinReasonUnforcedError:
+			return nil
+		case model.PointWinReasonDoubleFault:
+			if input.ShotType == model.ShotTypeServe {
+				return nil
+			}
+		}
+	}
+
+	return sharedErrors.NewValidationError(
+		"pointWinReason",
+		fmt.Sprintf("%s is not a valid reason for a %s shot", input.PointWinReason.String(), input.ShotOutcome.String()),
+	)
+}
@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TournamentValidator validates tournament-related inputs. Checks against the
+// stored tournament, like seeds already taken, are left to the service.
+type TournamentValidator struct{}
+
+// NewTournamentValidator creates a new tournament validator
+func NewTournamentValidator() *TournamentValidator {
+	return &TournamentValidator{}
+}
+
+// Validate implements the Validator interface
+func (v *TournamentValidator) Validate(ctx context.Context, input interface{}) error {
+	switch typedInput := input.(type) {
+	case model.CreateTournamentInput:
+		return v.ValidateCreateTournamentInput(ctx, typedInput)
+	case model.AddTournamentEntryInput:
+		return v.ValidateAddTournamentEntryInput(ctx, typedInput, model.MatchUpTypeSingles)
+	case model.CreateDrawInput:
+		return v.ValidateCreateDrawInput(ctx, typedInput)
+	default:
+		return fmt.Errorf("unsupported input type for TournamentValidator: %T", input)
+	}
+}
+
+// ValidateCreateTournamentInput validates the input for creating a tournament
+func (v *TournamentValidator) ValidateCreateTournamentInput(ctx context.Context, input model.CreateTournamentInput) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return internalErrors.NewRequiredFieldError("name")
+	}
+	if !input.MatchUpType.IsValid() {
+		return internalErrors.NewRequiredFieldError("matchUpType")
+	}
+
+	// The format comes either inline or from a preset, never both
+	if (input.MatchUpFormat == nil) == (input.MatchUpFormatPresetID == nil) {
+		return internalErrors.NewFormatSourceRequiredError()
+	}
+
+	if input.TrackingStyle != nil && !input.TrackingStyle.IsValid() {
+		return sharedErrors.NewValidationError("trackingStyle", "invalid tracking style")
+	}
+	if input.Visibility != nil && !input.Visibility.IsValid() {
+		return sharedErrors.NewValidationError("visibility", "invalid visibility")
+	}
+	return nil
+}
+
+// ValidateAddTournamentEntryInput validates an entry for a tournament of the
+// given match type: one player for singles, two for doubles, each entered once
+func (v *TournamentValidator) ValidateAddTournamentEntryInput(ctx context.Context, input model.AddTournamentEntryInput, matchType model.MatchUpType) error {
+	expected := 1
+	if matchType == model.MatchUpTypeDoubles {
+		expected = 2
+	}
+	if len(input.Players) != expected {
+		return internalErrors.NewEntryPlayerCountError(matchType.String(), expected)
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(input.Players))
+	for _, player := range input.Players {
+		if strings.TrimSpace(player.DisplayedName) == "" {
+			return internalErrors.NewRequiredFieldError("displayedName")
+		}
+		if seen[player.ID] {
+			return internalErrors.NewPlayerAlreadyEnteredError()
+		}
+		seen[player.ID] = true
+	}
+
+	if input.Seed != nil && *input.Seed < 1 {
+		return internalErrors.NewInvalidSeedError()
+	}
+	return nil
+}
+
+// ValidateCreateDrawInput validates the input for creating a draw
+func (v *TournamentValidator) ValidateCreateDrawInput(ctx context.Context, input model.CreateDrawInput) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return internalErrors.NewRequiredFieldError("name")
+	}
+	if !input.DrawType.IsValid() {
+		return internalErrors.NewRequiredFieldError("drawType")
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(input.EntryIds))
+	for _, id := range input.EntryIds {
+		if seen[id] {
+			return internalErrors.NewDrawDuplicateEntryError()
+		}
+		seen[id] = true
+	}
+	return nil
+}
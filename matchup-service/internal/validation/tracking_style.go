@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+)
+
+// shotFieldRule is a shot detail that a tracking style requires whenever it
+// applies to the shot being recorded
+type shotFieldRule struct {
+	field   string
+	applies func(input model.AddShotInput) bool
+	present func(input model.AddShotInput) bool
+}
+
+var (
+	groundStrokeTypeRule = shotFieldRule{
+		field:   "groundStrokeType",
+		applies: func(input model.AddShotInput) bool { return input.ShotType == model.ShotTypeGroundStroke },
+		present: func(input model.AddShotInput) bool { return input.GroundStrokeType != nil },
+	}
+	groundStrokeStyleRule = shotFieldRule{
+		field:   "groundStrokeStyle",
+		applies: func(input model.AddShotInput) bool { return input.ShotType == model.ShotTypeGroundStroke },
+		present: func(input model.AddShotInput) bool { return input.GroundStrokeStyle != nil },
+	}
+	serveStyleRule = shotFieldRule{
+		field:   "serveStyle",
+		applies: func(input model.AddShotInput) bool { return input.ShotType == model.ShotTypeServe },
+		present: func(input model.AddShotInput) bool { return input.ServeStyle != nil },
+	}
+	// Rally winners can only be WINNER, but an error has to say whether it was forced
+	rallyErrorReasonRule = shotFieldRule{
+		field: "pointWinReason",
+		applies: func(input model.AddShotInput) bool {
+			return input.ShotType != model.ShotTypeServe && input.ShotOutcome == model.ShotOutcomeError
+		},
+		present: func(input model.AddShotInput) bool { return input.PointWinReason != nil },
+	}
+)
+
+// trackingStyleRules lists the shot details each tracking style requires on
+// top of shotType and shotOutcome
+var trackingStyleRules = map[model.MatchUpTrackingStyle][]shotFieldRule{
+	model.MatchUpTrackingStyleBeginner:     nil,
+	model.MatchUpTrackingStyleIntermediate: {groundStrokeTypeRule, rallyErrorReasonRule},
+	model.MatchUpTrackingStyleAdvanced:     {groundStrokeTypeRule, groundStrokeStyleRule, serveStyleRule, rallyErrorReasonRule},
+}
+
+// ValidateTrackingStyle checks that a shot gives every detail the matchup's
+// tracking style requires
+func (v *ShotValidator) ValidateTrackingStyle(ctx context.Context, input model.AddShotInput, style model.MatchUpTrackingStyle) error {
+	for _, rule := range trackingStyleRules[style] {
+		if rule.applies(input) && !rule.present(input) {
+			return internalErrors.NewTrackingStyleFieldRequiredError(rule.field, style.String())
+		}
+	}
+	return nil
+}
+
+// AllowsPointOnly reports whether points can be recorded without their shots.
+// Matchups created before tracking styles were stored have no style and are
+// treated as BEGINNER.
+func AllowsPointOnly(style model.MatchUpTrackingStyle) bool {
+	return style == model.MatchUpTrackingStyleBeginner || style == ""
+}
@@ -0,0 +1,123 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/access"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ access.Checker = (*AccessChecker)(nil)
+
+// AccessChecker is an in-memory access.Checker. Viewers only have access to
+// an owner's data at the levels they have been granted, and users only hold
+// the entity roles granted to them. Like the real checker, which has its own
+// MongoDB client, it can't be used inside a transaction.
+type AccessChecker struct {
+	mu      sync.Mutex
+	granted map[string]bool
+	roles   map[string]map[access.Role]bool
+}
+
+// NewAccessChecker creates a checker that grants nothing yet
+func NewAccessChecker() *AccessChecker {
+	return &AccessChecker{
+		granted: make(map[string]bool),
+		roles:   make(map[string]map[access.Role]bool),
+	}
+}
+
+// ContextWithAccessChecker returns a context carrying the checker, as the
+// server's access control middleware does
+func ContextWithAccessChecker(ctx context.Context, checker access.Checker) context.Context {
+	return context.WithValue(ctx, access.CheckerContextKey, checker)
+}
+
+// outsideTransaction fails the way the driver does when a session is used
+// with another client
+func outsideTransaction(ctx context.Context) error {
+	if ctx.Value(transactionKey{}) != nil {
+		return mongo.ErrWrongClient
+	}
+	return nil
+}
+
+// Grant gives a viewer access to an owner's data at a level
+func (c *AccessChecker) Grant(ownerID, viewerID primitive.ObjectID, level access.AccessLevel) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.granted[ownerID.Hex()+":"+viewerID.Hex()+":"+string(level)] = true
+}
+
+func (c *AccessChecker) CheckAccess(ctx context.Context, ownerID, viewerID string, config access.CheckConfig) (*access.AccessResult, error) {
+	if err := outsideTransaction(ctx); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hasAccess := ownerID == viewerID ||
+		config.RequiredLevel == access.AccessLevelPublic ||
+		c.granted[ownerID+":"+viewerID+":"+string(config.RequiredLevel)]
+	return &access.AccessResult{HasAccess: hasAccess, AccessLevel: config.RequiredLevel}, nil
+}
+
+func (c *AccessChecker) HasRole(ctx context.Context, userID string, entityID string, role access.Role) (bool, error) {
+	if err := outsideTransaction(ctx); err != nil {
+		return false, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.roles[userID+":"+entityID][role], nil
+}
+
+func (c *AccessChecker) GetRoles(ctx context.Context, userID string, entityID string) ([]access.Role, error) {
+	if err := outsideTransaction(ctx); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	roles := []access.Role{}
+	for role := range c.roles[userID+":"+entityID] {
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func (c *AccessChecker) GrantRole(ctx context.Context, userID string, entityID string, role access.Role, grantedBy string) error {
+	if err := outsideTransaction(ctx); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := userID + ":" + entityID
+	if c.roles[key] == nil {
+		c.roles[key] = make(map[access.Role]bool)
+	}
+	c.roles[key][role] = true
+	return nil
+}
+
+func (c *AccessChecker) RevokeRole(ctx context.Context, userID string, entityID string, role access.Role) error {
+	if err := outsideTransaction(ctx); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.roles[userID+":"+entityID], role)
+	return nil
+}
+
+func (c *AccessChecker) ClearCache(userIDs ...string) {}
+
+// RoleCount returns how many entity roles are held. Test helper.
+func (c *AccessChecker) RoleCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count := 0
+	for _, roles := range c.roles {
+		count += len(roles)
+	}
+	return count
+}
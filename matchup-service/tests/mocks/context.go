@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ContextWithMongoID returns a context carrying the given user, built the same
+// way the server does it: through the X-User-Claims middleware.
+func ContextWithMongoID(id primitive.ObjectID) context.Context {
+	var ctx context.Context
+	handler := middleware.WithUserClaims(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header.Set("X-User-Claims", `{"mongoId":"`+id.Hex()+`"}`)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	return ctx
+}
@@ -0,0 +1,800 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	internalErrors "github.com/CourtIQ/courtiq-backend/matchup-service/internal/errors"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/repository"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	_ repository.MatchupsRepository      = (*MatchupsRepository)(nil)
+	_ repository.ShotsRepository         = (*ShotsRepository)(nil)
+	_ repository.FormatPresetsRepository = (*FormatPresetsRepository)(nil)
+	_ repository.GuestClaimsRepository   = (*GuestClaimsRepository)(nil)
+	_ repository.RatingsRepository       = (*RatingsRepository)(nil)
+	_ repository.TournamentsRepository   = (*TournamentsRepository)(nil)
+)
+
+// roundTrip copies a document through BSON so stored values behave like
+// documents read back from MongoDB (and can't be mutated by the caller)
+func roundTrip[T any](in *T) *T {
+	data, err := bson.Marshal(in)
+	if err != nil {
+		panic(err)
+	}
+	var out T
+	if err := bson.Unmarshal(data, &out); err != nil {
+		panic(err)
+	}
+	return &out
+}
+
+// copyDocuments copies stored documents, so a snapshot isn't changed by
+// updates made in place afterwards
+func copyDocuments[T any](documents map[primitive.ObjectID]*T) map[primitive.ObjectID]*T {
+	copied := make(map[primitive.ObjectID]*T, len(documents))
+	for id, document := range documents {
+		copied[id] = roundTrip(document)
+	}
+	return copied
+}
+
+// paginate applies limit/offset to an already ordered slice
+func paginate[T any](items []*T, limit, offset *int) []*T {
+	start := 0
+	if offset != nil && *offset > 0 {
+		start = *offset
+	}
+	if start > len(items) {
+		return []*T{}
+	}
+	items = items[start:]
+	if limit != nil && *limit >= 0 && *limit < len(items) {
+		items = items[:*limit]
+	}
+	return items
+}
+
+// MatchupsRepository is an in-memory implementation of repository.MatchupsRepository
+type MatchupsRepository struct {
+	mu       sync.Mutex
+	matchups map[primitive.ObjectID]*model.MatchUp
+}
+
+// NewMatchupsRepository creates an empty in-memory matchups repository
+func NewMatchupsRepository() *MatchupsRepository {
+	return &MatchupsRepository{matchups: make(map[primitive.ObjectID]*model.MatchUp)}
+}
+
+// Snapshot saves the stored matchups, returning a function that restores them
+func (r *MatchupsRepository) Snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	saved := copyDocuments(r.matchups)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.matchups = saved
+	}
+}
+
+func (r *MatchupsRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*model.MatchUp, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matchup, ok := r.matchups[id]
+	if !ok {
+		return nil, sharedErrors.ErrNotFound
+	}
+	return roundTrip(matchup), nil
+}
+
+func (r *MatchupsRepository) Insert(ctx context.Context, matchup *model.MatchUp) (*model.MatchUp, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if matchup.ID == primitive.NilObjectID {
+		matchup.ID = primitive.NewObjectID()
+	}
+	r.matchups[matchup.ID] = roundTrip(matchup)
+	return matchup, nil
+}
+
+func (r *MatchupsRepository) Update(ctx context.Context, matchup *model.MatchUp) (*model.MatchUp, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.matchups[matchup.ID]
+	if !ok {
+		return nil, sharedErrors.ErrNotFound
+	}
+	if stored.Version != matchup.Version {
+		return nil, internalErrors.NewMatchUpModifiedError()
+	}
+	matchup.Version++
+	r.matchups[matchup.ID] = roundTrip(matchup)
+	return roundTrip(matchup), nil
+}
+
+func (r *MatchupsRepository) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.matchups[id]; !ok {
+		return false, sharedErrors.ErrNotFound
+	}
+	delete(r.matchups, id)
+	return true, nil
+}
+
+func (r *MatchupsRepository) GetMatchups(ctx context.Context, limit, offset *int) ([]*model.MatchUp, error) {
+	return paginate(r.all(), limit, offset), nil
+}
+
+func (r *MatchupsRepository) GetMatchupsByTeam(ctx context.Context, teamID primitive.ObjectID, limit, offset *int) ([]*model.MatchUp, error) {
+	var matchups []*model.MatchUp
+	for _, matchup := range r.all() {
+		for _, participant := range matchup.Participants {
+			if participant.ID == teamID {
+				matchups = append(matchups, matchup)
+				break
+			}
+		}
+	}
+	return paginate(matchups, limit, offset), nil
+}
+
+func (r *MatchupsRepository) GetMyMatchupsByStatus(ctx context.Context, userID primitive.ObjectID, status model.MatchUpStatus, limit, offset *int) ([]*model.MatchUp, error) {
+	filter := &model.MatchUpFilterInput{Status: []model.MatchUpStatus{status}}
+	return r.FindByParticipant(ctx, userID, filter, limit, offset)
+}
+
+func (r *MatchupsRepository) FindByParticipant(ctx context.Context, participantID primitive.ObjectID, filter *model.MatchUpFilterInput, limit, offset *int) ([]*model.MatchUp, error) {
+	var matchups []*model.MatchUp
+	for _, matchup := range r.all() {
+		if matchesParticipantFilter(matchup, participantID, filter) {
+			matchups = append(matchups, matchup)
+		}
+	}
+
+	// Most recently started first, unstarted matchups last
+	sort.SliceStable(matchups, func(i, j int) bool {
+		a, b := matchups[i].StartTime, matchups[j].StartTime
+		switch {
+		case a == nil && b == nil:
+			return matchups[i].ID.Hex() > matchups[j].ID.Hex()
+		case a == nil || b == nil:
+			return b == nil
+		case a.Equal(*b):
+			return matchups[i].ID.Hex() > matchups[j].ID.Hex()
+		default:
+			return a.After(*b)
+		}
+	})
+	return paginate(matchups, limit, offset), nil
+}
+
+func (r *MatchupsRepository) FindDecided(ctx context.Context) ([]*model.MatchUp, error) {
+	var matchups []*model.MatchUp
+	for _, matchup := range r.all() {
+		if matchup.MatchUpStatus == model.MatchUpStatusCompleted || matchup.MatchUpStatus == model.MatchUpStatusRetired {
+			matchups = append(matchups, matchup)
+		}
+	}
+
+	// Oldest end first
+	sort.SliceStable(matchups, func(i, j int) bool {
+		a, b := matchups[i].EndTime, matchups[j].EndTime
+		switch {
+		case a == nil || b == nil:
+			return a == nil && b != nil
+		default:
+			return a.Before(*b)
+		}
+	})
+	return matchups, nil
+}
+
+func (r *MatchupsRepository) FindByInvitee(ctx context.Context, userID primitive.ObjectID, status *model.InvitationStatus, limit, offset *int) ([]*model.MatchUp, error) {
+	var matchups []*model.MatchUp
+	for _, matchup := range r.all() {
+		if matchup.MatchUpStatus != model.MatchUpStatusRequested && matchup.MatchUpStatus != model.MatchUpStatusScheduled {
+			continue
+		}
+		for _, participant := range matchup.Participants {
+			invited := participant.ID == userID && participant.InvitationStatus != nil
+			if invited && (status == nil || *participant.InvitationStatus == *status) {
+				matchups = append(matchups, matchup)
+				break
+			}
+		}
+	}
+
+	// Newest first
+	sort.SliceStable(matchups, func(i, j int) bool {
+		if !matchups[i].CreatedAt.Equal(matchups[j].CreatedAt) {
+			return matchups[i].CreatedAt.After(matchups[j].CreatedAt)
+		}
+		return matchups[i].ID.Hex() > matchups[j].ID.Hex()
+	})
+	return paginate(matchups, limit, offset), nil
+}
+
+// matchesParticipantFilter mirrors the query built by the Mongo repository
+func matchesParticipantFilter(matchup *model.MatchUp, participantID primitive.ObjectID, filter *model.MatchUpFilterInput) bool {
+	sides := make(map[primitive.ObjectID]model.TeamSide)
+	for _, participant := range matchup.Participants {
+		sides[participant.ID] = participant.TeamSide
+	}
+	side, ok := sides[participantID]
+	if !ok {
+		return false
+	}
+	if filter == nil {
+		return true
+	}
+
+	if len(filter.Status) > 0 {
+		found := false
+		for _, status := range filter.Status {
+			found = found || matchup.MatchUpStatus == status
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.MatchUpType != nil && matchup.MatchUpType != *filter.MatchUpType {
+		return false
+	}
+	if filter.ParticipantID != nil {
+		if _, ok := sides[*filter.ParticipantID]; !ok {
+			return false
+		}
+	}
+	if filter.StartTimeFrom != nil && (matchup.StartTime == nil || matchup.StartTime.Before(*filter.StartTimeFrom)) {
+		return false
+	}
+	if filter.StartTimeTo != nil && (matchup.StartTime == nil || matchup.StartTime.After(*filter.StartTimeTo)) {
+		return false
+	}
+	if filter.OpponentID != nil {
+		opponentSide, ok := sides[*filter.OpponentID]
+		if !ok || opponentSide == side {
+			return false
+		}
+	}
+	if filter.Outcome != nil {
+		if matchup.Winner == nil {
+			return false
+		}
+		won := *matchup.Winner == side
+		if won != (*filter.Outcome == model.MatchUpOutcomeWon) {
+			return false
+		}
+	}
+	return true
+}
+
+// all returns copies of every stored matchup in creation order
+func (r *MatchupsRepository) all() []*model.MatchUp {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matchups := make([]*model.MatchUp, 0, len(r.matchups))
+	for _, matchup := range r.matchups {
+		matchups = append(matchups, roundTrip(matchup))
+	}
+	sort.Slice(matchups, func(i, j int) bool {
+		return matchups[i].ID.Hex() < matchups[j].ID.Hex()
+	})
+	return matchups
+}
+
+// ShotsRepository is an in-memory implementation of repository.ShotsRepository
+type ShotsRepository struct {
+	mu    sync.Mutex
+	shots map[primitive.ObjectID]*model.MatchUpShot
+}
+
+// NewShotsRepository creates an empty in-memory shots repository
+func NewShotsRepository() *ShotsRepository {
+	return &ShotsRepository{shots: make(map[primitive.ObjectID]*model.MatchUpShot)}
+}
+
+// Snapshot saves the stored shots, returning a function that restores them
+func (r *ShotsRepository) Snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	saved := copyDocuments(r.shots)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.shots = saved
+	}
+}
+
+func (r *ShotsRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*model.MatchUpShot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shot, ok := r.shots[id]
+	if !ok {
+		return nil, sharedErrors.ErrNotFound
+	}
+	return roundTrip(shot), nil
+}
+
+func (r *ShotsRepository) FindByMatchUpID(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.MatchUpShot, error) {
+	var shots []*model.MatchUpShot
+	for _, shot := range r.all() {
+		if shot.MatchUpID == matchUpID {
+			shots = append(shots, shot)
+		}
+	}
+	return shots, nil
+}
+
+func (r *ShotsRepository) FindShotsByGame(ctx context.Context, matchUpID primitive.ObjectID, setNumber, gameNumber int) ([]*model.MatchUpShot, error) {
+	var shots []*model.MatchUpShot
+	for _, shot := range r.all() {
+		if shot.MatchUpID == matchUpID &&
+			shot.PointContext.SetNumber == setNumber &&
+			shot.PointContext.GameNumber == gameNumber {
+			shots = append(shots, shot)
+		}
+	}
+	return shots, nil
+}
+
+func (r *ShotsRepository) FindLastShot(ctx context.Context, matchUpID primitive.ObjectID) (*model.MatchUpShot, error) {
+	shots, _ := r.FindByMatchUpID(ctx, matchUpID)
+	if len(shots) == 0 {
+		return nil, sharedErrors.ErrNotFound
+	}
+	return shots[len(shots)-1], nil
+}
+
+func (r *ShotsRepository) FindByClientShotID(ctx context.Context, matchUpID primitive.ObjectID, clientShotID string) (*model.MatchUpShot, error) {
+	for _, shot := range r.all() {
+		if shot.MatchUpID == matchUpID && shot.ClientShotID != nil && *shot.ClientShotID == clientShotID {
+			return shot, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *ShotsRepository) Insert(ctx context.Context, shot *model.MatchUpShot) (*model.MatchUpShot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if shot.ID == primitive.NilObjectID {
+		shot.ID = primitive.NewObjectID()
+	}
+	r.shots[shot.ID] = roundTrip(shot)
+	return shot, nil
+}
+
+func (r *ShotsRepository) Update(ctx context.Context, shot *model.MatchUpShot) (*model.MatchUpShot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.shots[shot.ID]; !ok {
+		return nil, sharedErrors.ErrNotFound
+	}
+	r.shots[shot.ID] = roundTrip(shot)
+	return roundTrip(shot), nil
+}
+
+func (r *ShotsRepository) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.shots[id]; !ok {
+		return false, sharedErrors.ErrNotFound
+	}
+	delete(r.shots, id)
+	return true, nil
+}
+
+func (r *ShotsRepository) DeleteByIDs(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for _, id := range ids {
+		if _, ok := r.shots[id]; ok {
+			delete(r.shots, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *ShotsRepository) ReassignPlayer(ctx context.Context, matchUpID, fromID, toID primitive.ObjectID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var modified int64
+	for _, shot := range r.shots {
+		if shot.MatchUpID != matchUpID {
+			continue
+		}
+		if shot.HitterID == fromID {
+			shot.HitterID = toID
+			modified++
+		}
+		if shot.PointContext != nil && shot.PointContext.ServerID == fromID {
+			shot.PointContext.ServerID = toID
+			modified++
+		}
+		if state := shot.MatchStateAfterShot; state != nil {
+			if state.CurrentServer == fromID {
+				state.CurrentServer = toID
+				modified++
+			}
+			for i, player := range state.ServingOrder {
+				if player == fromID {
+					state.ServingOrder[i] = toID
+					modified++
+				}
+			}
+		}
+	}
+	return modified, nil
+}
+
+// Count returns how many shots are stored, including undone ones
+func (r *ShotsRepository) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.shots)
+}
+
+// all returns copies of every stored shot ordered by timestamp
+func (r *ShotsRepository) all() []*model.MatchUpShot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shots := make([]*model.MatchUpShot, 0, len(r.shots))
+	for _, shot := range r.shots {
+		shots = append(shots, roundTrip(shot))
+	}
+	sort.SliceStable(shots, func(i, j int) bool {
+		if shots[i].Timestamp.Equal(shots[j].Timestamp) {
+			return shots[i].ID.Hex() < shots[j].ID.Hex()
+		}
+		return shots[i].Timestamp.Before(shots[j].Timestamp)
+	})
+	return shots
+}
+
+// FormatPresetsRepository is an in-memory implementation of repository.FormatPresetsRepository
+type FormatPresetsRepository struct {
+	mu      sync.Mutex
+	presets map[primitive.ObjectID]*model.MatchUpFormatPreset
+}
+
+// NewFormatPresetsRepository creates an empty in-memory format presets repository
+func NewFormatPresetsRepository() *FormatPresetsRepository {
+	return &FormatPresetsRepository{presets: make(map[primitive.ObjectID]*model.MatchUpFormatPreset)}
+}
+
+// Snapshot saves the stored presets, returning a function that restores them
+func (r *FormatPresetsRepository) Snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	saved := copyDocuments(r.presets)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.presets = saved
+	}
+}
+
+func (r *FormatPresetsRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*model.MatchUpFormatPreset, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	preset, ok := r.presets[id]
+	if !ok {
+		return nil, sharedErrors.ErrNotFound
+	}
+	return roundTrip(preset), nil
+}
+
+func (r *FormatPresetsRepository) FindByOwner(ctx context.Context, ownerID primitive.ObjectID, limit, offset *int) ([]*model.MatchUpFormatPreset, error) {
+	r.mu.Lock()
+	var presets []*model.MatchUpFormatPreset
+	for _, preset := range r.presets {
+		if preset.Owner != nil && *preset.Owner == ownerID {
+			presets = append(presets, roundTrip(preset))
+		}
+	}
+	r.mu.Unlock()
+
+	// Newest first
+	sort.Slice(presets, func(i, j int) bool {
+		if presets[i].CreatedAt.Equal(presets[j].CreatedAt) {
+			return presets[i].ID.Hex() > presets[j].ID.Hex()
+		}
+		return presets[i].CreatedAt.After(presets[j].CreatedAt)
+	})
+	return paginate(presets, limit, offset), nil
+}
+
+func (r *FormatPresetsRepository) Insert(ctx context.Context, preset *model.MatchUpFormatPreset) (*model.MatchUpFormatPreset, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if preset.ID == primitive.NilObjectID {
+		preset.ID = primitive.NewObjectID()
+	}
+	r.presets[preset.ID] = roundTrip(preset)
+	return preset, nil
+}
+
+func (r *FormatPresetsRepository) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.presets[id]; !ok {
+		return false, sharedErrors.ErrNotFound
+	}
+	delete(r.presets, id)
+	return true, nil
+}
+
+// GuestClaimsRepository is an in-memory implementation of repository.GuestClaimsRepository
+type GuestClaimsRepository struct {
+	mu     sync.Mutex
+	claims map[primitive.ObjectID]*model.GuestClaim
+}
+
+// NewGuestClaimsRepository creates an empty in-memory guest claims repository
+func NewGuestClaimsRepository() *GuestClaimsRepository {
+	return &GuestClaimsRepository{claims: make(map[primitive.ObjectID]*model.GuestClaim)}
+}
+
+// Snapshot saves the stored claims, returning a function that restores them
+func (r *GuestClaimsRepository) Snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	saved := copyDocuments(r.claims)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.claims = saved
+	}
+}
+
+func (r *GuestClaimsRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*model.GuestClaim, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	claim, ok := r.claims[id]
+	if !ok {
+		return nil, sharedErrors.ErrNotFound
+	}
+	return roundTrip(claim), nil
+}
+
+func (r *GuestClaimsRepository) FindPendingByGuest(ctx context.Context, guestID primitive.ObjectID) (*model.GuestClaim, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, claim := range r.claims {
+		if claim.GuestID == guestID && claim.Status == model.GuestClaimStatusPending {
+			return roundTrip(claim), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *GuestClaimsRepository) FindByClaimant(ctx context.Context, claimantID primitive.ObjectID, status *model.GuestClaimStatus, limit, offset *int) ([]*model.GuestClaim, error) {
+	r.mu.Lock()
+	var claims []*model.GuestClaim
+	for _, claim := range r.claims {
+		if claim.Claimant == claimantID && (status == nil || claim.Status == *status) {
+			claims = append(claims, roundTrip(claim))
+		}
+	}
+	r.mu.Unlock()
+
+	// Newest first
+	sort.Slice(claims, func(i, j int) bool {
+		if claims[i].CreatedAt.Equal(claims[j].CreatedAt) {
+			return claims[i].ID.Hex() > claims[j].ID.Hex()
+		}
+		return claims[i].CreatedAt.After(claims[j].CreatedAt)
+	})
+	return paginate(claims, limit, offset), nil
+}
+
+func (r *GuestClaimsRepository) Insert(ctx context.Context, claim *model.GuestClaim) (*model.GuestClaim, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if claim.ID == primitive.NilObjectID {
+		claim.ID = primitive.NewObjectID()
+	}
+	r.claims[claim.ID] = roundTrip(claim)
+	return claim, nil
+}
+
+func (r *GuestClaimsRepository) Update(ctx context.Context, claim *model.GuestClaim) (*model.GuestClaim, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.claims[claim.ID]; !ok {
+		return nil, sharedErrors.ErrNotFound
+	}
+	r.claims[claim.ID] = roundTrip(claim)
+	return roundTrip(claim), nil
+}
+
+// RatingsRepository is an in-memory implementation of repository.RatingsRepository
+type RatingsRepository struct {
+	mu      sync.Mutex
+	changes []*model.RatingChange
+}
+
+// NewRatingsRepository creates an empty in-memory ratings repository
+func NewRatingsRepository() *RatingsRepository {
+	return &RatingsRepository{}
+}
+
+// Snapshot saves the rating changes, returning a function that restores them
+func (r *RatingsRepository) Snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	changes := make([]*model.RatingChange, len(r.changes))
+	for i, change := range r.changes {
+		changes[i] = roundTrip(change)
+	}
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.changes = changes
+	}
+}
+
+// newestFirst returns copies of the changes matching keep, from the latest
+// back. Changes made at the same time keep their insertion order reversed.
+func (r *RatingsRepository) newestFirst(keep func(*model.RatingChange) bool) []*model.RatingChange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var changes []*model.RatingChange
+	for i := len(r.changes) - 1; i >= 0; i-- {
+		if keep(r.changes[i]) {
+			changes = append(changes, roundTrip(r.changes[i]))
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].CreatedAt.After(changes[j].CreatedAt)
+	})
+	return changes
+}
+
+func (r *RatingsRepository) FindLatest(ctx context.Context, userID primitive.ObjectID) (*model.RatingChange, error) {
+	changes := r.newestFirst(func(change *model.RatingChange) bool { return change.UserID == userID })
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return changes[0], nil
+}
+
+func (r *RatingsRepository) FindByMatchUp(ctx context.Context, matchUpID primitive.ObjectID) ([]*model.RatingChange, error) {
+	return r.newestFirst(func(change *model.RatingChange) bool { return change.MatchUpID == matchUpID }), nil
+}
+
+func (r *RatingsRepository) FindByUser(ctx context.Context, userID primitive.ObjectID, limit, offset *int) ([]*model.RatingChange, error) {
+	changes := r.newestFirst(func(change *model.RatingChange) bool { return change.UserID == userID })
+	return paginate(changes, limit, offset), nil
+}
+
+func (r *RatingsRepository) InsertMany(ctx context.Context, changes []*model.RatingChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, change := range changes {
+		if change.ID == primitive.NilObjectID {
+			change.ID = primitive.NewObjectID()
+		}
+		r.changes = append(r.changes, roundTrip(change))
+	}
+	return nil
+}
+
+func (r *RatingsRepository) DeleteByMatchUp(ctx context.Context, matchUpID primitive.ObjectID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.changes[:0]
+	for _, change := range r.changes {
+		if change.MatchUpID != matchUpID {
+			kept = append(kept, change)
+		}
+	}
+	deleted := int64(len(r.changes) - len(kept))
+	r.changes = kept
+	return deleted, nil
+}
+
+func (r *RatingsRepository) DeleteAll(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deleted := int64(len(r.changes))
+	r.changes = nil
+	return deleted, nil
+}
+
+// TournamentsRepository is an in-memory implementation of repository.TournamentsRepository
+type TournamentsRepository struct {
+	mu          sync.Mutex
+	tournaments map[primitive.ObjectID]*model.Tournament
+}
+
+// NewTournamentsRepository creates an empty in-memory tournaments repository
+func NewTournamentsRepository() *TournamentsRepository {
+	return &TournamentsRepository{tournaments: make(map[primitive.ObjectID]*model.Tournament)}
+}
+
+// Snapshot saves the stored tournaments, returning a function that restores them
+func (r *TournamentsRepository) Snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	saved := copyDocuments(r.tournaments)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.tournaments = saved
+	}
+}
+
+func (r *TournamentsRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Tournament, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tournament, ok := r.tournaments[id]
+	if !ok {
+		return nil, sharedErrors.ErrNotFound
+	}
+	return roundTrip(tournament), nil
+}
+
+func (r *TournamentsRepository) FindByUser(ctx context.Context, userID primitive.ObjectID, limit, offset *int) ([]*model.Tournament, error) {
+	r.mu.Lock()
+	var tournaments []*model.Tournament
+	for _, tournament := range r.tournaments {
+		if tournament.Owner == userID || entered(tournament, userID) {
+			tournaments = append(tournaments, roundTrip(tournament))
+		}
+	}
+	r.mu.Unlock()
+
+	// Newest first
+	sort.Slice(tournaments, func(i, j int) bool {
+		if tournaments[i].CreatedAt.Equal(tournaments[j].CreatedAt) {
+			return tournaments[i].ID.Hex() > tournaments[j].ID.Hex()
+		}
+		return tournaments[i].CreatedAt.After(tournaments[j].CreatedAt)
+	})
+	return paginate(tournaments, limit, offset), nil
+}
+
+// entered reports whether a user plays for one of a tournament's entries
+func entered(tournament *model.Tournament, userID primitive.ObjectID) bool {
+	for _, entry := range tournament.Entries {
+		for _, player := range entry.Players {
+			if player.ID == userID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *TournamentsRepository) Insert(ctx context.Context, tournament *model.Tournament) (*model.Tournament, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if tournament.ID == primitive.NilObjectID {
+		tournament.ID = primitive.NewObjectID()
+	}
+	r.tournaments[tournament.ID] = roundTrip(tournament)
+	return tournament, nil
+}
+
+func (r *TournamentsRepository) Update(ctx context.Context, tournament *model.Tournament) (*model.Tournament, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tournaments[tournament.ID]; !ok {
+		return nil, sharedErrors.ErrNotFound
+	}
+	r.tournaments[tournament.ID] = roundTrip(tournament)
+	return roundTrip(tournament), nil
+}
@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/repository"
+)
+
+var _ repository.Transactor = (*Transactor)(nil)
+
+type transactionKey struct{}
+
+// Snapshotter is an in-memory repository a failed transaction rolls back
+type Snapshotter interface {
+	// Snapshot saves the repository's contents, returning a function that
+	// restores them
+	Snapshot() func()
+}
+
+// Transactor runs units of work one at a time, standing in for MongoDB
+// transactions. When a unit of work fails, the repositories it was given are
+// rolled back to where they were when it started.
+type Transactor struct {
+	mu      sync.Mutex
+	countMu sync.Mutex
+	count   int
+	repos   []Snapshotter
+}
+
+// NewTransactor creates a transactor with no transactions run yet, rolling
+// back the given repositories
+func NewTransactor(repos ...Snapshotter) *Transactor {
+	return &Transactor{repos: repos}
+}
+
+func (t *Transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested calls join the open transaction, like the real one
+	if ctx.Value(transactionKey{}) != nil {
+		return fn(ctx)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.countMu.Lock()
+	t.count++
+	t.countMu.Unlock()
+
+	restores := make([]func(), len(t.repos))
+	for i, repo := range t.repos {
+		restores[i] = repo.Snapshot()
+	}
+	if err := fn(context.WithValue(ctx, transactionKey{}, true)); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	return nil
+}
+
+// Count returns how many transactions have been run. Test helper.
+func (t *Transactor) Count() int {
+	t.countMu.Lock()
+	defer t.countMu.Unlock()
+	return t.count
+}
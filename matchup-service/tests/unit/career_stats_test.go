@@ -0,0 +1,136 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// shortSetFormat is a single set of one game with a tiebreak at 1-1, so a
+// side that takes every point wins it 2-0
+func shortSetFormat() *model.MatchUpFormatInput {
+	return &model.MatchUpFormatInput{
+		NumberOfSets: 1,
+		SetFormat: &model.SetFormatInput{
+			NumberOfGames: 1,
+			DeuceType:     model.DeuceTypeNormalDeuce,
+			TiebreakFormat: &model.TiebreakFormatInput{
+				Points:     7,
+				TiebreakAt: 1,
+			},
+		},
+	}
+}
+
+// matchTiebreakFormat is a single set played as a first to five tiebreak
+func matchTiebreakFormat() *model.MatchUpFormatInput {
+	return &model.MatchUpFormatInput{
+		NumberOfSets: 1,
+		SetFormat: &model.SetFormatInput{
+			NumberOfGames: 1,
+			DeuceType:     model.DeuceTypeNormalDeuce,
+			TiebreakFormat: &model.TiebreakFormatInput{
+				Points:     5,
+				TiebreakAt: 0,
+			},
+		},
+	}
+}
+
+// playMatch plays a singles match between player A and an opponent until the
+// winner has taken every point
+func (f *fixture) playMatch(t *testing.T, opponent primitive.ObjectID, format *model.MatchUpFormatInput, server, winner primitive.ObjectID) {
+	t.Helper()
+	matchUp, err := f.service.InitiateMatchUp(f.ctx, model.InitiateMatchUpInput{
+		MatchUpType:   model.MatchUpTypeSingles,
+		MatchUpFormat: format,
+		Participants: []*model.ParticipantInput{
+			{ID: &f.playerA, DisplayedName: "Player A", TeamSide: model.TeamSideTeamA},
+			{ID: &opponent, DisplayedName: "Opponent", TeamSide: model.TeamSideTeamB},
+		},
+		MatchUpTracker: f.playerA,
+		InitialServer:  server,
+	})
+	require.NoError(t, err)
+	f.matchUp = matchUp
+	f.acceptInvitations(t)
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+
+	for f.reload(t).MatchUpStatus == model.MatchUpStatusInProgress {
+		f.winPoint(t, winner)
+	}
+}
+
+func TestCareerStats(t *testing.T) {
+	f := newFixture(t)
+	playerC, playerD := primitive.NewObjectID(), primitive.NewObjectID()
+
+	// Each match is won on every point: by A against B and C, each serving a
+	// game, then by B and by D in a match tiebreak
+	f.playMatch(t, f.playerB, shortSetFormat(), f.playerA, f.playerA)
+	f.playMatch(t, playerC, shortSetFormat(), playerC, f.playerA)
+	f.playMatch(t, f.playerB, shortSetFormat(), f.playerA, f.playerB)
+	f.playMatch(t, playerD, matchTiebreakFormat(), playerD, playerD)
+
+	stats, err := f.service.GetCareerStats(f.ctx, f.playerA, nil)
+	require.NoError(t, err)
+
+	// The fixture's own match is still in progress and doesn't count
+	assert.Equal(t, 4, stats.MatchesPlayed)
+	assert.Equal(t, 2, stats.MatchesWon)
+	assert.Equal(t, 2, stats.MatchesLost)
+	require.Len(t, stats.Records, 1)
+	assert.Equal(t, model.MatchUpTypeRecord{MatchUpType: model.MatchUpTypeSingles, Played: 4, Won: 2, Lost: 2}, *stats.Records[0])
+	assert.Equal(t, 2, stats.SetsWon)
+	assert.Equal(t, 2, stats.SetsLost)
+	assert.Equal(t, 4, stats.GamesWon)
+	assert.Equal(t, 0, stats.TiebreaksWon)
+	assert.Equal(t, 1, stats.TiebreaksLost)
+	assert.Equal(t, 8, stats.Aces)
+	// A love game on the opponent's serve reaches one break point
+	assert.Equal(t, 2, stats.BreakPointOpportunities)
+	assert.Equal(t, 2, stats.BreakPointsConverted)
+	require.NotNil(t, stats.BreakPointConversion)
+	assert.Equal(t, 100.0, *stats.BreakPointConversion)
+
+	// Against B: four aces and four double faults in eight service points
+	opponent := f.playerB
+	stats, err = f.service.GetCareerStats(f.ctx, f.playerA, &model.CareerStatsFilterInput{OpponentID: &opponent})
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.MatchesPlayed)
+	assert.Equal(t, 2, stats.GamesWon)
+	assert.Equal(t, 2, stats.GamesLost)
+	assert.Equal(t, 4, stats.Aces)
+	assert.Equal(t, 4, stats.DoubleFaults)
+	require.NotNil(t, stats.AceRate)
+	assert.Equal(t, 50.0, *stats.AceRate)
+	assert.Equal(t, 50.0, *stats.DoubleFaultRate)
+
+	// The opponent's view of the same matches
+	stats, err = f.service.GetCareerStats(f.ctx, f.playerB, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.MatchesWon)
+	assert.Equal(t, 1, stats.MatchesLost)
+	assert.Equal(t, 1, stats.BreakPointsConverted)
+}
+
+func TestCareerStatsDateRange(t *testing.T) {
+	f := newFixture(t)
+	f.playMatch(t, f.playerB, shortSetFormat(), f.playerA, f.playerA)
+
+	from := time.Now().Add(time.Hour)
+	stats, err := f.service.GetCareerStats(f.ctx, f.playerA, &model.CareerStatsFilterInput{StartTimeFrom: &from})
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.MatchesPlayed)
+	assert.Empty(t, stats.Records)
+	assert.Nil(t, stats.AceRate)
+
+	to := time.Now().Add(-time.Hour)
+	_, err = f.service.GetCareerStats(f.ctx, f.playerA, &model.CareerStatsFilterInput{StartTimeFrom: &from, StartTimeTo: &to})
+	assert.Error(t, err)
+}
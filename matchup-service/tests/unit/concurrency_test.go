@@ -0,0 +1,85 @@
+package unit
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchUpVersionIncrementsOnUpdate(t *testing.T) {
+	f := newFixture(t)
+	before := f.reload(t).Version
+
+	f.ace(t, f.playerA)
+	assert.Equal(t, before+1, f.reload(t).Version)
+
+	_, err := f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, before+2, f.reload(t).Version)
+}
+
+func TestStaleMatchUpUpdateIsRejected(t *testing.T) {
+	f := newFixture(t)
+	stale := f.reload(t)
+
+	// Another tracker records a point in the meantime
+	f.ace(t, f.playerA)
+
+	stale.LastUpdated = stale.LastUpdated.Add(1)
+	_, err := f.matchups.Update(f.ctx, stale)
+	require.Error(t, err)
+	assert.True(t, sharedErrors.IsConflictError(err))
+	assert.Equal(t, model.InGameScoreFifteen, inGameScore(f.reload(t), model.TeamSideTeamA))
+}
+
+func TestShotWritesRunInTransactions(t *testing.T) {
+	f := newFixture(t)
+	before := f.transactions.Count()
+
+	f.ace(t, f.playerA)
+	_, err := f.point(model.TeamSideTeamA, nil, nil)
+	require.NoError(t, err)
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	_, err = f.service.RedoShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, before+4, f.transactions.Count())
+}
+
+func TestConcurrentTrackersKeepShotChainIntact(t *testing.T) {
+	f := newFixture(t)
+
+	// Two trackers record an ace each at the same time
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = f.service.AddShot(f.ctx, model.AddShotInput{
+				MatchUpID:   f.matchUp.ID,
+				HitterID:    f.playerA,
+				ShotType:    model.ShotTypeServe,
+				ShotOutcome: model.ShotOutcomeWonPoint,
+			})
+		}()
+	}
+	wg.Wait()
+
+	matchUp := f.reload(t)
+	first, err := f.shots.FindByID(f.ctx, *matchUp.FirstShot)
+	require.NoError(t, err)
+	require.NotNil(t, first.NextShotID)
+	second, err := f.shots.FindByID(f.ctx, *first.NextShotID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, f.shots.Count())
+	assert.Equal(t, first.ID, *second.PrevShotID)
+	assert.Nil(t, second.NextShotID)
+	assert.Equal(t, second.ID, *matchUp.LastShot)
+	assert.Equal(t, model.InGameScoreThirty, inGameScore(matchUp, model.TeamSideTeamA))
+}
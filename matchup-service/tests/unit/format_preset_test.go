@@ -0,0 +1,172 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/formats"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// builtInID returns the ID of the built-in preset with the given name
+func builtInID(t *testing.T, name string) primitive.ObjectID {
+	t.Helper()
+	for _, preset := range formats.BuiltInPresets() {
+		if preset.Name == name {
+			return preset.ID
+		}
+	}
+	t.Fatalf("no built-in preset named %q", name)
+	return primitive.NilObjectID
+}
+
+// savePreset stores a preset for the fixture's owner
+func (f *fixture) savePreset(t *testing.T, name string) *model.MatchUpFormatPreset {
+	t.Helper()
+	preset, err := f.service.CreateMatchUpFormatPreset(f.ctx, model.CreateMatchUpFormatPresetInput{
+		Name:          name,
+		MatchUpFormat: standardFormat(),
+	})
+	require.NoError(t, err)
+	return preset
+}
+
+// initiateFromPreset starts a singles matchup against player B using a preset
+func (f *fixture) initiateFromPreset(t *testing.T, presetID primitive.ObjectID) (*model.MatchUp, error) {
+	t.Helper()
+	return f.service.InitiateMatchUp(f.ctx, model.InitiateMatchUpInput{
+		MatchUpType:           model.MatchUpTypeSingles,
+		MatchUpFormatPresetID: &presetID,
+		Participants: []*model.ParticipantInput{
+			{ID: &f.playerA, DisplayedName: "Player A", TeamSide: model.TeamSideTeamA},
+			{ID: &f.playerB, DisplayedName: "Player B", TeamSide: model.TeamSideTeamB},
+		},
+		MatchUpTracker: f.playerA,
+		InitialServer:  f.playerA,
+	})
+}
+
+func TestFormatPresetsListBuiltInsFirst(t *testing.T) {
+	f := newFixture(t)
+	builtIns := len(formats.BuiltInPresets())
+
+	saved := f.savePreset(t, "Club doubles")
+	assert.Equal(t, f.playerA, *saved.Owner)
+	assert.False(t, saved.IsBuiltIn)
+
+	// Presets saved by someone else are not listed
+	other := *f
+	other.ctx = mocks.ContextWithMongoID(primitive.NewObjectID())
+	other.savePreset(t, "Someone else's")
+
+	presets, err := f.service.GetMatchUpFormatPresets(f.ctx, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, presets, builtIns+1)
+	assert.True(t, presets[0].IsBuiltIn)
+	assert.Equal(t, saved.ID, presets[builtIns].ID)
+
+	// A page can span the built-ins and the saved presets
+	limit, offset := 2, builtIns-1
+	presets, err = f.service.GetMatchUpFormatPresets(f.ctx, &limit, &offset)
+	require.NoError(t, err)
+	require.Len(t, presets, 2)
+	assert.True(t, presets[0].IsBuiltIn)
+	assert.Equal(t, saved.ID, presets[1].ID)
+}
+
+func TestCreateFormatPresetValidatesFormat(t *testing.T) {
+	f := newFixture(t)
+
+	_, err := f.service.CreateMatchUpFormatPreset(f.ctx, model.CreateMatchUpFormatPresetInput{
+		Name:          " ",
+		MatchUpFormat: standardFormat(),
+	})
+	assert.Error(t, err)
+
+	format := standardFormat()
+	format.SetFormat.TiebreakFormat = nil
+	_, err = f.service.CreateMatchUpFormatPreset(f.ctx, model.CreateMatchUpFormatPresetInput{
+		Name:          "No tiebreak",
+		MatchUpFormat: format,
+	})
+	assert.Error(t, err)
+}
+
+func TestInitiateMatchUpFromBuiltInPreset(t *testing.T) {
+	f := newFixture(t)
+
+	matchUp, err := f.initiateFromPreset(t, builtInID(t, "Fast4"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, matchUp.MatchUpFormat.SetFormat.NumberOfGames)
+	assert.Equal(t, model.DeuceTypeSuddenDeath, matchUp.MatchUpFormat.SetFormat.DeuceType)
+
+	// Two sets of four games decide a Fast4 match
+	f.matchUp = matchUp
+	f.acceptInvitations(t)
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+	for i := 0; i < 32; i++ {
+		f.winPoint(t, f.playerA)
+	}
+	assert.Equal(t, model.MatchUpStatusCompleted, f.reload(t).MatchUpStatus)
+}
+
+func TestInitiateMatchUpFromSavedPreset(t *testing.T) {
+	f := newFixture(t)
+	saved := f.savePreset(t, "Club singles")
+
+	matchUp, err := f.initiateFromPreset(t, saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saved.MatchUpFormat, matchUp.MatchUpFormat)
+
+	// Another user can't start a match from it
+	other := *f
+	other.ctx = mocks.ContextWithMongoID(f.playerB)
+	_, err = other.initiateFromPreset(t, saved.ID)
+	assert.Error(t, err)
+}
+
+func TestInitiateMatchUpRequiresOneFormatSource(t *testing.T) {
+	f := newFixture(t)
+	presetID := builtInID(t, "8-game pro set")
+
+	input := model.InitiateMatchUpInput{
+		MatchUpType: model.MatchUpTypeSingles,
+		Participants: []*model.ParticipantInput{
+			{ID: &f.playerA, DisplayedName: "Player A", TeamSide: model.TeamSideTeamA},
+			{ID: &f.playerB, DisplayedName: "Player B", TeamSide: model.TeamSideTeamB},
+		},
+		MatchUpTracker: f.playerA,
+		InitialServer:  f.playerA,
+	}
+	_, err := f.service.InitiateMatchUp(f.ctx, input)
+	assert.Error(t, err)
+
+	input.MatchUpFormat = standardFormat()
+	input.MatchUpFormatPresetID = &presetID
+	_, err = f.service.InitiateMatchUp(f.ctx, input)
+	assert.Error(t, err)
+}
+
+func TestDeleteFormatPreset(t *testing.T) {
+	f := newFixture(t)
+	saved := f.savePreset(t, "Club singles")
+
+	_, err := f.service.DeleteMatchUpFormatPreset(f.ctx, builtInID(t, "Fast4"))
+	assert.Error(t, err)
+
+	other := *f
+	other.ctx = mocks.ContextWithMongoID(f.playerB)
+	_, err = other.service.DeleteMatchUpFormatPreset(other.ctx, saved.ID)
+	assert.Error(t, err)
+
+	deleted, err := f.service.DeleteMatchUpFormatPreset(f.ctx, saved.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	_, err = f.service.GetMatchUpFormatPreset(f.ctx, saved.ID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/tests/mocks"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newGuestFixture creates a started singles matchup against a guest who serves first
+func newGuestFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := newScheduledFixture(t)
+
+	matchUp, err := f.service.InitiateMatchUp(f.ctx, model.InitiateMatchUpInput{
+		MatchUpType:   model.MatchUpTypeSingles,
+		MatchUpFormat: standardFormat(),
+		Participants: []*model.ParticipantInput{
+			{ID: &f.playerA, DisplayedName: "Player A", TeamSide: model.TeamSideTeamA},
+			{DisplayedName: "Guest", TeamSide: model.TeamSideTeamB},
+		},
+		MatchUpTracker: f.playerA,
+		InitialServer:  f.playerA,
+	})
+	require.NoError(t, err)
+	f.matchUp = matchUp
+	f.playerB = matchUp.Participants[1].ID
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+
+	return f
+}
+
+// sendClaim invites the claimant to claim the fixture's guest
+func (f *fixture) sendClaim(t *testing.T, claimant primitive.ObjectID) *model.GuestClaim {
+	t.Helper()
+	claim, err := f.service.SendGuestClaim(f.ctx, model.SendGuestClaimInput{
+		MatchUpID: f.matchUp.ID,
+		GuestID:   f.playerB,
+		Claimant:  claimant,
+	})
+	require.NoError(t, err)
+	return claim
+}
+
+func TestAcceptGuestClaimRewritesHistory(t *testing.T) {
+	f := newGuestFixture(t)
+	// The guest wins a rally, so their ID is on a shot and in the serving order
+	f.ace(t, f.playerA)
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeContinuedRally)
+	f.shot(t, f.playerB, model.ShotTypeGroundStroke, model.ShotOutcomeWonPoint)
+
+	claimant := primitive.NewObjectID()
+	claim := f.sendClaim(t, claimant)
+	assert.Equal(t, model.GuestClaimStatusPending, claim.Status)
+	assert.Equal(t, "Guest", claim.DisplayName)
+
+	claimantCtx := mocks.ContextWithMongoID(claimant)
+	received, err := f.service.GetMyGuestClaims(claimantCtx, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+
+	accepted, err := f.service.AcceptGuestClaim(claimantCtx, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.GuestClaimStatusAccepted, accepted.Status)
+	assert.Equal(t, []primitive.ObjectID{f.matchUp.ID}, accepted.ClaimedMatchUpIds)
+
+	matchUp := f.reload(t)
+	assert.Equal(t, claimant, matchUp.Participants[1].ID)
+	assert.False(t, matchUp.Participants[1].IsGuest)
+	assert.Contains(t, matchUp.ServingOrder, claimant)
+	assert.NotContains(t, matchUp.ServingOrder, f.playerB)
+
+	shots, err := f.shots.FindByMatchUpID(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	for _, shot := range shots {
+		assert.NotEqual(t, f.playerB, shot.HitterID)
+		assert.NotEqual(t, f.playerB, shot.PointContext.ServerID)
+	}
+
+	// The match is now part of the claimant's history
+	mine, err := f.service.GetMyMatchUps(claimantCtx, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	players, err := f.service.GetPlayerStatistics(claimantCtx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, claimant, players[1].PlayerID)
+	assert.Equal(t, 1, players[1].PointsWon)
+
+	// Scoring carries on with the claimant
+	f.playerB = claimant
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeContinuedRally)
+	f.shot(t, claimant, model.ShotTypeGroundStroke, model.ShotOutcomeWonPoint)
+	assert.Equal(t, model.InGameScoreThirty, inGameScore(f.reload(t), model.TeamSideTeamB))
+}
+
+func TestAcceptGuestClaimCarriesOverTournamentsAndRatings(t *testing.T) {
+	f := newGuestFixture(t)
+	f.winPoint(t, f.playerA)
+	retiring := model.TeamSideTeamB
+	_, err := f.setStatus(model.MatchUpStatusRetired, &retiring)
+	require.NoError(t, err)
+	f.unrated(t, f.playerA)
+
+	// The owner entered the guest in one of their tournaments too
+	guest := f.playerB
+	tournament := f.newTournament(t, guest, primitive.NewObjectID())
+	draw, err := f.service.CreateDraw(f.ctx, model.CreateDrawInput{
+		TournamentID: tournament.ID,
+		Name:         "Final",
+		DrawType:     model.DrawTypeSingleElimination,
+	})
+	require.NoError(t, err)
+	final := f.drawSlot(t, tournament, draw, "MAIN-1-1")
+
+	claimant := primitive.NewObjectID()
+	claim := f.sendClaim(t, claimant)
+	accepted, err := f.service.AcceptGuestClaim(mocks.ContextWithMongoID(claimant), claim.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []primitive.ObjectID{f.matchUp.ID, *final.MatchUpID}, accepted.ClaimedMatchUpIds)
+
+	// The retired match is now between registered players, so it is rated
+	assert.Greater(t, f.userRating(t, f.playerA), 1500)
+	assert.Less(t, f.userRating(t, claimant), 1500)
+
+	// The entry and its draw match belong to the claimant, and the draw
+	// slot still points at the same entry
+	stored, err := f.service.GetTournament(f.ctx, tournament.ID)
+	require.NoError(t, err)
+	assert.Equal(t, claimant, stored.Entries[0].Players[0].ID)
+	assert.Equal(t, final.Sides, f.drawSlot(t, tournament, draw, "MAIN-1-1").Sides)
+	f.matchUp = &model.MatchUp{ID: *final.MatchUpID}
+	var players []primitive.ObjectID
+	for _, participant := range f.reload(t).Participants {
+		players = append(players, participant.ID)
+	}
+	assert.Contains(t, players, claimant)
+	assert.NotContains(t, players, guest)
+}
+
+func TestUndoAfterGuestClaimRestoresClaimantAsServer(t *testing.T) {
+	f := newGuestFixture(t)
+	// Player A holds serve, so the guest serves next in every snapshot since
+	for range 4 {
+		f.ace(t, f.playerA)
+	}
+	f.ace(t, f.playerB)
+
+	claimant := primitive.NewObjectID()
+	claim := f.sendClaim(t, claimant)
+	_, err := f.service.AcceptGuestClaim(mocks.ContextWithMongoID(claimant), claim.ID)
+	require.NoError(t, err)
+
+	shots, err := f.shots.FindByMatchUpID(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	for _, shot := range shots {
+		assert.NotEqual(t, f.playerB, shot.MatchStateAfterShot.CurrentServer)
+		assert.NotContains(t, shot.MatchStateAfterShot.ServingOrder, f.playerB)
+	}
+
+	// Undoing and redoing restore the serving state the snapshots kept
+	f.playerB = claimant
+	f.ace(t, claimant)
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, claimant, f.reload(t).CurrentServer)
+	assert.NotContains(t, f.reload(t).ServingOrder, claim.GuestID)
+	_, err = f.service.RedoShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, claimant, f.reload(t).CurrentServer)
+
+	f.ace(t, claimant)
+	assert.Equal(t, model.InGameScoreForty, inGameScore(f.reload(t), model.TeamSideTeamB))
+}
+
+func TestSendGuestClaimChecks(t *testing.T) {
+	f := newGuestFixture(t)
+	claimant := primitive.NewObjectID()
+
+	// Only guests can be claimed
+	_, err := f.service.SendGuestClaim(f.ctx, model.SendGuestClaimInput{
+		MatchUpID: f.matchUp.ID,
+		GuestID:   f.playerA,
+		Claimant:  claimant,
+	})
+	assert.True(t, sharedErrors.IsValidationError(err))
+
+	// Only by the owner
+	_, err = f.service.SendGuestClaim(mocks.ContextWithMongoID(claimant), model.SendGuestClaimInput{
+		MatchUpID: f.matchUp.ID,
+		GuestID:   f.playerB,
+		Claimant:  claimant,
+	})
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+
+	// Not by someone who already played in the match
+	_, err = f.service.SendGuestClaim(f.ctx, model.SendGuestClaimInput{
+		MatchUpID: f.matchUp.ID,
+		GuestID:   f.playerB,
+		Claimant:  f.playerA,
+	})
+	assert.True(t, sharedErrors.IsConflictError(err))
+
+	// And one invitation at a time
+	f.sendClaim(t, claimant)
+	_, err = f.service.SendGuestClaim(f.ctx, model.SendGuestClaimInput{
+		MatchUpID: f.matchUp.ID,
+		GuestID:   f.playerB,
+		Claimant:  primitive.NewObjectID(),
+	})
+	assert.True(t, sharedErrors.IsConflictError(err))
+}
+
+func TestAnsweringGuestClaims(t *testing.T) {
+	f := newGuestFixture(t)
+	claimant := primitive.NewObjectID()
+	claimantCtx := mocks.ContextWithMongoID(claimant)
+	claim := f.sendClaim(t, claimant)
+
+	// The owner can't accept on the claimant's behalf, and strangers can't see it
+	_, err := f.service.AcceptGuestClaim(f.ctx, claim.ID)
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+	_, err = f.service.AcceptGuestClaim(mocks.ContextWithMongoID(primitive.NewObjectID()), claim.ID)
+	assert.True(t, sharedErrors.IsNotFoundError(err))
+
+	declined, err := f.service.DeclineGuestClaim(claimantCtx, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.GuestClaimStatusDeclined, declined.Status)
+	_, err = f.service.AcceptGuestClaim(claimantCtx, claim.ID)
+	assert.True(t, sharedErrors.IsConflictError(err))
+	assert.True(t, f.reload(t).Participants[1].IsGuest)
+
+	// Once answered the guest can be offered again, and withdrawn
+	again := f.sendClaim(t, claimant)
+	_, err = f.service.CancelGuestClaim(claimantCtx, again.ID)
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+	cancelled, err := f.service.CancelGuestClaim(f.ctx, again.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.GuestClaimStatusCancelled, cancelled.Status)
+
+	pending := model.GuestClaimStatusPending
+	received, err := f.service.GetMyGuestClaims(claimantCtx, &pending, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, received)
+}
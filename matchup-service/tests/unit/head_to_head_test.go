@@ -0,0 +1,86 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/tests/mocks"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/access"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestHeadToHead(t *testing.T) {
+	f := newFixture(t)
+	playerC := primitive.NewObjectID()
+
+	// A wins a short set 2-0, loses a match tiebreak 0-5, and beats someone else
+	f.playMatch(t, f.playerB, shortSetFormat(), f.playerA, f.playerA)
+	f.playMatch(t, playerC, shortSetFormat(), f.playerA, f.playerA)
+	f.playMatch(t, f.playerB, matchTiebreakFormat(), f.playerB, f.playerB)
+
+	record, err := f.service.GetHeadToHead(f.ctx, f.playerA, f.playerB)
+	require.NoError(t, err)
+	assert.Equal(t, 2, record.MatchesPlayed)
+	assert.Equal(t, 1, record.UserAWins)
+	assert.Equal(t, 1, record.UserBWins)
+	assert.Equal(t, 1, record.UserASetsWon)
+	assert.Equal(t, 1, record.UserBSetsWon)
+	assert.Equal(t, 2, record.UserAGamesWon)
+	assert.Equal(t, 1, record.UserBGamesWon)
+	require.Len(t, record.MatchUps, 2)
+	assert.Equal(t, f.matchUp.ID, record.MatchUps[0].ID)
+	require.NotNil(t, record.LastScoreline)
+	assert.Equal(t, "[0-5]", *record.LastScoreline)
+	assert.Equal(t, f.reload(t).EndTime, record.LastPlayedAt)
+
+	// The same record from the other side
+	record, err = f.service.GetHeadToHead(f.ctx, f.playerB, f.playerA)
+	require.NoError(t, err)
+	assert.Equal(t, 1, record.UserAWins)
+	assert.Equal(t, "[5-0]", *record.LastScoreline)
+
+	// Players who never met
+	record, err = f.service.GetHeadToHead(f.ctx, f.playerB, playerC)
+	require.NoError(t, err)
+	assert.Equal(t, 0, record.MatchesPlayed)
+	assert.Empty(t, record.MatchUps)
+	assert.Nil(t, record.LastScoreline)
+
+	_, err = f.service.GetHeadToHead(f.ctx, f.playerA, f.playerA)
+	assert.True(t, sharedErrors.IsValidationError(err))
+}
+
+func TestHeadToHeadRespectsMatchAccess(t *testing.T) {
+	f := newFixture(t)
+	f.playMatch(t, f.playerB, shortSetFormat(), f.playerA, f.playerA)
+
+	viewer := primitive.NewObjectID()
+	checker := mocks.NewAccessChecker()
+	ctx := mocks.ContextWithAccessChecker(mocks.ContextWithMongoID(viewer), checker)
+
+	// Someone who didn't play needs access to the owner's matches
+	record, err := f.service.GetHeadToHead(ctx, f.playerA, f.playerB)
+	require.NoError(t, err)
+	assert.Equal(t, 0, record.MatchesPlayed)
+
+	// Matches are private until the owner shares them
+	checker.Grant(f.playerA, viewer, access.AccessLevelMatchParticipants)
+	record, err = f.service.GetHeadToHead(ctx, f.playerA, f.playerB)
+	require.NoError(t, err)
+	assert.Equal(t, 0, record.MatchesPlayed)
+
+	_, err = f.service.UpdateMatchUpVisibility(f.ctx, f.matchUp.ID, model.MatchUpVisibilityMatchParticipants)
+	require.NoError(t, err)
+	record, err = f.service.GetHeadToHead(ctx, f.playerA, f.playerB)
+	require.NoError(t, err)
+	assert.Equal(t, 1, record.MatchesPlayed)
+
+	// The players themselves always see their matches
+	ctx = mocks.ContextWithAccessChecker(mocks.ContextWithMongoID(f.playerB), mocks.NewAccessChecker())
+	record, err = f.service.GetHeadToHead(ctx, f.playerA, f.playerB)
+	require.NoError(t, err)
+	assert.Equal(t, 1, record.MatchesPlayed)
+}
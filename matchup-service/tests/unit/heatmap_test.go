@@ -0,0 +1,125 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// placedShot records a shot with where it bounced and where it was hit from
+func (f *fixture) placedShot(t *testing.T, hitter primitive.ObjectID, shotType model.ShotType, outcome model.ShotOutcome, bounce, from *model.CourtPositionInput) *model.MatchUpShot {
+	t.Helper()
+	shot, err := f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:      f.matchUp.ID,
+		HitterID:       hitter,
+		ShotType:       shotType,
+		ShotOutcome:    outcome,
+		BounceLocation: bounce,
+		HitterPosition: from,
+	})
+	require.NoError(t, err)
+	return shot
+}
+
+func TestShotHeatmaps(t *testing.T) {
+	f := newFixture(t)
+	baseline := &model.CourtPositionInput{X: 0.55, Y: -0.05}
+
+	// Aces down the T in the deuce box and out wide in the ad box, then a
+	// first serve that lands wide of the ad box
+	shot := f.placedShot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint, &model.CourtPositionInput{X: 0.48, Y: 0.7}, baseline)
+	require.NotNil(t, shot.BounceLocation)
+	assert.Equal(t, 0.48, shot.BounceLocation.X)
+	f.placedShot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint, &model.CourtPositionInput{X: 0.85, Y: 0.72}, baseline)
+	f.placedShot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint, &model.CourtPositionInput{X: 0.45, Y: 0.74}, baseline)
+	f.placedShot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeFirstFault, &model.CourtPositionInput{X: 0.92, Y: 0.7}, baseline)
+	// The second serve isn't placed and the return is netted
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeContinuedRally)
+	f.placedShot(t, f.playerB, model.ShotTypeGroundStroke, model.ShotOutcomeError, &model.CourtPositionInput{X: 0.4, Y: 0.45}, nil)
+
+	heatmaps, err := f.service.GetShotHeatmaps(f.ctx, f.matchUp.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, heatmaps, 4)
+
+	adFaults, adAces, deuceAces, returns := heatmaps[0], heatmaps[1], heatmaps[2], heatmaps[3]
+	assert.Equal(t, model.ServiceBoxSideAdSide, *adFaults.ServiceBoxSide)
+	assert.Equal(t, model.ShotOutcomeFirstFault, adFaults.ShotOutcome)
+	require.Len(t, adFaults.BounceZones, 1)
+	assert.Equal(t, 0.875, adFaults.BounceZones[0].XMin)
+
+	assert.Equal(t, model.ServiceBoxSideAdSide, *adAces.ServiceBoxSide)
+	assert.Equal(t, model.ShotOutcomeWonPoint, adAces.ShotOutcome)
+	assert.Equal(t, 1, adAces.Shots)
+
+	assert.Equal(t, f.playerA, deuceAces.PlayerID)
+	assert.Equal(t, model.ServiceBoxSideDeuceSide, *deuceAces.ServiceBoxSide)
+	assert.Equal(t, 2, deuceAces.Shots)
+	require.Len(t, deuceAces.BounceZones, 1)
+	zone := deuceAces.BounceZones[0]
+	assert.Equal(t, []float64{0.375, 0.5, 0.625, 0.75}, []float64{zone.XMin, zone.XMax, zone.YMin, zone.YMax})
+	assert.Equal(t, 2, zone.Count)
+	assert.Equal(t, 1.0, zone.Share)
+	require.Len(t, deuceAces.HitterZones, 1)
+	assert.Equal(t, 2, deuceAces.HitterZones[0].Count)
+
+	assert.Equal(t, f.playerB, returns.PlayerID)
+	assert.Nil(t, returns.ServiceBoxSide)
+	assert.Empty(t, returns.HitterZones)
+	require.Len(t, returns.BounceZones, 1)
+	assert.Equal(t, 0.375, returns.BounceZones[0].YMin)
+
+	// Narrowed to the serves into the ad box
+	ad, serve := model.ServiceBoxSideAdSide, model.ShotTypeServe
+	heatmaps, err = f.service.GetShotHeatmaps(f.ctx, f.matchUp.ID, &model.ShotHeatmapFilterInput{
+		PlayerID:       &f.playerA,
+		ShotType:       &serve,
+		ServiceBoxSide: &ad,
+	})
+	require.NoError(t, err)
+	require.Len(t, heatmaps, 2)
+	assert.Equal(t, adFaults.Shots, heatmaps[0].Shots)
+	assert.Equal(t, adAces.ShotOutcome, heatmaps[1].ShotOutcome)
+
+	// Undone shots are not counted
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	heatmaps, err = f.service.GetShotHeatmaps(f.ctx, f.matchUp.ID, nil)
+	require.NoError(t, err)
+	assert.Len(t, heatmaps, 3)
+}
+
+func TestCourtPositionValidation(t *testing.T) {
+	f := newFixture(t)
+
+	_, err := f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:      f.matchUp.ID,
+		HitterID:       f.playerA,
+		ShotType:       model.ShotTypeServe,
+		ShotOutcome:    model.ShotOutcomeWonPoint,
+		BounceLocation: &model.CourtPositionInput{X: 0.5, Y: 1.6},
+	})
+	assert.Error(t, err)
+
+	// Positions survive an export and import
+	f.placedShot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint,
+		&model.CourtPositionInput{X: 0.5, Y: 1.5}, &model.CourtPositionInput{X: -0.5, Y: -0.125})
+	for _, format := range []model.MatchUpExportFormat{model.MatchUpExportFormatCSV, model.MatchUpExportFormatJSON} {
+		export, err := f.service.ExportMatchUp(f.ctx, f.matchUp.ID, format)
+		require.NoError(t, err)
+		imported, err := f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+			Format:  format,
+			Content: export.Content,
+			MatchUp: f.guestSetupFor(model.MatchUpTypeSingles),
+		})
+		require.NoError(t, err)
+
+		shots, err := f.shots.FindByMatchUpID(f.ctx, imported.ID)
+		require.NoError(t, err)
+		require.Len(t, shots, 1)
+		assert.Equal(t, &model.CourtPosition{X: 0.5, Y: 1.5}, shots[0].BounceLocation)
+		assert.Equal(t, &model.CourtPosition{X: -0.5, Y: -0.125}, shots[0].HitterPosition)
+	}
+}
@@ -0,0 +1,164 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/integrity"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// checkShotChains runs the checker and returns the report on the fixture's matchup
+func (f *fixture) checkShotChains(t *testing.T, repair bool) *integrity.Report {
+	t.Helper()
+	reports, err := f.service.CheckShotChains(f.ctx, repair)
+	require.NoError(t, err)
+	for _, report := range reports {
+		if report.MatchUpID == f.matchUp.ID {
+			return report
+		}
+	}
+	t.Fatal("no report on the matchup")
+	return nil
+}
+
+// problemKinds lists the kinds of problems in a report
+func problemKinds(report *integrity.Report) []integrity.Kind {
+	kinds := make([]integrity.Kind, len(report.Problems))
+	for i, problem := range report.Problems {
+		kinds[i] = problem.Kind
+	}
+	return kinds
+}
+
+func TestCheckShotChainsOnConsistentMatch(t *testing.T) {
+	f := newFixture(t)
+	playBrokenServiceGame(t, f)
+	// Undone shots are kept for redo and are not a problem
+	_, err := f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	report := f.checkShotChains(t, true)
+	assert.Empty(t, report.Problems)
+	assert.False(t, report.Repaired)
+}
+
+func TestCheckAndRepairShotChains(t *testing.T) {
+	f := newFixture(t)
+	playBrokenServiceGame(t, f)
+	expected := f.reload(t)
+	shots, err := f.shots.FindByMatchUpID(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	require.Len(t, shots, 12)
+
+	// A next link to a shot that doesn't exist, a shot stamped before the one
+	// it follows, a wrong snapshot, a stray shot and a matchup left behind
+	missing := primitive.NewObjectID()
+	shots[3].NextShotID = &missing
+	shots[6].Timestamp = shots[5].Timestamp.Add(-time.Minute)
+	shots[8].MatchStateAfterShot.Score = scoring.CloneScore(shots[11].MatchStateAfterShot.Score)
+	for _, shot := range []*model.MatchUpShot{shots[3], shots[6], shots[8]} {
+		_, err := f.shots.Update(f.ctx, shot)
+		require.NoError(t, err)
+	}
+	orphan := *shots[11]
+	orphan.ID = primitive.NewObjectID()
+	orphan.PrevShotID = &missing
+	_, err = f.shots.Insert(f.ctx, &orphan)
+	require.NoError(t, err)
+
+	stale := f.reload(t)
+	stale.CurrentScore = scoring.CloneScore(shots[2].MatchStateAfterShot.Score)
+	winner := model.TeamSideTeamA
+	stale.Winner = &winner
+	_, err = f.matchups.Update(f.ctx, stale)
+	require.NoError(t, err)
+
+	// Checking alone changes nothing
+	report := f.checkShotChains(t, false)
+	assert.Equal(t, []integrity.Kind{
+		integrity.BrokenLink,
+		integrity.TimestampDisorder,
+		integrity.OrphanedShot,
+		integrity.StaleSnapshot,
+		integrity.StaleMatchUp,
+	}, problemKinds(report))
+	assert.Equal(t, shots[8].ID, *report.Problems[3].ShotID)
+	assert.Contains(t, report.Problems[4].Detail, "currentScore, winner")
+	assert.False(t, report.Repaired)
+	assert.NotNil(t, f.reload(t).Winner)
+
+	report = f.checkShotChains(t, true)
+	assert.True(t, report.Repaired)
+	assert.Empty(t, f.checkShotChains(t, false).Problems)
+
+	repaired := f.reload(t)
+	assert.Equal(t, shots[11].ID, *repaired.LastShot)
+	assert.Nil(t, repaired.Winner)
+	assert.True(t, scoring.SameScore(expected.CurrentScore, repaired.CurrentScore))
+	assert.Equal(t, expected.CurrentServer, repaired.CurrentServer)
+	_, err = f.shots.FindByID(f.ctx, orphan.ID)
+	assert.Error(t, err)
+
+	// Reading the shots by timestamp now follows the chain
+	reread, err := f.shots.FindByMatchUpID(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	require.Len(t, reread, 12)
+	for i, shot := range reread {
+		assert.Equal(t, shots[i].ID, shot.ID)
+	}
+	state, err := f.service.GetMatchStateAt(f.ctx, f.matchUp.ID, shots[8].ID)
+	require.NoError(t, err)
+	assert.True(t, state.MatchesStoredState)
+}
+
+func TestRepairCompletesMatchTheReplayDecides(t *testing.T) {
+	f := newFixture(t)
+	_, err := f.event(model.MatchEventTypeDefault, &f.playerA, nil)
+	require.NoError(t, err)
+
+	// The matchup lost the default's result, and the ratings with it
+	stale := f.reload(t)
+	stale.MatchUpStatus = model.MatchUpStatusInProgress
+	stale.Winner, stale.Loser = nil, nil
+	_, err = f.matchups.Update(f.ctx, stale)
+	require.NoError(t, err)
+	_, err = f.ratings.DeleteByMatchUp(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	report := f.checkShotChains(t, true)
+	assert.Equal(t, []integrity.Kind{integrity.StaleMatchUp}, problemKinds(report))
+	assert.True(t, report.Repaired)
+
+	// Repairing moves the match on the way recording the shot did
+	repaired := f.reload(t)
+	assert.Equal(t, model.MatchUpStatusCompleted, repaired.MatchUpStatus)
+	assert.Equal(t, model.TeamSideTeamB, *repaired.Winner)
+	assert.Equal(t, model.TeamSideTeamA, *repaired.Loser)
+	ratings, err := f.ratings.FindByMatchUp(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Len(t, ratings, 2)
+}
+
+func TestUnreplayableShotChainIsNotRepaired(t *testing.T) {
+	f := newFixture(t)
+	f.ace(t, f.playerA)
+	shot := f.ace(t, f.playerA)
+
+	// The second point now claims to be served by the receiver
+	stored, err := f.shots.FindByID(f.ctx, shot.ID)
+	require.NoError(t, err)
+	stored.HitterID = f.playerB
+	stored.HitterSide = model.TeamSideTeamB
+	_, err = f.shots.Update(f.ctx, stored)
+	require.NoError(t, err)
+
+	report := f.checkShotChains(t, true)
+	assert.Equal(t, []integrity.Kind{integrity.Unreplayable}, problemKinds(report))
+	assert.Equal(t, shot.ID, *report.Problems[0].ShotID)
+	assert.False(t, report.Repaired)
+}
@@ -0,0 +1,240 @@
+package unit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// playSamplePoints records an ace, a double fault, a rally won with a
+// backhand slice winner and a forced error on the return
+func (f *fixture) playSamplePoints(t *testing.T) {
+	t.Helper()
+	backhand, slice := model.GroundStrokeTypeBackhand, model.GroundStrokeStyleSlice
+	forced := model.PointWinReasonForcedError
+
+	f.ace(t, f.playerA)
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeFirstFault)
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeError)
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeContinuedRally)
+	f.shot(t, f.playerB, model.ShotTypeGroundStroke, model.ShotOutcomeContinuedRally)
+	_, err := f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:         f.matchUp.ID,
+		HitterID:          f.playerA,
+		ShotType:          model.ShotTypeGroundStroke,
+		GroundStrokeType:  &backhand,
+		GroundStrokeStyle: &slice,
+		ShotOutcome:       model.ShotOutcomeWonPoint,
+	})
+	require.NoError(t, err)
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeContinuedRally)
+	_, err = f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:      f.matchUp.ID,
+		HitterID:       f.playerB,
+		ShotType:       model.ShotTypeGroundStroke,
+		ShotOutcome:    model.ShotOutcomeError,
+		PointWinReason: &forced,
+	})
+	require.NoError(t, err)
+}
+
+// setupFor returns the fixture's match details for an import
+func (f *fixture) setupFor(matchUpType model.MatchUpType) *model.InitiateMatchUpInput {
+	return &model.InitiateMatchUpInput{
+		MatchUpType:   matchUpType,
+		MatchUpFormat: standardFormat(),
+		Participants: []*model.ParticipantInput{
+			{ID: &f.playerA, DisplayedName: "Player A", TeamSide: model.TeamSideTeamA},
+			{ID: &f.playerB, DisplayedName: "Player B", TeamSide: model.TeamSideTeamB},
+		},
+		MatchUpTracker: f.playerA,
+		InitialServer:  f.playerA,
+	}
+}
+
+// guestSetupFor returns match details for an import of a match player A
+// played against a guest standing in for player B
+func (f *fixture) guestSetupFor(matchUpType model.MatchUpType) *model.InitiateMatchUpInput {
+	setup := f.setupFor(matchUpType)
+	setup.Participants[1].ID = nil
+	return setup
+}
+
+// matchUpCount returns how many matchups are stored
+func (f *fixture) matchUpCount(t *testing.T) int {
+	t.Helper()
+	matchUps, err := f.matchups.GetMatchups(context.Background(), nil, nil)
+	require.NoError(t, err)
+	return len(matchUps)
+}
+
+func TestExportCharting(t *testing.T) {
+	f := newFixture(t)
+	f.playSamplePoints(t)
+
+	export, err := f.service.ExportMatchUp(f.ctx, f.matchUp.ID, model.MatchUpExportFormatCharting)
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv", export.ContentType)
+
+	lines := strings.Split(strings.TrimSpace(export.Content), "\n")
+	assert.Equal(t, []string{
+		"Pt,Set1,Set2,Gm1,Gm2,Pts,Svr,1st,2nd,PtWinner",
+		"1,0,0,0,0,0-0,1,0*,,1",
+		"2,0,0,0,0,15-0,1,0e,0e,2",
+		"3,0,0,0,0,15-15,1,0qs*,,1",
+		"4,0,0,0,0,30-15,1,0qe#,,1",
+	}, lines)
+}
+
+func TestImportCharting(t *testing.T) {
+	f := newFixture(t)
+
+	content := "Pt,1st,2nd\n" +
+		"1,6*,\n" +
+		"2,4n,5d\n" +
+		"3,c4f1b3*,\n" +
+		"4,5f2n@,\n"
+	matchUp, err := f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+		Format:  model.MatchUpExportFormatCharting,
+		Content: content,
+		MatchUp: f.guestSetupFor(model.MatchUpTypeSingles),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, model.MatchUpStatusInProgress, matchUp.MatchUpStatus)
+	assert.Equal(t, model.InGameScoreForty, inGameScore(matchUp, model.TeamSideTeamA))
+	assert.Equal(t, model.InGameScoreFifteen, inGameScore(matchUp, model.TeamSideTeamB))
+
+	statistics, err := f.service.GetMatchStatistics(f.ctx, matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *statistics.TeamStats[0].Aces)
+	assert.Equal(t, 1, *statistics.TeamStats[0].DoubleFaults)
+}
+
+func TestImportChartingRejectsDoubles(t *testing.T) {
+	f := newFixture(t)
+
+	_, err := f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+		Format:  model.MatchUpExportFormatCharting,
+		Content: "Pt,1st,2nd\n1,6*,\n",
+		MatchUp: f.guestSetupFor(model.MatchUpTypeDoubles),
+	})
+	assert.Error(t, err)
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	f := newFixture(t)
+	f.playSamplePoints(t)
+	original := f.reload(t)
+
+	export, err := f.service.ExportMatchUp(f.ctx, f.matchUp.ID, model.MatchUpExportFormatCSV)
+	require.NoError(t, err)
+
+	// CSV files only carry the shots
+	_, err = f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+		Format:  model.MatchUpExportFormatCSV,
+		Content: export.Content,
+	})
+	assert.Error(t, err)
+
+	imported, err := f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+		Format:  model.MatchUpExportFormatCSV,
+		Content: export.Content,
+		MatchUp: f.guestSetupFor(model.MatchUpTypeSingles),
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, original.ID, imported.ID)
+	assert.Equal(t, original.CurrentScore, imported.CurrentScore)
+
+	// The rally details survive the round trip
+	reexport, err := f.service.ExportMatchUp(f.ctx, imported.ID, model.MatchUpExportFormatCharting)
+	require.NoError(t, err)
+	assert.Contains(t, reexport.Content, "0qs*")
+}
+
+func TestJSONRoundTripKeepsGuests(t *testing.T) {
+	f := newScheduledFixture(t)
+	matchUp, err := f.service.InitiateMatchUp(f.ctx, model.InitiateMatchUpInput{
+		MatchUpType:   model.MatchUpTypeSingles,
+		MatchUpFormat: standardFormat(),
+		Participants: []*model.ParticipantInput{
+			{ID: &f.playerA, DisplayedName: "Player A", TeamSide: model.TeamSideTeamA},
+			{DisplayedName: "Guest", TeamSide: model.TeamSideTeamB},
+		},
+		MatchUpTracker: f.playerA,
+		InitialServer:  f.playerA,
+	})
+	require.NoError(t, err)
+	f.matchUp = matchUp
+	f.playerB = matchUp.Participants[1].ID
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+	f.playSamplePoints(t)
+
+	export, err := f.service.ExportMatchUp(f.ctx, f.matchUp.ID, model.MatchUpExportFormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", export.ContentType)
+
+	imported, err := f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+		Format:  model.MatchUpExportFormatJSON,
+		Content: export.Content,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, f.reload(t).CurrentScore, imported.CurrentScore)
+	assert.True(t, imported.Participants[1].IsGuest)
+	assert.Equal(t, f.playerA, imported.MatchUpTracker)
+}
+
+func TestFailedImportLeavesNothingBehind(t *testing.T) {
+	f := newFixture(t)
+	checker := mocks.NewAccessChecker()
+	f.withChecker(checker, f.playerA)
+	before, shots := f.matchUpCount(t), f.shots.Count()
+
+	// Player B can't serve the first point, and the coach tracking it would
+	// otherwise get a role on the match
+	content := "hitterName,shotType,shotOutcome\n" +
+		"Player A,SERVE,WON_POINT\n" +
+		"Player B,SERVE,WON_POINT\n"
+	setup := f.guestSetupFor(model.MatchUpTypeSingles)
+	setup.MatchUpTracker = primitive.NewObjectID()
+	_, err := f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+		Format:  model.MatchUpExportFormatCSV,
+		Content: content,
+		MatchUp: setup,
+	})
+	assert.Error(t, err)
+	assert.Equal(t, before, f.matchUpCount(t))
+	assert.Equal(t, shots, f.shots.Count())
+	assert.Zero(t, checker.RoleCount())
+
+	// Once the import succeeds, the tracker gets their role
+	content = "hitterName,shotType,shotOutcome\nPlayer A,SERVE,WON_POINT\n"
+	_, err = f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+		Format:  model.MatchUpExportFormatCSV,
+		Content: content,
+		MatchUp: setup,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, checker.RoleCount())
+}
+
+func TestImportAgainstRegisteredPlayerNeedsTheirAcceptance(t *testing.T) {
+	f := newFixture(t)
+	before := f.matchUpCount(t)
+
+	// A file can't stand in for a match player B never agreed to
+	_, err := f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+		Format:  model.MatchUpExportFormatCSV,
+		Content: "hitterName,shotType,shotOutcome\nPlayer A,SERVE,WON_POINT\n",
+		MatchUp: f.setupFor(model.MatchUpTypeSingles),
+	})
+	assert.Error(t, err)
+	assert.Equal(t, before, f.matchUpCount(t))
+}
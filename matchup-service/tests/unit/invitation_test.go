@@ -0,0 +1,167 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/tests/mocks"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// acceptInvitations answers every invitation to the fixture's matchup as
+// the invited player, so the match can start
+func (f *fixture) acceptInvitations(t *testing.T) {
+	t.Helper()
+	for _, participant := range f.matchUp.Participants {
+		if participant.InvitationStatus == nil {
+			continue
+		}
+		matchUp, err := f.service.AcceptMatchUpInvitation(mocks.ContextWithMongoID(participant.ID), f.matchUp.ID)
+		require.NoError(t, err)
+		f.matchUp = matchUp
+	}
+}
+
+// invitations lists player B's upcoming invitations with the given answer
+func (f *fixture) invitations(t *testing.T, status model.InvitationStatus) []primitive.ObjectID {
+	t.Helper()
+	matchUps, err := f.service.GetMyMatchUpInvitations(mocks.ContextWithMongoID(f.playerB), &status, nil, nil)
+	require.NoError(t, err)
+	return ids(matchUps)
+}
+
+func TestInitiateMatchUpInvitesRegisteredParticipants(t *testing.T) {
+	f := newScheduledFixture(t)
+	start := time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC)
+	court, playerD := primitive.NewObjectID(), primitive.NewObjectID()
+
+	setup := f.setupFor(model.MatchUpTypeDoubles)
+	setup.Participants = append(setup.Participants,
+		&model.ParticipantInput{DisplayedName: "Guest", TeamSide: model.TeamSideTeamA},
+		&model.ParticipantInput{ID: &playerD, DisplayedName: "Player D", TeamSide: model.TeamSideTeamB},
+	)
+	setup.ScheduledStartTime = &start
+	setup.CourtID = &court
+	matchUp, err := f.service.InitiateMatchUp(f.ctx, *setup)
+	require.NoError(t, err)
+	assert.Equal(t, start, *matchUp.ScheduledStartTime)
+	assert.Equal(t, court, matchUp.Court.ID)
+
+	// The owner and guests are never asked
+	assert.Nil(t, matchUp.Participants[0].InvitationStatus)
+	assert.Equal(t, model.InvitationStatusPending, *matchUp.Participants[1].InvitationStatus)
+	assert.Nil(t, matchUp.Participants[2].InvitationStatus)
+	assert.Equal(t, model.InvitationStatusPending, *matchUp.Participants[3].InvitationStatus)
+
+	assert.Equal(t, []primitive.ObjectID{matchUp.ID}, f.invitations(t, model.InvitationStatusPending))
+	mine, err := f.service.GetMyMatchUpInvitations(f.ctx, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, mine)
+}
+
+func TestMatchUpStartsOnceInvitationsAccepted(t *testing.T) {
+	f := newFixture(t)
+	f.matchUp = f.initiate(t, f.playerB)
+	invitee := mocks.ContextWithMongoID(f.playerB)
+
+	_, err := f.setStatus(model.MatchUpStatusInProgress, nil)
+	assert.True(t, sharedErrors.IsConflictError(err))
+
+	// A declined invitation still holds the match back
+	declined, err := f.service.DeclineMatchUpInvitation(invitee, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.InvitationStatusDeclined, *declined.Participants[1].InvitationStatus)
+	assert.NotNil(t, declined.Participants[1].InvitationRespondedAt)
+	assert.Equal(t, []primitive.ObjectID{f.matchUp.ID}, f.invitations(t, model.InvitationStatusDeclined))
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	assert.True(t, sharedErrors.IsConflictError(err))
+
+	// Changing their mind lets it start
+	_, err = f.service.AcceptMatchUpInvitation(invitee, f.matchUp.ID)
+	require.NoError(t, err)
+	matchUp, err := f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.MatchUpStatusInProgress, matchUp.MatchUpStatus)
+
+	// Once started the invitation is settled
+	_, err = f.service.DeclineMatchUpInvitation(invitee, f.matchUp.ID)
+	assert.True(t, sharedErrors.IsConflictError(err))
+	assert.Empty(t, f.invitations(t, model.InvitationStatusAccepted))
+}
+
+func TestOwnerCanOverrideInvitations(t *testing.T) {
+	f := newScheduledFixture(t)
+	f.matchUp = f.initiate(t, f.playerB)
+	override := true
+
+	// Only the owner can start without the invitations
+	_, err := f.service.UpdateMatchUpStatus(mocks.ContextWithMongoID(f.playerB), model.UpdateMatchUpStatusInput{
+		MatchUpID:           f.matchUp.ID,
+		Status:              model.MatchUpStatusInProgress,
+		OverrideInvitations: &override,
+	})
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+
+	// Someone who wasn't invited has nothing to answer
+	_, err = f.service.AcceptMatchUpInvitation(mocks.ContextWithMongoID(primitive.NewObjectID()), f.matchUp.ID)
+	assert.True(t, sharedErrors.IsNotFoundError(err))
+	_, err = f.service.AcceptMatchUpInvitation(f.ctx, f.matchUp.ID)
+	assert.True(t, sharedErrors.IsNotFoundError(err))
+
+	matchUp, err := f.service.UpdateMatchUpStatus(f.ctx, model.UpdateMatchUpStatusInput{
+		MatchUpID:           f.matchUp.ID,
+		Status:              model.MatchUpStatusInProgress,
+		OverrideInvitations: &override,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.MatchUpStatusInProgress, matchUp.MatchUpStatus)
+	assert.Equal(t, model.InvitationStatusPending, *matchUp.Participants[1].InvitationStatus)
+	assert.Empty(t, f.invitations(t, model.InvitationStatusPending))
+}
+
+func TestScheduleMatchUp(t *testing.T) {
+	f := newScheduledFixture(t)
+	start := time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC)
+	court := primitive.NewObjectID()
+
+	matchUp, err := f.service.ScheduleMatchUp(f.ctx, model.ScheduleMatchUpInput{
+		MatchUpID:          f.matchUp.ID,
+		ScheduledStartTime: graphql.OmittableOf(&start),
+		CourtID:            graphql.OmittableOf(&court),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, start, *matchUp.ScheduledStartTime)
+	assert.Equal(t, court, matchUp.Court.ID)
+
+	// Moving the start time keeps the court
+	later := start.Add(time.Hour)
+	matchUp, err = f.service.ScheduleMatchUp(f.ctx, model.ScheduleMatchUpInput{
+		MatchUpID:          f.matchUp.ID,
+		ScheduledStartTime: graphql.OmittableOf(&later),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, later, *matchUp.ScheduledStartTime)
+	assert.Equal(t, court, matchUp.Court.ID)
+
+	// Setting the court to null clears it and keeps the start time
+	matchUp, err = f.service.ScheduleMatchUp(f.ctx, model.ScheduleMatchUpInput{
+		MatchUpID: f.matchUp.ID,
+		CourtID:   graphql.OmittableOf[*primitive.ObjectID](nil),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, matchUp.Court)
+	assert.Equal(t, later, *f.reload(t).ScheduledStartTime)
+
+	_, err = f.service.ScheduleMatchUp(mocks.ContextWithMongoID(f.playerB), model.ScheduleMatchUpInput{MatchUpID: f.matchUp.ID})
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+	_, err = f.service.ScheduleMatchUp(f.ctx, model.ScheduleMatchUpInput{MatchUpID: f.matchUp.ID})
+	assert.True(t, sharedErrors.IsConflictError(err))
+}
@@ -0,0 +1,164 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/tests/mocks"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// setStatus moves the fixture's matchup to a new status
+func (f *fixture) setStatus(status model.MatchUpStatus, retiringSide *model.TeamSide) (*model.MatchUp, error) {
+	return f.service.UpdateMatchUpStatus(f.ctx, model.UpdateMatchUpStatusInput{
+		MatchUpID:    f.matchUp.ID,
+		Status:       status,
+		RetiringSide: retiringSide,
+	})
+}
+
+// winPoint gives a point to a player with an ace on their serve or a
+// double fault on the opponent's
+func (f *fixture) winPoint(t *testing.T, winner primitive.ObjectID) {
+	t.Helper()
+	server := f.reload(t).CurrentServer
+	if server == winner {
+		f.ace(t, server)
+		return
+	}
+	f.shot(t, server, model.ShotTypeServe, model.ShotOutcomeFirstFault)
+	f.shot(t, server, model.ShotTypeServe, model.ShotOutcomeError)
+}
+
+func TestShotsRequireMatchInProgress(t *testing.T) {
+	f := newScheduledFixture(t)
+
+	_, err := f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:   f.matchUp.ID,
+		HitterID:    f.playerA,
+		ShotType:    model.ShotTypeServe,
+		ShotOutcome: model.ShotOutcomeWonPoint,
+	})
+	assert.Error(t, err)
+}
+
+func TestStartingMatchRecordsTransition(t *testing.T) {
+	f := newScheduledFixture(t)
+
+	matchUp, err := f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, model.MatchUpStatusInProgress, matchUp.MatchUpStatus)
+	require.NotNil(t, matchUp.StartTime)
+	assert.Nil(t, matchUp.EndTime)
+	require.Len(t, matchUp.StatusHistory, 1)
+	assert.Equal(t, model.MatchUpStatusScheduled, matchUp.StatusHistory[0].FromStatus)
+	assert.Equal(t, model.MatchUpStatusInProgress, matchUp.StatusHistory[0].ToStatus)
+	assert.Equal(t, f.playerA, matchUp.StatusHistory[0].ChangedBy)
+}
+
+func TestIllegalStatusTransitions(t *testing.T) {
+	f := newScheduledFixture(t)
+
+	_, err := f.setStatus(model.MatchUpStatusSuspended, nil)
+	assert.Error(t, err)
+
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+
+	// The score is still open
+	_, err = f.setStatus(model.MatchUpStatusCompleted, nil)
+	assert.Error(t, err)
+
+	_, err = f.setStatus(model.MatchUpStatusCancelled, nil)
+	assert.Error(t, err)
+}
+
+func TestSuspendAndResumeKeepsStartTime(t *testing.T) {
+	f := newFixture(t)
+	started := f.matchUp.StartTime
+
+	_, err := f.setStatus(model.MatchUpStatusSuspended, nil)
+	require.NoError(t, err)
+	matchUp, err := f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+
+	assert.True(t, started.Equal(*matchUp.StartTime))
+	assert.Len(t, matchUp.StatusHistory, 3)
+}
+
+func TestRetirementNamesWinner(t *testing.T) {
+	f := newFixture(t)
+	retiring := model.TeamSideTeamB
+
+	_, err := f.setStatus(model.MatchUpStatusRetired, nil)
+	assert.Error(t, err)
+
+	matchUp, err := f.setStatus(model.MatchUpStatusRetired, &retiring)
+	require.NoError(t, err)
+	assert.Equal(t, model.TeamSideTeamA, *matchUp.Winner)
+	assert.Equal(t, model.TeamSideTeamB, *matchUp.Loser)
+	assert.Equal(t, model.TeamSideTeamB, *matchUp.RetiringSide)
+	assert.NotNil(t, matchUp.EndTime)
+
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	assert.Error(t, err)
+}
+
+func TestDecidingShotCompletesMatch(t *testing.T) {
+	f := newFixture(t)
+
+	for i := 0; i < 48; i++ {
+		f.winPoint(t, f.playerA)
+	}
+
+	matchUp := f.reload(t)
+	assert.Equal(t, model.MatchUpStatusCompleted, matchUp.MatchUpStatus)
+	assert.Equal(t, model.TeamSideTeamA, *matchUp.Winner)
+	assert.NotNil(t, matchUp.EndTime)
+
+	// Undoing the deciding shot reopens the match
+	_, err := f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	matchUp = f.reload(t)
+	assert.Equal(t, model.MatchUpStatusInProgress, matchUp.MatchUpStatus)
+	assert.Nil(t, matchUp.Winner)
+	assert.Nil(t, matchUp.EndTime)
+
+	// Only undoing can reopen it; a completed match is otherwise final
+	_, err = f.service.RedoShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	assert.Error(t, err)
+	assert.Equal(t, model.MatchUpStatusCompleted, f.reload(t).MatchUpStatus)
+}
+
+func TestOnlyOwnerAndTrackersChangeStatus(t *testing.T) {
+	f := newFixture(t)
+	stranger := primitive.NewObjectID()
+	checker := mocks.NewAccessChecker()
+
+	// Neither a player nor anyone else can end the owner's match
+	for _, userID := range []primitive.ObjectID{f.playerB, stranger} {
+		_, err := f.service.UpdateMatchUpStatus(f.withChecker(checker, userID), model.UpdateMatchUpStatusInput{
+			MatchUpID: f.matchUp.ID,
+			Status:    model.MatchUpStatusAbandoned,
+		})
+		assert.True(t, sharedErrors.IsForbiddenError(err))
+	}
+
+	// A tracker can
+	coachCtx := f.withChecker(checker, stranger)
+	_, err := f.service.GrantMatchUpTracker(f.ctx, f.matchUp.ID, stranger)
+	require.NoError(t, err)
+	matchUp, err := f.service.UpdateMatchUpStatus(coachCtx, model.UpdateMatchUpStatusInput{
+		MatchUpID: f.matchUp.ID,
+		Status:    model.MatchUpStatusSuspended,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.MatchUpStatusSuspended, matchUp.MatchUpStatus)
+}
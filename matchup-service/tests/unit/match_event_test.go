@@ -0,0 +1,177 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// event records a let, penalty or default
+func (f *fixture) event(eventType model.MatchEventType, player *primitive.ObjectID, violation *model.ViolationType) (*model.MatchUpShot, error) {
+	return f.service.AddMatchEvent(f.ctx, model.AddMatchEventInput{
+		MatchUpID: f.matchUp.ID,
+		EventType: eventType,
+		PlayerID:  player,
+		Violation: violation,
+	})
+}
+
+func TestLetReplaysTheServe(t *testing.T) {
+	f := newFixture(t)
+
+	// A let before the first serve leaves the server two serves
+	let, err := f.event(model.MatchEventTypeLet, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.ShotTypeMatchEvent, let.ShotType)
+	assert.Equal(t, f.playerA, let.HitterID)
+	assert.Equal(t, model.ServeNumberFirstServe, *let.ServeNumber)
+	assert.Equal(t, model.ServiceBoxSideDeuceSide, *let.ServiceBoxSide)
+	assert.False(t, let.MatchStateAfterShot.PointCompleted)
+	ace := f.ace(t, f.playerA)
+	assert.Equal(t, 1, ace.PointContext.PointNumber)
+
+	// A let on the second serve leaves one, so a fault after it is a double fault
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeFirstFault)
+	let, err = f.event(model.MatchEventTypeLet, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.ServeNumberSecondServe, *let.ServeNumber)
+	doubleFault := f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeFirstFault)
+	assert.Equal(t, model.PointWinReasonDoubleFault, *doubleFault.PointWinReason)
+	assert.Equal(t, 2, doubleFault.PointContext.PointNumber)
+
+	// Lets are only called on a serve
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeContinuedRally)
+	_, err = f.event(model.MatchEventTypeLet, nil, nil)
+	assert.Error(t, err)
+
+	statistics, err := f.service.GetMatchStatistics(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, statistics.TotalPoints)
+	assert.Equal(t, 1, *statistics.TeamStats[0].Aces)
+	assert.Equal(t, 1, *statistics.TeamStats[0].DoubleFaults)
+}
+
+func TestPenaltiesAwardPointGameAndMatch(t *testing.T) {
+	f := newFixture(t)
+
+	// A point penalty between serves ends the point
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeFirstFault)
+	timeViolation := model.ViolationTypeTimeViolation
+	penalty, err := f.event(model.MatchEventTypePointPenalty, &f.playerA, &timeViolation)
+	require.NoError(t, err)
+	assert.Equal(t, model.ShotOutcomeError, penalty.ShotOutcome)
+	assert.Equal(t, model.PointWinReasonPenalty, *penalty.PointWinReason)
+	assert.Equal(t, model.ViolationTypeTimeViolation, *penalty.Violation)
+	assert.Equal(t, model.TeamSideTeamB, *penalty.MatchStateAfterShot.PointWinner)
+	assert.Equal(t, model.InGameScoreFifteen, inGameScore(f.reload(t), model.TeamSideTeamB))
+
+	// A game penalty gives the rest of the game
+	game, err := f.event(model.MatchEventTypeGamePenalty, &f.playerA, nil)
+	require.NoError(t, err)
+	assert.True(t, game.MatchStateAfterShot.GameCompleted)
+	matchUp := f.reload(t)
+	assert.Equal(t, 1, scoring.SideScore(scoring.CurrentSet(matchUp.CurrentScore), model.TeamSideTeamB).GamesWon)
+	assert.Equal(t, f.playerB, matchUp.CurrentServer)
+
+	// A default decides the match and undoing it reopens the match
+	_, err = f.event(model.MatchEventTypeDefault, &f.playerB, nil)
+	require.NoError(t, err)
+	matchUp = f.reload(t)
+	assert.Equal(t, model.MatchUpStatusCompleted, matchUp.MatchUpStatus)
+	assert.Equal(t, model.TeamSideTeamA, *matchUp.Winner)
+	assert.Equal(t, 1, scoring.SideScore(scoring.CurrentSet(matchUp.CurrentScore), model.TeamSideTeamB).GamesWon)
+
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	matchUp = f.reload(t)
+	assert.Equal(t, model.MatchUpStatusInProgress, matchUp.MatchUpStatus)
+	assert.Nil(t, matchUp.Winner)
+
+	// Penalties don't count as aces, winners or errors
+	statistics, err := f.service.GetMatchStatistics(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, statistics.TotalPoints)
+	assert.Equal(t, 2, statistics.TeamStats[1].PointsWon)
+	assert.Nil(t, statistics.TeamStats[0].DoubleFaults)
+}
+
+func TestDefaultDecidesTheMatchWhateverTheScore(t *testing.T) {
+	f := newFixture(t)
+
+	// Defaulting team A before a set is won gives the match to team B
+	_, err := f.event(model.MatchEventTypeDefault, &f.playerA, nil)
+	require.NoError(t, err)
+	matchUp := f.reload(t)
+	assert.Equal(t, model.MatchUpStatusCompleted, matchUp.MatchUpStatus)
+	assert.Equal(t, model.TeamSideTeamB, *matchUp.Winner)
+	assert.Equal(t, model.TeamSideTeamA, *matchUp.Loser)
+
+	// The players' ratings follow the real result
+	ratings, err := f.ratings.FindByMatchUp(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	require.Len(t, ratings, 2)
+	for _, change := range ratings {
+		assert.Equal(t, change.UserID == f.playerB, change.Change > 0)
+	}
+}
+
+func TestAddMatchEventValidation(t *testing.T) {
+	f := newFixture(t)
+	codeViolation := model.ViolationTypeCodeViolation
+	stranger := primitive.NewObjectID()
+
+	_, err := f.event(model.MatchEventTypeLet, nil, &codeViolation)
+	assert.True(t, sharedErrors.IsValidationError(err))
+	_, err = f.event(model.MatchEventTypePointPenalty, nil, &codeViolation)
+	assert.True(t, sharedErrors.IsValidationError(err))
+	_, err = f.event(model.MatchEventTypePointPenalty, &stranger, nil)
+	assert.True(t, sharedErrors.IsValidationError(err))
+
+	// The receiver can't be given a let on their own serve
+	_, err = f.event(model.MatchEventTypeLet, &f.playerB, nil)
+	assert.Error(t, err)
+
+	// Events have their own mutation
+	_, err = f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:   f.matchUp.ID,
+		HitterID:    f.playerA,
+		ShotType:    model.ShotTypeMatchEvent,
+		ShotOutcome: model.ShotOutcomeError,
+	})
+	assert.True(t, sharedErrors.IsValidationError(err))
+}
+
+func TestMatchEventsRoundTrip(t *testing.T) {
+	f := newFixture(t)
+	_, err := f.event(model.MatchEventTypeLet, nil, nil)
+	require.NoError(t, err)
+	f.ace(t, f.playerA)
+	codeViolation := model.ViolationTypeCodeViolation
+	_, err = f.event(model.MatchEventTypeGamePenalty, &f.playerB, &codeViolation)
+	require.NoError(t, err)
+	original := f.reload(t)
+
+	export, err := f.service.ExportMatchUp(f.ctx, f.matchUp.ID, model.MatchUpExportFormatCSV)
+	require.NoError(t, err)
+	assert.Contains(t, export.Content, "MATCH_EVENT")
+	assert.Contains(t, export.Content, "GAME_PENALTY,CODE_VIOLATION")
+
+	imported, err := f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+		Format:  model.MatchUpExportFormatCSV,
+		Content: export.Content,
+		MatchUp: f.guestSetupFor(model.MatchUpTypeSingles),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, original.CurrentScore, imported.CurrentScore)
+
+	// The charting notation writes the let before its serve
+	charting, err := f.service.ExportMatchUp(f.ctx, f.matchUp.ID, model.MatchUpExportFormatCharting)
+	require.NoError(t, err)
+	assert.Contains(t, charting.Content, "1,0,0,0,0,0-0,1,c0*,,1")
+	assert.Contains(t, charting.Content, "2,0,0,0,0,15-0,1,S,,1")
+}
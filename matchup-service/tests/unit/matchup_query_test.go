@@ -0,0 +1,102 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// initiate creates another singles matchup between player A and an opponent
+func (f *fixture) initiate(t *testing.T, opponent primitive.ObjectID) *model.MatchUp {
+	t.Helper()
+	matchUp, err := f.service.InitiateMatchUp(f.ctx, model.InitiateMatchUpInput{
+		MatchUpType:   model.MatchUpTypeSingles,
+		MatchUpFormat: standardFormat(),
+		Participants: []*model.ParticipantInput{
+			{ID: &f.playerA, DisplayedName: "Player A", TeamSide: model.TeamSideTeamA},
+			{ID: &opponent, DisplayedName: "Opponent", TeamSide: model.TeamSideTeamB},
+		},
+		MatchUpTracker: f.playerA,
+		InitialServer:  f.playerA,
+	})
+	require.NoError(t, err)
+	return matchUp
+}
+
+// ids returns the IDs of the given matchups in order
+func ids(matchUps []*model.MatchUp) []primitive.ObjectID {
+	result := make([]primitive.ObjectID, len(matchUps))
+	for i, matchUp := range matchUps {
+		result[i] = matchUp.ID
+	}
+	return result
+}
+
+func TestGetMatchUpByID(t *testing.T) {
+	f := newFixture(t)
+
+	matchUp, err := f.service.GetMatchUpById(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, f.matchUp.ID, matchUp.ID)
+
+	_, err = f.service.GetMatchUpById(f.ctx, primitive.NewObjectID())
+	assert.Error(t, err)
+}
+
+func TestMyMatchUpsFilters(t *testing.T) {
+	f := newFixture(t)
+	playerC := primitive.NewObjectID()
+
+	// The fixture's matchup against B is in progress; against C one is retired by C and one is scheduled
+	inProgress := f.matchUp
+	retired := f.initiate(t, playerC)
+	scheduled := f.initiate(t, playerC)
+	f.matchUp = retired
+	f.acceptInvitations(t)
+	_, err := f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+	retiring := model.TeamSideTeamB
+	_, err = f.setStatus(model.MatchUpStatusRetired, &retiring)
+	require.NoError(t, err)
+
+	all, err := f.service.GetMyMatchUps(f.ctx, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, scheduled.ID, all[2].ID, "unstarted matchups come last")
+
+	won := model.MatchUpOutcomeWon
+	matchUps, err := f.service.GetMyMatchUps(f.ctx, &model.MatchUpFilterInput{Outcome: &won}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{retired.ID}, ids(matchUps))
+
+	matchUps, err = f.service.GetMyMatchUps(f.ctx, &model.MatchUpFilterInput{OpponentID: &playerC}, nil, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []primitive.ObjectID{retired.ID, scheduled.ID}, ids(matchUps))
+
+	matchUps, err = f.service.GetMyMatchUps(f.ctx, &model.MatchUpFilterInput{
+		Status: []model.MatchUpStatus{model.MatchUpStatusScheduled, model.MatchUpStatusInProgress},
+	}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{inProgress.ID, scheduled.ID}, ids(matchUps))
+
+	limit, offset := 1, 1
+	matchUps, err = f.service.GetMyMatchUps(f.ctx, nil, &limit, &offset)
+	require.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{all[1].ID}, ids(matchUps))
+}
+
+func TestMyMatchUpsRejectsInvalidDateRange(t *testing.T) {
+	f := newFixture(t)
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	_, err := f.service.GetMyMatchUps(f.ctx, &model.MatchUpFilterInput{
+		StartTimeFrom: &now,
+		StartTimeTo:   &earlier,
+	}, nil, nil)
+	assert.Error(t, err)
+}
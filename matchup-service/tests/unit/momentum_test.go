@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMatchMomentum(t *testing.T) {
+	f := newFixture(t)
+	f.playMatch(t, f.playerB, shortSetFormat(), f.playerA, f.playerA)
+
+	momentum, err := f.service.GetMatchMomentum(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	// A held to love with aces and broke with B's double faults
+	assert.InDelta(t, 10.0/14, momentum.TeamAServePointWinRate, 1e-9)
+	assert.InDelta(t, 6.0/14, momentum.TeamBServePointWinRate, 1e-9)
+	assert.Greater(t, momentum.InitialWinProbability, 0.5)
+
+	require.Len(t, momentum.Points, 8)
+	shots, err := f.shots.FindByMatchUpID(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	pointEnds := make(map[primitive.ObjectID]bool)
+	for _, shot := range shots {
+		pointEnds[shot.ID] = shot.MatchStateAfterShot.PointCompleted
+	}
+	flagged := 0
+	previous := momentum.InitialWinProbability
+	for i, point := range momentum.Points {
+		assert.Equal(t, i+1, point.PointNumber)
+		assert.Equal(t, model.TeamSideTeamA, point.PointWinner)
+		assert.True(t, pointEnds[point.ShotID])
+		assert.Greater(t, point.WinProbability, previous)
+		assert.InDelta(t, point.WinProbability-previous, point.Swing, 1e-9)
+		previous = point.WinProbability
+		if point.IsBiggestSwing {
+			flagged++
+		}
+	}
+	assert.Equal(t, 1.0, previous)
+	assert.Equal(t, 5, flagged)
+}
+
+func TestMatchMomentumInProgress(t *testing.T) {
+	f := newFixture(t)
+
+	momentum, err := f.service.GetMatchMomentum(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Empty(t, momentum.Points)
+	// Evenly matched until a point is played
+	assert.InDelta(t, 0.5, momentum.InitialWinProbability, 1e-6)
+
+	f.winPoint(t, f.playerB)
+	momentum, err = f.service.GetMatchMomentum(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	require.Len(t, momentum.Points, 1)
+	assert.Less(t, momentum.Points[0].Swing, 0.0)
+	assert.True(t, momentum.Points[0].IsBiggestSwing)
+}
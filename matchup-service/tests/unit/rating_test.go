@@ -0,0 +1,150 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/rating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// userRating returns a user's current rating, failing if they have none
+func (f *fixture) userRating(t *testing.T, userID primitive.ObjectID) int {
+	t.Helper()
+	value, err := f.service.GetRating(f.ctx, userID)
+	require.NoError(t, err)
+	require.NotNil(t, value, "user has no rating")
+	return *value
+}
+
+// unrated asserts that a user has no current rating
+func (f *fixture) unrated(t *testing.T, userID primitive.ObjectID) {
+	t.Helper()
+	value, err := f.service.GetRating(f.ctx, userID)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestCompletedMatchUpdatesRatings(t *testing.T) {
+	f := newFixture(t)
+	f.playMatch(t, f.playerB, shortSetFormat(), f.playerA, f.playerA)
+
+	history, err := f.service.GetRatingHistory(f.ctx, f.playerA, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	change := history[0]
+	assert.Equal(t, f.matchUp.ID, change.MatchUpID)
+	assert.True(t, change.Won)
+	assert.Equal(t, rating.Initial, change.RatingBefore)
+	assert.Equal(t, rating.Initial, change.OpponentRating)
+	assert.Equal(t, 1, change.MatchesRated)
+	assert.Greater(t, change.Change, 0.0)
+
+	assert.Equal(t, 1524, f.userRating(t, f.playerA))
+	assert.Equal(t, 1476, f.userRating(t, f.playerB))
+
+	// Undoing the deciding point reopens the match and takes the rating back
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.MatchUpStatusInProgress, f.reload(t).MatchUpStatus)
+	history, err = f.service.GetRatingHistory(f.ctx, f.playerA, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+	f.unrated(t, f.playerA)
+
+	// Playing it again rates it once more
+	_, err = f.service.RedoShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1524, f.userRating(t, f.playerA))
+
+	// The next match builds on the new ratings
+	f.playMatch(t, f.playerB, shortSetFormat(), f.playerA, f.playerB)
+	history, err = f.service.GetRatingHistory(f.ctx, f.playerB, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.InDelta(t, history[1].RatingAfter, history[0].RatingBefore, 1e-9)
+	assert.Equal(t, 2, history[0].MatchesRated)
+}
+
+func TestRetiredMatchIsRated(t *testing.T) {
+	f := newFixture(t)
+	f.winPoint(t, f.playerB)
+
+	retiring := model.TeamSideTeamB
+	_, err := f.setStatus(model.MatchUpStatusRetired, &retiring)
+	require.NoError(t, err)
+
+	assert.Greater(t, f.userRating(t, f.playerA), 1500)
+	assert.Less(t, f.userRating(t, f.playerB), 1500)
+}
+
+func TestMatchAgainstGuestIsNotRated(t *testing.T) {
+	f := newGuestFixture(t)
+	for f.reload(t).MatchUpStatus == model.MatchUpStatusInProgress {
+		f.winPoint(t, f.playerA)
+	}
+
+	history, err := f.service.GetRatingHistory(f.ctx, f.playerA, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+	f.unrated(t, f.playerA)
+}
+
+func TestRebuildRatingsReplaysHistory(t *testing.T) {
+	f := newFixture(t)
+	playerC := primitive.NewObjectID()
+	f.playMatch(t, f.playerB, shortSetFormat(), f.playerA, f.playerA)
+	f.playMatch(t, playerC, shortSetFormat(), playerC, playerC)
+	f.playMatch(t, f.playerB, matchTiebreakFormat(), f.playerB, f.playerB)
+
+	before, err := f.service.GetRatingHistory(f.ctx, f.playerA, nil, nil)
+	require.NoError(t, err)
+	ratingA := f.userRating(t, f.playerA)
+
+	rated, err := f.service.RebuildRatings(f.ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, rated)
+
+	after, err := f.service.GetRatingHistory(f.ctx, f.playerA, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, after, len(before))
+	for i := range before {
+		assert.Equal(t, before[i].MatchUpID, after[i].MatchUpID)
+		assert.InDelta(t, before[i].RatingAfter, after[i].RatingAfter, 1e-9)
+	}
+	assert.Equal(t, ratingA, f.userRating(t, f.playerA))
+
+	limit := 1
+	latest, err := f.service.GetRatingHistory(f.ctx, f.playerA, &limit, nil)
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	assert.Equal(t, after[0].ID, latest[0].ID)
+}
+
+func TestMatchStartedOverInvitationsIsNotRated(t *testing.T) {
+	f := newScheduledFixture(t)
+	f.matchUp = f.initiate(t, f.playerB)
+	override := true
+	_, err := f.service.UpdateMatchUpStatus(f.ctx, model.UpdateMatchUpStatusInput{
+		MatchUpID:           f.matchUp.ID,
+		Status:              model.MatchUpStatusInProgress,
+		OverrideInvitations: &override,
+	})
+	require.NoError(t, err)
+	f.winPoint(t, f.playerA)
+
+	retiring := model.TeamSideTeamB
+	_, err = f.setStatus(model.MatchUpStatusRetired, &retiring)
+	require.NoError(t, err)
+
+	// Player B never agreed to the match, so neither side is rated
+	f.unrated(t, f.playerA)
+	f.unrated(t, f.playerB)
+
+	rated, err := f.service.RebuildRatings(f.ctx)
+	require.NoError(t, err)
+	assert.Zero(t, rated)
+	f.unrated(t, f.playerA)
+}
@@ -0,0 +1,314 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/services"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fixture bundles a service backed by in-memory repositories and a singles matchup
+type fixture struct {
+	ctx          context.Context
+	service      *services.MatchUpService
+	matchups     *mocks.MatchupsRepository
+	shots        *mocks.ShotsRepository
+	presets      *mocks.FormatPresetsRepository
+	claims       *mocks.GuestClaimsRepository
+	ratings      *mocks.RatingsRepository
+	tournaments  *mocks.TournamentsRepository
+	transactions *mocks.Transactor
+	matchUp      *model.MatchUp
+	playerA      primitive.ObjectID
+	playerB      primitive.ObjectID
+}
+
+// standardFormat is best of three advantage sets with a 7 point tiebreak at 6-6
+func standardFormat() *model.MatchUpFormatInput {
+	return &model.MatchUpFormatInput{
+		NumberOfSets: 3,
+		SetFormat: &model.SetFormatInput{
+			NumberOfGames: 6,
+			DeuceType:     model.DeuceTypeNormalDeuce,
+			MustWinByTwo:  false,
+			TiebreakFormat: &model.TiebreakFormatInput{
+				Points:       7,
+				MustWinByTwo: true,
+				TiebreakAt:   6,
+			},
+		},
+	}
+}
+
+// newFixture creates a singles matchup that has already been started
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := newScheduledFixture(t)
+
+	matchUp, err := f.service.UpdateMatchUpStatus(f.ctx, model.UpdateMatchUpStatusInput{
+		MatchUpID: f.matchUp.ID,
+		Status:    model.MatchUpStatusInProgress,
+	})
+	require.NoError(t, err)
+	f.matchUp = matchUp
+
+	return f
+}
+
+// newScheduledFixture creates a singles matchup that has not started yet.
+// Player B has already accepted the invitation to play.
+func newScheduledFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	owner := primitive.NewObjectID()
+	f := &fixture{
+		ctx:         mocks.ContextWithMongoID(owner),
+		matchups:    mocks.NewMatchupsRepository(),
+		shots:       mocks.NewShotsRepository(),
+		presets:     mocks.NewFormatPresetsRepository(),
+		claims:      mocks.NewGuestClaimsRepository(),
+		ratings:     mocks.NewRatingsRepository(),
+		tournaments: mocks.NewTournamentsRepository(),
+		playerA:     owner,
+		playerB:     primitive.NewObjectID(),
+	}
+	f.transactions = mocks.NewTransactor(f.matchups, f.shots, f.presets, f.claims, f.ratings, f.tournaments)
+	f.service = services.NewMatchUpService(f.matchups, f.shots, f.presets, f.claims, f.ratings, f.tournaments, f.transactions)
+
+	matchUp, err := f.service.InitiateMatchUp(f.ctx, model.InitiateMatchUpInput{
+		MatchUpType:   model.MatchUpTypeSingles,
+		MatchUpFormat: standardFormat(),
+		Participants: []*model.ParticipantInput{
+			{ID: &f.playerA, DisplayedName: "Player A", TeamSide: model.TeamSideTeamA},
+			{ID: &f.playerB, DisplayedName: "Player B", TeamSide: model.TeamSideTeamB},
+		},
+		MatchUpTracker: owner,
+		InitialServer:  f.playerA,
+	})
+	require.NoError(t, err)
+	f.matchUp = matchUp
+	f.acceptInvitations(t)
+
+	return f
+}
+
+// shot records a shot for the fixture's matchup
+func (f *fixture) shot(t *testing.T, hitter primitive.ObjectID, shotType model.ShotType, outcome model.ShotOutcome) *model.MatchUpShot {
+	t.Helper()
+	shot, err := f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:   f.matchUp.ID,
+		HitterID:    hitter,
+		ShotType:    shotType,
+		ShotOutcome: outcome,
+	})
+	require.NoError(t, err)
+	return shot
+}
+
+// ace records an ace by the given server
+func (f *fixture) ace(t *testing.T, server primitive.ObjectID) *model.MatchUpShot {
+	t.Helper()
+	return f.shot(t, server, model.ShotTypeServe, model.ShotOutcomeWonPoint)
+}
+
+// reload fetches the stored matchup
+func (f *fixture) reload(t *testing.T) *model.MatchUp {
+	t.Helper()
+	matchUp, err := f.matchups.FindByID(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	return matchUp
+}
+
+// inGameScore returns a side's point score in the current game
+func inGameScore(matchUp *model.MatchUp, side model.TeamSide) model.InGameScore {
+	set := matchUp.CurrentScore.Sets[len(matchUp.CurrentScore.Sets)-1]
+	for _, s := range set.Sides {
+		if s.Side == side {
+			return s.InGameScore
+		}
+	}
+	return ""
+}
+
+func TestAddShotScoresAce(t *testing.T) {
+	f := newFixture(t)
+
+	shot := f.ace(t, f.playerA)
+
+	assert.True(t, shot.MatchStateAfterShot.PointCompleted)
+	assert.Equal(t, model.TeamSideTeamA, *shot.MatchStateAfterShot.PointWinner)
+	assert.Equal(t, model.PointWinReasonAce, *shot.PointWinReason)
+	assert.Equal(t, f.playerA, shot.PointContext.ServerID)
+	assert.Equal(t, 1, shot.PointContext.PointNumber)
+
+	matchUp := f.reload(t)
+	assert.Equal(t, shot.ID, *matchUp.FirstShot)
+	assert.Equal(t, shot.ID, *matchUp.LastShot)
+	assert.Equal(t, model.InGameScoreFifteen, inGameScore(matchUp, model.TeamSideTeamA))
+}
+
+func TestAddShotDoubleFault(t *testing.T) {
+	f := newFixture(t)
+
+	first := f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeFirstFault)
+	assert.False(t, first.MatchStateAfterShot.PointCompleted)
+
+	second := f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeError)
+	assert.True(t, second.MatchStateAfterShot.PointCompleted)
+	assert.Equal(t, model.TeamSideTeamB, *second.MatchStateAfterShot.PointWinner)
+	assert.Equal(t, model.PointWinReasonDoubleFault, *second.PointWinReason)
+	assert.Equal(t, first.ID, *second.PrevShotID)
+
+	stored, err := f.shots.FindByID(f.ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, *stored.NextShotID)
+}
+
+func TestAddShotRejectsRallyWithoutServe(t *testing.T) {
+	f := newFixture(t)
+
+	_, err := f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:   f.matchUp.ID,
+		HitterID:    f.playerB,
+		ShotType:    model.ShotTypeGroundStroke,
+		ShotOutcome: model.ShotOutcomeWonPoint,
+	})
+	assert.Error(t, err)
+}
+
+func TestUndoAndRedo(t *testing.T) {
+	f := newFixture(t)
+
+	first := f.ace(t, f.playerA)
+	second := f.ace(t, f.playerA)
+	third := f.ace(t, f.playerA)
+
+	last, err := f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, last.ID)
+	assert.Equal(t, model.InGameScoreThirty, inGameScore(f.reload(t), model.TeamSideTeamA))
+
+	last, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, last.ID)
+
+	redone, err := f.service.RedoShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, redone.ID)
+
+	redone, err = f.service.RedoShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, third.ID, redone.ID)
+	assert.Equal(t, model.InGameScoreForty, inGameScore(f.reload(t), model.TeamSideTeamA))
+
+	_, err = f.service.RedoShot(f.ctx, f.matchUp.ID)
+	assert.Error(t, err)
+}
+
+func TestUndoEveryShot(t *testing.T) {
+	f := newFixture(t)
+
+	first := f.ace(t, f.playerA)
+
+	last, err := f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Nil(t, last)
+
+	matchUp := f.reload(t)
+	assert.Nil(t, matchUp.LastShot)
+	assert.Equal(t, model.InGameScoreZero, inGameScore(matchUp, model.TeamSideTeamA))
+
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	assert.Error(t, err)
+
+	redone, err := f.service.RedoShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, redone.ID)
+}
+
+func TestAddShotTruncatesRedoBranch(t *testing.T) {
+	f := newFixture(t)
+
+	first := f.ace(t, f.playerA)
+	f.ace(t, f.playerA)
+	f.ace(t, f.playerA)
+
+	_, err := f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	replacement := f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeFirstFault)
+	assert.Equal(t, first.ID, *replacement.PrevShotID)
+	assert.Equal(t, 2, f.shots.Count())
+
+	_, err = f.service.RedoShot(f.ctx, f.matchUp.ID)
+	assert.Error(t, err)
+}
+
+func TestServerRotatesAfterGame(t *testing.T) {
+	f := newFixture(t)
+
+	first := f.ace(t, f.playerA)
+	assert.Equal(t, model.ServiceBoxSideDeuceSide, *first.ServiceBoxSide)
+	second := f.ace(t, f.playerA)
+	assert.Equal(t, model.ServiceBoxSideAdSide, second.PointContext.ServiceBoxSide)
+	f.ace(t, f.playerA)
+	f.ace(t, f.playerA)
+
+	matchUp := f.reload(t)
+	assert.Equal(t, f.playerB, matchUp.CurrentServer)
+	assert.Equal(t, model.ServiceBoxSideDeuceSide, matchUp.CurrentServiceBoxSide)
+	assert.Equal(t, model.PhysicalCourtSideSouthSide, matchUp.CourtSides[0].CourtSide)
+
+	_, err := f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:   f.matchUp.ID,
+		HitterID:    f.playerA,
+		ShotType:    model.ShotTypeServe,
+		ShotOutcome: model.ShotOutcomeWonPoint,
+	})
+	assert.Error(t, err)
+
+	shot := f.ace(t, f.playerB)
+	assert.Equal(t, f.playerB, shot.PointContext.ServerID)
+	assert.Equal(t, model.PhysicalCourtSideNorthSide, shot.PointContext.ServerCourtSide)
+
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, f.playerA, f.reload(t).CurrentServer)
+}
+
+func TestAddShotRejectsWrongServiceBox(t *testing.T) {
+	f := newFixture(t)
+
+	adSide := model.ServiceBoxSideAdSide
+	_, err := f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:      f.matchUp.ID,
+		HitterID:       f.playerA,
+		ShotType:       model.ShotTypeServe,
+		ServiceBoxSide: &adSide,
+		ShotOutcome:    model.ShotOutcomeWonPoint,
+	})
+	assert.Error(t, err)
+}
+
+func TestPointImportanceIsTracked(t *testing.T) {
+	f := newFixture(t)
+
+	f.ace(t, f.playerA)
+	f.ace(t, f.playerA)
+	assert.Equal(t, model.PointImportanceRegular, f.reload(t).NextPointImportance)
+
+	f.ace(t, f.playerA)
+	assert.Equal(t, model.PointImportanceGamePoint, f.reload(t).NextPointImportance)
+
+	shot := f.ace(t, f.playerA)
+	assert.Equal(t, model.PointImportanceGamePoint, shot.PointImportance)
+}
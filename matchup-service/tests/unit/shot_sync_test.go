@@ -0,0 +1,144 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// offlineShot builds a shot recorded offline with the given idempotency key
+func (f *fixture) offlineShot(key string, hitter primitive.ObjectID, shotType model.ShotType, outcome model.ShotOutcome) *model.AddShotInput {
+	return &model.AddShotInput{
+		MatchUpID:    f.matchUp.ID,
+		HitterID:     hitter,
+		ShotType:     shotType,
+		ShotOutcome:  outcome,
+		ClientShotID: &key,
+	}
+}
+
+// offlinePoints is an ace followed by a rally the receiver wins
+func (f *fixture) offlinePoints() []*model.AddShotInput {
+	return []*model.AddShotInput{
+		f.offlineShot("k1", f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint),
+		f.offlineShot("k2", f.playerA, model.ShotTypeServe, model.ShotOutcomeContinuedRally),
+		f.offlineShot("k3", f.playerB, model.ShotTypeGroundStroke, model.ShotOutcomeWonPoint),
+	}
+}
+
+func TestSyncShotsAppliesBatchInOrder(t *testing.T) {
+	f := newFixture(t)
+
+	result, err := f.service.SyncShots(f.ctx, f.matchUp.ID, f.offlinePoints(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Skipped)
+	require.Len(t, result.Shots, 3)
+	assert.Equal(t, "k1", *result.Shots[0].ClientShotID)
+	assert.Equal(t, result.Shots[2].ID, *result.MatchUp.LastShot)
+	assert.Equal(t, model.InGameScoreFifteen, inGameScore(result.MatchUp, model.TeamSideTeamA))
+	assert.Equal(t, model.InGameScoreFifteen, inGameScore(result.MatchUp, model.TeamSideTeamB))
+}
+
+func TestSyncShotsRetryIsIdempotent(t *testing.T) {
+	f := newFixture(t)
+	batch := f.offlinePoints()
+
+	first, err := f.service.SyncShots(f.ctx, f.matchUp.ID, batch[:2], nil)
+	require.NoError(t, err)
+
+	// The retry resends everything, against the same base
+	retry, err := f.service.SyncShots(f.ctx, f.matchUp.ID, batch, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, retry.Skipped)
+	require.Len(t, retry.Shots, 3)
+	assert.Equal(t, first.Shots[0].ID, retry.Shots[0].ID)
+	assert.Equal(t, first.Shots[1].ID, retry.Shots[1].ID)
+	assert.Equal(t, 3, f.shots.Count())
+
+	// A full repeat changes nothing
+	again, err := f.service.SyncShots(f.ctx, f.matchUp.ID, batch, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, again.Skipped)
+	assert.Equal(t, 3, f.shots.Count())
+}
+
+func TestSyncShotsReportsConflict(t *testing.T) {
+	f := newFixture(t)
+	base := f.ace(t, f.playerA)
+
+	// Another device recorded a point the client hasn't seen
+	f.ace(t, f.playerA)
+
+	_, err := f.service.SyncShots(f.ctx, f.matchUp.ID, []*model.AddShotInput{
+		f.offlineShot("k1", f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint),
+	}, &base.ID)
+	require.Error(t, err)
+	assert.True(t, sharedErrors.IsConflictError(err))
+	assert.Equal(t, 2, f.shots.Count())
+
+	// A base the match doesn't have is a conflict too
+	unknown := primitive.NewObjectID()
+	_, err = f.service.SyncShots(f.ctx, f.matchUp.ID, []*model.AddShotInput{
+		f.offlineShot("k1", f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint),
+	}, &unknown)
+	assert.True(t, sharedErrors.IsConflictError(err))
+}
+
+func TestSyncShotsValidatesBatch(t *testing.T) {
+	f := newFixture(t)
+
+	missingKey := f.offlineShot("k1", f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint)
+	missingKey.ClientShotID = nil
+	_, err := f.service.SyncShots(f.ctx, f.matchUp.ID, []*model.AddShotInput{missingKey}, nil)
+	assert.True(t, sharedErrors.IsValidationError(err))
+
+	_, err = f.service.SyncShots(f.ctx, f.matchUp.ID, []*model.AddShotInput{
+		f.offlineShot("k1", f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint),
+		f.offlineShot("k1", f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint),
+	}, nil)
+	assert.True(t, sharedErrors.IsValidationError(err))
+	assert.Equal(t, 0, f.shots.Count())
+}
+
+func TestSyncShotsAppliesBatchAsAWhole(t *testing.T) {
+	f := newFixture(t)
+	batch := []*model.AddShotInput{
+		f.offlineShot("k1", f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint),
+		f.offlineShot("k2", f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint),
+	}
+
+	// The base check and every shot share one transaction
+	transactions := f.transactions.Count()
+	result, err := f.service.SyncShots(f.ctx, f.matchUp.ID, batch, nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.InGameScoreThirty, inGameScore(result.MatchUp, model.TeamSideTeamA))
+	assert.Equal(t, transactions+1, f.transactions.Count())
+
+	// So a shot that fails takes the shots before it down with it
+	transactions = f.transactions.Count()
+	_, err = f.service.SyncShots(f.ctx, f.matchUp.ID, []*model.AddShotInput{
+		f.offlineShot("k3", f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint),
+		// Player B can't serve the next point
+		f.offlineShot("k4", f.playerB, model.ShotTypeServe, model.ShotOutcomeWonPoint),
+	}, result.MatchUp.LastShot)
+	require.Error(t, err)
+	assert.Equal(t, transactions+1, f.transactions.Count())
+}
+
+func TestAddShotWithClientShotIDIsIdempotent(t *testing.T) {
+	f := newFixture(t)
+	input := f.offlineShot("k1", f.playerA, model.ShotTypeServe, model.ShotOutcomeWonPoint)
+
+	first, err := f.service.AddShot(f.ctx, *input)
+	require.NoError(t, err)
+	second, err := f.service.AddShot(f.ctx, *input)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, 1, f.shots.Count())
+	assert.Equal(t, model.InGameScoreFifteen, inGameScore(f.reload(t), model.TeamSideTeamA))
+}
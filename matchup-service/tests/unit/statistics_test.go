@@ -0,0 +1,105 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playBrokenServiceGame has player A lose serve from 15-40, saving one break point first
+func playBrokenServiceGame(t *testing.T, f *fixture) {
+	t.Helper()
+	serve, stroke := model.ShotTypeServe, model.ShotTypeGroundStroke
+
+	f.ace(t, f.playerA)                                      // 15-0
+	f.shot(t, f.playerA, serve, model.ShotOutcomeFirstFault) // double fault
+	f.shot(t, f.playerA, serve, model.ShotOutcomeError)      // 15-15
+	f.shot(t, f.playerA, serve, model.ShotOutcomeContinuedRally)
+	f.shot(t, f.playerB, stroke, model.ShotOutcomeWonPoint) // 15-30
+	f.shot(t, f.playerA, serve, model.ShotOutcomeContinuedRally)
+	f.shot(t, f.playerB, stroke, model.ShotOutcomeWonPoint) // 15-40
+
+	// Break point saved with a second serve that forces an error
+	f.shot(t, f.playerA, serve, model.ShotOutcomeFirstFault)
+	f.shot(t, f.playerA, serve, model.ShotOutcomeContinuedRally)
+	forced := model.PointWinReasonForcedError
+	_, err := f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:      f.matchUp.ID,
+		HitterID:       f.playerB,
+		ShotType:       stroke,
+		ShotOutcome:    model.ShotOutcomeError,
+		PointWinReason: &forced,
+	})
+	require.NoError(t, err) // 30-40
+
+	// Break point converted
+	f.shot(t, f.playerA, serve, model.ShotOutcomeContinuedRally)
+	f.shot(t, f.playerB, stroke, model.ShotOutcomeWonPoint)
+}
+
+func TestMatchStatistics(t *testing.T) {
+	f := newFixture(t)
+	playBrokenServiceGame(t, f)
+
+	stats, err := f.service.GetMatchStatistics(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, stats.TotalPoints)
+	assert.Equal(t, 1, stats.TotalGames)
+	assert.Equal(t, 1, stats.TotalSets)
+	assert.Nil(t, stats.DurationMillis)
+	require.Len(t, stats.TeamStats, 2)
+
+	teamA, teamB := stats.TeamStats[0], stats.TeamStats[1]
+	assert.Equal(t, model.TeamSideTeamA, teamA.TeamSide)
+	assert.Equal(t, 2, teamA.PointsWon)
+	assert.Equal(t, 1, *teamA.Aces)
+	assert.Equal(t, 1, *teamA.DoubleFaults)
+	assert.Equal(t, 1, *teamA.ForcedErrorsInduced)
+	assert.Equal(t, 2, teamA.BreakPointsFaced)
+	assert.Equal(t, 1, teamA.BreakPointsSaved)
+	require.NotNil(t, teamA.FirstServePercentage)
+	assert.InDelta(t, 66.67, *teamA.FirstServePercentage, 0.01)
+	require.NotNil(t, teamA.SecondServePercentage)
+	assert.InDelta(t, 50.0, *teamA.SecondServePercentage, 0.01)
+
+	assert.Equal(t, 4, teamB.PointsWon)
+	assert.Equal(t, 1, teamB.GamesWon)
+	assert.Equal(t, 3, *teamB.Winners)
+	assert.Equal(t, 2, teamB.BreakPointOpportunities)
+	assert.Equal(t, 1, teamB.BreakPointsConverted)
+	assert.Nil(t, teamB.FirstServePercentage)
+}
+
+func TestPlayerStatistics(t *testing.T) {
+	f := newFixture(t)
+	playBrokenServiceGame(t, f)
+
+	stats, err := f.service.GetPlayerStatistics(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	playerA, playerB := stats[0], stats[1]
+	assert.Equal(t, f.playerA, playerA.PlayerID)
+	assert.Equal(t, 2, playerA.PointsWon)
+	assert.Equal(t, 1, *playerA.ForcedErrorsInduced)
+	assert.Equal(t, 2, playerA.BreakPointsFaced)
+	assert.Equal(t, 3, playerB.PointsWon)
+	assert.Equal(t, 3, *playerB.Winners)
+}
+
+func TestStatisticsIgnoreUndoneShots(t *testing.T) {
+	f := newFixture(t)
+	f.ace(t, f.playerA)
+	f.ace(t, f.playerA)
+
+	_, err := f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	stats, err := f.service.GetMatchStatistics(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.TotalPoints)
+	assert.Equal(t, 1, *stats.TeamStats[0].Aces)
+}
@@ -0,0 +1,110 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/internal/scoring"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchStateAt(t *testing.T) {
+	f := newFixture(t)
+	playBrokenServiceGame(t, f)
+	shots, err := f.shots.FindByMatchUpID(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	require.Len(t, shots, 12)
+
+	// At 15-40, break point is pending
+	state, err := f.service.GetMatchStateAt(f.ctx, f.matchUp.ID, shots[6].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 7, state.ShotNumber)
+	assert.True(t, state.PointCompleted)
+	assert.Equal(t, model.TeamSideTeamB, *state.PointWinner)
+	assert.Equal(t, model.InGameScoreFifteen, scoring.SideScore(scoring.CurrentSet(state.Score), model.TeamSideTeamA).InGameScore)
+	assert.Equal(t, model.InGameScoreForty, scoring.SideScore(scoring.CurrentSet(state.Score), model.TeamSideTeamB).InGameScore)
+	assert.Equal(t, 4, state.PointContext.PointNumber)
+	assert.Equal(t, f.playerA, state.CurrentServer)
+	assert.Equal(t, model.PointImportanceBreakPoint, state.PendingImportance)
+	assert.Equal(t, model.ServiceBoxSideDeuceSide, state.CurrentServiceBoxSide)
+	assert.True(t, state.MatchesStoredState)
+
+	// A first fault leaves the point being played
+	state, err = f.service.GetMatchStateAt(f.ctx, f.matchUp.ID, shots[7].ID)
+	require.NoError(t, err)
+	assert.False(t, state.PointCompleted)
+	assert.Nil(t, state.PointWinner)
+	assert.Equal(t, 5, state.PointContext.PointNumber)
+	assert.Equal(t, model.PointImportanceBreakPoint, state.PendingImportance)
+
+	// After the break the receiver serves the next game
+	state, err = f.service.GetMatchStateAt(f.ctx, f.matchUp.ID, shots[11].ID)
+	require.NoError(t, err)
+	assert.True(t, state.GameCompleted)
+	assert.Equal(t, f.playerB, state.CurrentServer)
+	assert.Equal(t, model.PointImportanceRegular, state.PendingImportance)
+	assert.True(t, scoring.SameScore(f.reload(t).CurrentScore, state.Score))
+
+	// A stored state that disagrees with the replay is flagged, and the
+	// replayed state is returned
+	stored := shots[4]
+	stored.MatchStateAfterShot.Score = scoring.CloneScore(shots[11].MatchStateAfterShot.Score)
+	_, err = f.shots.Update(f.ctx, stored)
+	require.NoError(t, err)
+	state, err = f.service.GetMatchStateAt(f.ctx, f.matchUp.ID, stored.ID)
+	require.NoError(t, err)
+	assert.False(t, state.MatchesStoredState)
+	assert.Equal(t, 0, scoring.GamesPlayed(scoring.CurrentSet(state.Score)))
+}
+
+func TestMatchStateAtUndoneShot(t *testing.T) {
+	f := newFixture(t)
+	f.ace(t, f.playerA)
+	undone := f.ace(t, f.playerA)
+	_, err := f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	_, err = f.service.GetMatchStateAt(f.ctx, f.matchUp.ID, undone.ID)
+	assert.True(t, sharedErrors.IsNotFoundError(err))
+}
+
+func TestMatchTimeline(t *testing.T) {
+	f := newFixture(t)
+	playBrokenServiceGame(t, f)
+	f.ace(t, f.playerB)
+
+	timeline, err := f.service.GetMatchTimeline(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	require.Len(t, timeline, 8)
+
+	for i, entry := range timeline[:6] {
+		assert.Equal(t, model.MatchTimelineEntryTypePoint, entry.Type)
+		assert.Equal(t, 1, entry.GameNumber)
+		assert.Equal(t, i+1, entry.PointNumber)
+		assert.Equal(t, model.TeamSideTeamA, entry.ServerSide)
+	}
+	assert.Equal(t, "0-0 15-0", timeline[0].Score)
+	assert.Equal(t, 1, timeline[0].ShotNumber)
+
+	saved := timeline[4]
+	assert.Equal(t, model.TeamSideTeamA, saved.Winner)
+	assert.Equal(t, model.PointImportanceBreakPoint, saved.Importance)
+	assert.Equal(t, "0-0 30-40", saved.Score)
+
+	game := timeline[6]
+	assert.Equal(t, model.MatchTimelineEntryTypeGame, game.Type)
+	assert.Equal(t, timeline[5].ShotID, game.ShotID)
+	assert.Equal(t, 12, game.ShotNumber)
+	assert.Equal(t, 6, game.PointNumber)
+	assert.Equal(t, model.TeamSideTeamB, game.Winner)
+	assert.Equal(t, model.PointImportanceBreakPoint, game.Importance)
+	assert.Equal(t, "0-1", game.Score)
+
+	next := timeline[7]
+	assert.Equal(t, model.MatchTimelineEntryTypePoint, next.Type)
+	assert.Equal(t, 2, next.GameNumber)
+	assert.Equal(t, model.TeamSideTeamB, next.ServerSide)
+	assert.Equal(t, "0-1 0-15", next.Score)
+}
@@ -0,0 +1,241 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/tests/mocks"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newTournament creates a singles tournament run by player A with an entry
+// for each player, seeded in the order given
+func (f *fixture) newTournament(t *testing.T, players ...primitive.ObjectID) *model.Tournament {
+	t.Helper()
+	tournament, err := f.service.CreateTournament(f.ctx, model.CreateTournamentInput{
+		Name:          "Club Championship",
+		MatchUpType:   model.MatchUpTypeSingles,
+		MatchUpFormat: shortSetFormat(),
+	})
+	require.NoError(t, err)
+
+	for i, player := range players {
+		seed := i + 1
+		tournament, err = f.service.AddTournamentEntry(f.ctx, model.AddTournamentEntryInput{
+			TournamentID: tournament.ID,
+			Players:      []*model.TournamentPlayerInput{{ID: player, DisplayedName: "Player"}},
+			Seed:         &seed,
+		})
+		require.NoError(t, err)
+	}
+	return tournament
+}
+
+// playDrawMatch plays a draw's match until the winner has taken every point
+func (f *fixture) playDrawMatch(t *testing.T, matchUpID, winner primitive.ObjectID) {
+	t.Helper()
+	f.matchUp = &model.MatchUp{ID: matchUpID}
+	_, err := f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+	for f.reload(t).MatchUpStatus == model.MatchUpStatusInProgress {
+		f.winPoint(t, winner)
+	}
+}
+
+// drawSlot returns a slot of a tournament's stored draw
+func (f *fixture) drawSlot(t *testing.T, tournament *model.Tournament, draw *model.Draw, slotID string) *model.DrawSlot {
+	t.Helper()
+	stored, err := f.service.GetTournamentDraw(f.ctx, tournament.ID, draw.ID)
+	require.NoError(t, err)
+	for _, slot := range stored.Slots {
+		if slot.ID == slotID {
+			return slot
+		}
+	}
+	t.Fatalf("draw has no slot %s", slotID)
+	return nil
+}
+
+func TestSingleEliminationDrawAdvancesWinners(t *testing.T) {
+	f := newScheduledFixture(t)
+	first, second, third := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	tournament := f.newTournament(t, first, second, third)
+
+	// The top seed has a bye, so only the semifinal is ready to play
+	draw, err := f.service.CreateDraw(f.ctx, model.CreateDrawInput{
+		TournamentID: tournament.ID,
+		Name:         "Men's Singles",
+		DrawType:     model.DrawTypeSingleElimination,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.DrawSlotStatusWalkover, f.drawSlot(t, tournament, draw, "MAIN-1-1").Status)
+	semifinal := f.drawSlot(t, tournament, draw, "MAIN-1-2")
+	require.Equal(t, model.DrawSlotStatusScheduled, semifinal.Status)
+
+	// Its match is played under the tournament's format by players who
+	// don't need to accept it
+	orderOfPlay, err := f.service.GetOrderOfPlay(f.ctx, tournament.ID)
+	require.NoError(t, err)
+	require.Len(t, orderOfPlay, 1)
+	matchUp := orderOfPlay[0].MatchUp
+	assert.Equal(t, *semifinal.MatchUpID, matchUp.ID)
+	assert.EqualValues(t, 1, matchUp.MatchUpFormat.NumberOfSets)
+	assert.Equal(t, &model.MatchUpDrawSlot{TournamentID: tournament.ID, DrawID: draw.ID, SlotID: "MAIN-1-2"}, matchUp.Tournament)
+	assert.Equal(t, model.InvitationStatusAccepted, *matchUp.Participants[0].InvitationStatus)
+
+	// The third seed wins and meets the top seed in the final
+	f.playDrawMatch(t, matchUp.ID, third)
+	final := f.drawSlot(t, tournament, draw, "MAIN-2-1")
+	require.Equal(t, model.DrawSlotStatusScheduled, final.Status)
+	orderOfPlay, err = f.service.GetOrderOfPlay(f.ctx, tournament.ID)
+	require.NoError(t, err)
+	require.Len(t, orderOfPlay, 1)
+	assert.Equal(t, *final.MatchUpID, orderOfPlay[0].MatchUp.ID)
+	assert.ElementsMatch(t, []primitive.ObjectID{first, third}, []primitive.ObjectID{
+		orderOfPlay[0].MatchUp.Participants[0].ID,
+		orderOfPlay[0].MatchUp.Participants[1].ID,
+	})
+
+	// Undoing the deciding shot takes the result back and calls off the final
+	_, err = f.service.UndoLastShot(f.ctx, matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.DrawSlotStatusScheduled, f.drawSlot(t, tournament, draw, "MAIN-1-2").Status)
+	assert.Equal(t, model.DrawSlotStatusWaiting, f.drawSlot(t, tournament, draw, "MAIN-2-1").Status)
+	f.matchUp = &model.MatchUp{ID: *final.MatchUpID}
+	assert.Equal(t, model.MatchUpStatusCancelled, f.reload(t).MatchUpStatus)
+}
+
+func TestOrganiserAwardsWalkoverAfterCancelledDrawMatch(t *testing.T) {
+	f := newScheduledFixture(t)
+	first, second, third := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	tournament := f.newTournament(t, first, second, third)
+	draw, err := f.service.CreateDraw(f.ctx, model.CreateDrawInput{
+		TournamentID: tournament.ID,
+		Name:         "Men's Singles",
+		DrawType:     model.DrawTypeSingleElimination,
+	})
+	require.NoError(t, err)
+	semifinal := f.drawSlot(t, tournament, draw, "MAIN-1-2")
+	award := model.AwardDrawWalkoverInput{
+		TournamentID: tournament.ID,
+		DrawID:       draw.ID,
+		SlotID:       semifinal.ID,
+		Winner:       model.TeamSideTeamB,
+	}
+
+	// A match that can still be played decides its own slot
+	_, err = f.service.AwardDrawWalkover(f.ctx, award)
+	assert.True(t, sharedErrors.IsConflictError(err))
+
+	f.matchUp = &model.MatchUp{ID: *semifinal.MatchUpID}
+	_, err = f.setStatus(model.MatchUpStatusCancelled, nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.DrawSlotStatusScheduled, f.drawSlot(t, tournament, draw, "MAIN-1-2").Status)
+
+	// Only the organiser decides the slot
+	_, err = f.service.AwardDrawWalkover(mocks.ContextWithMongoID(third), award)
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+	missing := award
+	missing.SlotID = "MAIN-9-9"
+	_, err = f.service.AwardDrawWalkover(f.ctx, missing)
+	assert.True(t, sharedErrors.IsNotFoundError(err))
+
+	stored, err := f.service.AwardDrawWalkover(f.ctx, award)
+	require.NoError(t, err)
+	walkover := f.drawSlot(t, tournament, stored, "MAIN-1-2")
+	assert.Equal(t, model.DrawSlotStatusWalkover, walkover.Status)
+	assert.Equal(t, walkover.Sides[1].EntryID, walkover.Winner)
+
+	// The winner meets the top seed in the final
+	final := f.drawSlot(t, tournament, draw, "MAIN-2-1")
+	require.Equal(t, model.DrawSlotStatusScheduled, final.Status)
+	assert.Contains(t, []*primitive.ObjectID{final.Sides[0].EntryID, final.Sides[1].EntryID}, walkover.Winner)
+	orderOfPlay, err := f.service.GetOrderOfPlay(f.ctx, tournament.ID)
+	require.NoError(t, err)
+	require.Len(t, orderOfPlay, 1)
+	assert.Equal(t, *final.MatchUpID, orderOfPlay[0].MatchUp.ID)
+
+	// The slot is decided once
+	_, err = f.service.AwardDrawWalkover(f.ctx, award)
+	assert.True(t, sharedErrors.IsConflictError(err))
+}
+
+func TestRoundRobinDrawKeepsStandings(t *testing.T) {
+	f := newScheduledFixture(t)
+	players := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()}
+	tournament := f.newTournament(t, players...)
+
+	// Every match of a round robin can be played straight away
+	draw, err := f.service.CreateDraw(f.ctx, model.CreateDrawInput{
+		TournamentID: tournament.ID,
+		Name:         "Group A",
+		DrawType:     model.DrawTypeRoundRobin,
+	})
+	require.NoError(t, err)
+	orderOfPlay, err := f.service.GetOrderOfPlay(f.ctx, tournament.ID)
+	require.NoError(t, err)
+	require.Len(t, orderOfPlay, 3)
+
+	// The third seed wins both their matches
+	for _, entry := range orderOfPlay {
+		matchUp := entry.MatchUp
+		winner := matchUp.Participants[0].ID
+		for _, participant := range matchUp.Participants {
+			if participant.ID == players[2] {
+				winner = participant.ID
+			}
+		}
+		f.playDrawMatch(t, matchUp.ID, winner)
+	}
+
+	stored, err := f.service.GetTournamentDraw(f.ctx, tournament.ID, draw.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Standings, 3)
+	assert.Equal(t, draw.EntryIds[2], stored.Standings[0].EntryID)
+	assert.Equal(t, 2, stored.Standings[0].Won)
+	orderOfPlay, err = f.service.GetOrderOfPlay(f.ctx, tournament.ID)
+	require.NoError(t, err)
+	assert.Empty(t, orderOfPlay)
+}
+
+func TestTournamentEntriesAndDrawsAreChecked(t *testing.T) {
+	f := newScheduledFixture(t)
+	player := primitive.NewObjectID()
+	tournament := f.newTournament(t, player)
+
+	// Only the organiser enters players
+	entry := model.AddTournamentEntryInput{
+		TournamentID: tournament.ID,
+		Players:      []*model.TournamentPlayerInput{{ID: primitive.NewObjectID(), DisplayedName: "Player"}},
+	}
+	_, err := f.service.AddTournamentEntry(mocks.ContextWithMongoID(f.playerB), entry)
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+
+	// Players are entered once, and seeds are handed out once
+	_, err = f.service.AddTournamentEntry(f.ctx, model.AddTournamentEntryInput{
+		TournamentID: tournament.ID,
+		Players:      []*model.TournamentPlayerInput{{ID: player, DisplayedName: "Player"}},
+	})
+	assert.True(t, sharedErrors.IsConflictError(err))
+	seed := 1
+	entry.Seed = &seed
+	_, err = f.service.AddTournamentEntry(f.ctx, entry)
+	assert.True(t, sharedErrors.IsConflictError(err))
+
+	// A singles entry has one player
+	entry.Seed = nil
+	entry.Players = append(entry.Players, &model.TournamentPlayerInput{ID: primitive.NewObjectID(), DisplayedName: "Partner"})
+	_, err = f.service.AddTournamentEntry(f.ctx, entry)
+	assert.True(t, sharedErrors.IsValidationError(err))
+
+	// A draw needs two entries
+	_, err = f.service.CreateDraw(f.ctx, model.CreateDrawInput{
+		TournamentID: tournament.ID,
+		Name:         "Men's Singles",
+		DrawType:     model.DrawTypeCompass,
+	})
+	assert.True(t, sharedErrors.IsValidationError(err))
+}
@@ -0,0 +1,114 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/tests/mocks"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// withChecker puts an access checker in the fixture's context and returns a
+// context for another user backed by the same checker
+func (f *fixture) withChecker(checker *mocks.AccessChecker, userID primitive.ObjectID) context.Context {
+	f.ctx = mocks.ContextWithAccessChecker(f.ctx, checker)
+	return mocks.ContextWithAccessChecker(mocks.ContextWithMongoID(userID), checker)
+}
+
+// aceAs records an ace by player A on behalf of the given user
+func (f *fixture) aceAs(ctx context.Context) (*model.MatchUpShot, error) {
+	return f.service.AddShot(ctx, model.AddShotInput{
+		MatchUpID:   f.matchUp.ID,
+		HitterID:    f.playerA,
+		ShotType:    model.ShotTypeServe,
+		ShotOutcome: model.ShotOutcomeWonPoint,
+	})
+}
+
+func TestOnlyOwnerAndTrackersRecordShots(t *testing.T) {
+	f := newFixture(t)
+	coach := primitive.NewObjectID()
+	checker := mocks.NewAccessChecker()
+	coachCtx := f.withChecker(checker, coach)
+	playerCtx := mocks.ContextWithAccessChecker(mocks.ContextWithMongoID(f.playerB), checker)
+
+	// Playing in the match doesn't make someone a tracker
+	_, err := f.aceAs(coachCtx)
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+	_, err = f.aceAs(playerCtx)
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+
+	matchUp, err := f.service.GrantMatchUpTracker(f.ctx, f.matchUp.ID, coach)
+	require.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{coach}, matchUp.Trackers)
+
+	_, err = f.aceAs(coachCtx)
+	require.NoError(t, err)
+	_, err = f.service.UndoLastShot(coachCtx, f.matchUp.ID)
+	require.NoError(t, err)
+	_, err = f.service.RedoShot(coachCtx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	matchUp, err = f.service.RevokeMatchUpTracker(f.ctx, f.matchUp.ID, coach)
+	require.NoError(t, err)
+	assert.Empty(t, matchUp.Trackers)
+	_, err = f.service.UndoLastShot(coachCtx, f.matchUp.ID)
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+
+	// The owner never needs the role
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	// Without a checker to ask, nobody else can track
+	_, err = f.service.GrantMatchUpTracker(f.ctx, f.matchUp.ID, coach)
+	require.NoError(t, err)
+	_, err = f.aceAs(mocks.ContextWithMongoID(coach))
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+}
+
+func TestInitiateMatchUpGrantsItsTracker(t *testing.T) {
+	f := newScheduledFixture(t)
+	coach := primitive.NewObjectID()
+	coachCtx := f.withChecker(mocks.NewAccessChecker(), coach)
+
+	setup := f.setupFor(model.MatchUpTypeSingles)
+	setup.MatchUpTracker = coach
+	matchUp, err := f.service.InitiateMatchUp(f.ctx, *setup)
+	require.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{coach}, matchUp.Trackers)
+
+	f.matchUp = matchUp
+	f.acceptInvitations(t)
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+	_, err = f.aceAs(coachCtx)
+	require.NoError(t, err)
+}
+
+func TestChangingTrackers(t *testing.T) {
+	f := newFixture(t)
+	coach := primitive.NewObjectID()
+
+	// Roles can't be kept without an access checker
+	_, err := f.service.GrantMatchUpTracker(f.ctx, f.matchUp.ID, coach)
+	assert.Error(t, err)
+
+	coachCtx := f.withChecker(mocks.NewAccessChecker(), coach)
+	_, err = f.service.GrantMatchUpTracker(coachCtx, f.matchUp.ID, coach)
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+	_, err = f.service.GrantMatchUpTracker(f.ctx, f.matchUp.ID, f.playerA)
+	assert.True(t, sharedErrors.IsValidationError(err))
+	_, err = f.service.RevokeMatchUpTracker(f.ctx, f.matchUp.ID, coach)
+	assert.True(t, sharedErrors.IsNotFoundError(err))
+
+	// Granting twice keeps one entry
+	_, err = f.service.GrantMatchUpTracker(f.ctx, f.matchUp.ID, coach)
+	require.NoError(t, err)
+	matchUp, err := f.service.GrantMatchUpTracker(f.ctx, f.matchUp.ID, coach)
+	require.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{coach}, matchUp.Trackers)
+}
@@ -0,0 +1,283 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newTrackedFixture creates a started singles matchup tracked at the given style
+func newTrackedFixture(t *testing.T, style model.MatchUpTrackingStyle) *fixture {
+	t.Helper()
+	f := newFixture(t)
+
+	setup := f.setupFor(model.MatchUpTypeSingles)
+	setup.TrackingStyle = &style
+	matchUp, err := f.service.InitiateMatchUp(f.ctx, *setup)
+	require.NoError(t, err)
+	f.matchUp = matchUp
+	f.acceptInvitations(t)
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+
+	return f
+}
+
+// point records a point without its rally
+func (f *fixture) point(winner model.TeamSide, reason *model.PointWinReason, player *primitive.ObjectID) (*model.MatchUpShot, error) {
+	return f.service.AddPoint(f.ctx, model.AddPointInput{
+		MatchUpID:      f.matchUp.ID,
+		PointWinner:    winner,
+		PointWinReason: reason,
+		PlayerID:       player,
+	})
+}
+
+func TestAddPointScoresWithoutShots(t *testing.T) {
+	f := newFixture(t)
+	assert.Equal(t, model.MatchUpTrackingStyleBeginner, f.matchUp.TrackingStyle)
+
+	first, err := f.point(model.TeamSideTeamA, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.ShotTypePoint, first.ShotType)
+	assert.Equal(t, f.playerA, first.HitterID)
+	assert.Equal(t, f.playerA, first.PointContext.ServerID)
+
+	unforced := model.PointWinReasonUnforcedError
+	second, err := f.point(model.TeamSideTeamB, &unforced, nil)
+	require.NoError(t, err)
+	assert.Equal(t, f.playerA, second.HitterID)
+	assert.Equal(t, model.ShotOutcomeError, second.ShotOutcome)
+
+	matchUp := f.reload(t)
+	assert.Equal(t, model.InGameScoreFifteen, inGameScore(matchUp, model.TeamSideTeamA))
+	assert.Equal(t, model.InGameScoreFifteen, inGameScore(matchUp, model.TeamSideTeamB))
+
+	// Points undo like shots
+	_, err = f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.InGameScoreZero, inGameScore(f.reload(t), model.TeamSideTeamB))
+}
+
+func TestAddPointChecksReasonAgainstServer(t *testing.T) {
+	f := newFixture(t)
+	ace, doubleFault := model.PointWinReasonAce, model.PointWinReasonDoubleFault
+
+	_, err := f.point(model.TeamSideTeamB, &ace, nil)
+	assert.Error(t, err)
+	_, err = f.point(model.TeamSideTeamA, &doubleFault, nil)
+	assert.Error(t, err)
+	_, err = f.point(model.TeamSideTeamA, &ace, &f.playerB)
+	assert.Error(t, err)
+
+	shot, err := f.point(model.TeamSideTeamA, &ace, nil)
+	require.NoError(t, err)
+	assert.Equal(t, f.playerA, shot.HitterID)
+}
+
+func TestAddPointNeedsPlayerInDoubles(t *testing.T) {
+	f := newFixture(t)
+	partnerA, partnerB := primitive.NewObjectID(), primitive.NewObjectID()
+	matchUp, err := f.service.InitiateMatchUp(f.ctx, model.InitiateMatchUpInput{
+		MatchUpType:   model.MatchUpTypeDoubles,
+		MatchUpFormat: standardFormat(),
+		Participants: []*model.ParticipantInput{
+			{ID: &f.playerA, DisplayedName: "Player A", TeamSide: model.TeamSideTeamA},
+			{ID: &partnerA, DisplayedName: "Partner A", TeamSide: model.TeamSideTeamA},
+			{ID: &f.playerB, DisplayedName: "Player B", TeamSide: model.TeamSideTeamB},
+			{ID: &partnerB, DisplayedName: "Partner B", TeamSide: model.TeamSideTeamB},
+		},
+		MatchUpTracker: f.playerA,
+		InitialServer:  f.playerA,
+	})
+	require.NoError(t, err)
+	f.matchUp = matchUp
+	f.acceptInvitations(t)
+	_, err = f.setStatus(model.MatchUpStatusInProgress, nil)
+	require.NoError(t, err)
+
+	winner := model.PointWinReasonWinner
+	_, err = f.point(model.TeamSideTeamB, &winner, nil)
+	assert.Error(t, err)
+	_, err = f.point(model.TeamSideTeamB, &winner, &partnerA)
+	assert.Error(t, err)
+
+	shot, err := f.point(model.TeamSideTeamB, &winner, &partnerB)
+	require.NoError(t, err)
+	assert.Equal(t, partnerB, shot.HitterID)
+	assert.Equal(t, model.ShotOutcomeWonPoint, shot.ShotOutcome)
+}
+
+func TestAddPointOnlyForBeginner(t *testing.T) {
+	f := newTrackedFixture(t, model.MatchUpTrackingStyleIntermediate)
+
+	_, err := f.point(model.TeamSideTeamA, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestAddPointCannotInterruptRally(t *testing.T) {
+	f := newFixture(t)
+	f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeContinuedRally)
+
+	_, err := f.point(model.TeamSideTeamA, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestAddShotRejectsPointType(t *testing.T) {
+	f := newFixture(t)
+
+	_, err := f.service.AddShot(f.ctx, model.AddShotInput{
+		MatchUpID:   f.matchUp.ID,
+		HitterID:    f.playerA,
+		ShotType:    model.ShotTypePoint,
+		ShotOutcome: model.ShotOutcomeWonPoint,
+	})
+	assert.Error(t, err)
+}
+
+func TestTrackingStyleRequiresShotDetails(t *testing.T) {
+	forehand, topspin := model.GroundStrokeTypeForehand, model.GroundStrokeStyleTopspin
+	flat := model.ServeStyleFlat
+	unforced := model.PointWinReasonUnforcedError
+
+	t.Run("intermediate", func(t *testing.T) {
+		f := newTrackedFixture(t, model.MatchUpTrackingStyleIntermediate)
+		f.shot(t, f.playerA, model.ShotTypeServe, model.ShotOutcomeContinuedRally)
+
+		// Ground strokes need their type
+		_, err := f.service.AddShot(f.ctx, model.AddShotInput{
+			MatchUpID:   f.matchUp.ID,
+			HitterID:    f.playerB,
+			ShotType:    model.ShotTypeGroundStroke,
+			ShotOutcome: model.ShotOutcomeContinuedRally,
+		})
+		assert.Error(t, err)
+		_, err = f.service.AddShot(f.ctx, model.AddShotInput{
+			MatchUpID:        f.matchUp.ID,
+			HitterID:         f.playerB,
+			ShotType:         model.ShotTypeGroundStroke,
+			GroundStrokeType: &forehand,
+			ShotOutcome:      model.ShotOutcomeContinuedRally,
+		})
+		require.NoError(t, err)
+
+		// Rally errors need to say whether they were forced
+		_, err = f.service.AddShot(f.ctx, model.AddShotInput{
+			MatchUpID:   f.matchUp.ID,
+			HitterID:    f.playerA,
+			ShotType:    model.ShotTypeVolley,
+			ShotOutcome: model.ShotOutcomeError,
+		})
+		assert.Error(t, err)
+		_, err = f.service.AddShot(f.ctx, model.AddShotInput{
+			MatchUpID:      f.matchUp.ID,
+			HitterID:       f.playerA,
+			ShotType:       model.ShotTypeVolley,
+			ShotOutcome:    model.ShotOutcomeError,
+			PointWinReason: &unforced,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("advanced", func(t *testing.T) {
+		f := newTrackedFixture(t, model.MatchUpTrackingStyleAdvanced)
+
+		// Serves need their style
+		_, err := f.service.AddShot(f.ctx, model.AddShotInput{
+			MatchUpID:   f.matchUp.ID,
+			HitterID:    f.playerA,
+			ShotType:    model.ShotTypeServe,
+			ShotOutcome: model.ShotOutcomeContinuedRally,
+		})
+		assert.Error(t, err)
+		_, err = f.service.AddShot(f.ctx, model.AddShotInput{
+			MatchUpID:   f.matchUp.ID,
+			HitterID:    f.playerA,
+			ShotType:    model.ShotTypeServe,
+			ServeStyle:  &flat,
+			ShotOutcome: model.ShotOutcomeContinuedRally,
+		})
+		require.NoError(t, err)
+
+		// Ground strokes need their style as well as their type
+		_, err = f.service.AddShot(f.ctx, model.AddShotInput{
+			MatchUpID:        f.matchUp.ID,
+			HitterID:         f.playerB,
+			ShotType:         model.ShotTypeGroundStroke,
+			GroundStrokeType: &forehand,
+			ShotOutcome:      model.ShotOutcomeWonPoint,
+		})
+		assert.Error(t, err)
+		_, err = f.service.AddShot(f.ctx, model.AddShotInput{
+			MatchUpID:         f.matchUp.ID,
+			HitterID:          f.playerB,
+			ShotType:          model.ShotTypeGroundStroke,
+			GroundStrokeType:  &forehand,
+			GroundStrokeStyle: &topspin,
+			ShotOutcome:       model.ShotOutcomeWonPoint,
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestStatisticsDegradeForPointOnlyMatches(t *testing.T) {
+	f := newFixture(t)
+
+	// Two points that only say who won: counts that need the ending are unknown
+	_, err := f.point(model.TeamSideTeamA, nil, nil)
+	require.NoError(t, err)
+	_, err = f.point(model.TeamSideTeamB, nil, nil)
+	require.NoError(t, err)
+
+	stats, err := f.service.GetMatchStatistics(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.MatchUpTrackingStyleBeginner, stats.TrackingStyle)
+	assert.Equal(t, 2, stats.TotalPoints)
+	teamA := stats.TeamStats[0]
+	assert.Equal(t, 1, teamA.PointsWon)
+	assert.Nil(t, teamA.Aces)
+	assert.Nil(t, teamA.Winners)
+	assert.Nil(t, teamA.FirstServePercentage)
+
+	// One point with a reason makes the counts available
+	ace := model.PointWinReasonAce
+	_, err = f.point(model.TeamSideTeamA, &ace, nil)
+	require.NoError(t, err)
+
+	stats, err = f.service.GetMatchStatistics(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	teamA = stats.TeamStats[0]
+	require.NotNil(t, teamA.Aces)
+	assert.Equal(t, 1, *teamA.Aces)
+	assert.Equal(t, 0, *teamA.Winners)
+	assert.Nil(t, teamA.FirstServePercentage)
+
+	players, err := f.service.GetPlayerStatistics(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, players[0].PointsWon)
+}
+
+func TestChartingRoundTripOfPointOnlyMatch(t *testing.T) {
+	f := newFixture(t)
+	_, err := f.point(model.TeamSideTeamA, nil, nil)
+	require.NoError(t, err)
+	_, err = f.point(model.TeamSideTeamB, nil, nil)
+	require.NoError(t, err)
+	f.ace(t, f.playerA)
+
+	export, err := f.service.ExportMatchUp(f.ctx, f.matchUp.ID, model.MatchUpExportFormatCharting)
+	require.NoError(t, err)
+	assert.Contains(t, export.Content, "1,0,0,0,0,0-0,1,S,,1")
+	assert.Contains(t, export.Content, "2,0,0,0,0,15-0,1,R,,2")
+
+	imported, err := f.service.ImportMatchUp(f.ctx, model.ImportMatchUpInput{
+		Format:  model.MatchUpExportFormatCharting,
+		Content: export.Content,
+		MatchUp: f.guestSetupFor(model.MatchUpTypeSingles),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, f.reload(t).CurrentScore, imported.CurrentScore)
+}
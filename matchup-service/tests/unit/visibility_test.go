@@ -0,0 +1,223 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/graph/model"
+	"github.com/CourtIQ/courtiq-backend/matchup-service/tests/mocks"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/access"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
+	"github.com/CourtIQ/courtiq-backend/shared/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// viewer returns a context for a user as the server builds it, with the
+// access checker and the service's resource loader
+func (f *fixture) viewer(checker *mocks.AccessChecker, userID primitive.ObjectID) context.Context {
+	ctx := mocks.ContextWithAccessChecker(mocks.ContextWithMongoID(userID), checker)
+	return context.WithValue(ctx, access.ResourceLoaderContextKey, access.ResourceLoader(f.service.LoadAccessResource))
+}
+
+// statistics resolves matchStatistics through @accessControl(entityArg: "matchUpId")
+func (f *fixture) statistics(ctx context.Context) (interface{}, error) {
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Args: map[string]interface{}{"matchUpId": f.matchUp.ID},
+	})
+	return server.AccessControlDirective(ctx, nil, func(ctx context.Context) (interface{}, error) {
+		return f.service.GetMatchStatistics(ctx, f.matchUp.ID)
+	}, map[string]interface{}{"entityArg": "matchUpId"})
+}
+
+func TestVisibilityLimitsMatchQueries(t *testing.T) {
+	f := newFixture(t)
+	friend, coach := primitive.NewObjectID(), primitive.NewObjectID()
+	checker := mocks.NewAccessChecker()
+	checker.Grant(f.playerA, friend, access.AccessLevelFriends)
+	checker.Grant(f.playerA, coach, access.AccessLevelCoaches)
+	f.ace(t, f.playerA)
+
+	// A practice set stays with its players
+	assert.Equal(t, model.MatchUpVisibilityPrivate, f.matchUp.Visibility)
+	_, err := f.statistics(f.viewer(checker, friend))
+	assert.ErrorIs(t, err, access.ErrAccessDenied)
+	_, err = f.statistics(f.viewer(checker, f.playerB))
+	require.NoError(t, err)
+
+	// Shared with friends, coaches still can't see it
+	_, err = f.service.UpdateMatchUpVisibility(f.ctx, f.matchUp.ID, model.MatchUpVisibilityFriends)
+	require.NoError(t, err)
+	_, err = f.statistics(f.viewer(checker, friend))
+	require.NoError(t, err)
+	_, err = f.statistics(f.viewer(checker, coach))
+	assert.ErrorIs(t, err, access.ErrAccessDenied)
+
+	// Only the owner can share it
+	_, err = f.service.UpdateMatchUpVisibility(mocks.ContextWithMongoID(f.playerB), f.matchUp.ID, model.MatchUpVisibilityPublic)
+	assert.True(t, sharedErrors.IsForbiddenError(err))
+}
+
+func TestVisibilityFiltersReturnedMatchesAndShots(t *testing.T) {
+	f := newFixture(t)
+	stranger := primitive.NewObjectID()
+	ctx := f.viewer(mocks.NewAccessChecker(), stranger)
+	shot := f.ace(t, f.playerA)
+
+	// A shot follows its match, and a hidden one reads as missing
+	resolveShot := func(ctx context.Context) (interface{}, error) {
+		return shot, nil
+	}
+	args := map[string]interface{}{"redactWithNull": true}
+	res, err := server.AccessControlDirective(ctx, nil, resolveShot, args)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	// Hidden matches are left out of a list
+	public, err := f.service.UpdateMatchUpVisibility(f.ctx, f.initiate(t, f.playerB).ID, model.MatchUpVisibilityPublic)
+	require.NoError(t, err)
+	resolveMatchUps := func(ctx context.Context) (interface{}, error) {
+		return []*model.MatchUp{f.reload(t), public}, nil
+	}
+	res, err = server.AccessControlDirective(ctx, nil, resolveMatchUps, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{public.ID}, ids(res.([]*model.MatchUp)))
+
+	_, err = f.service.UpdateMatchUpVisibility(f.ctx, f.matchUp.ID, model.MatchUpVisibilityPublic)
+	require.NoError(t, err)
+	res, err = server.AccessControlDirective(ctx, nil, resolveShot, args)
+	require.NoError(t, err)
+	assert.Equal(t, shot.ID, res.(*model.MatchUpShot).ID)
+}
+
+// guarded resolves a field with the given arguments through @accessControl
+func guarded(ctx context.Context, fieldArgs, directiveArgs map[string]interface{}, resolve graphql.Resolver) (interface{}, error) {
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{Args: fieldArgs})
+	return server.AccessControlDirective(ctx, nil, resolve, directiveArgs)
+}
+
+func TestShotQueriesFollowMatchVisibility(t *testing.T) {
+	f := newFixture(t)
+	stranger := primitive.NewObjectID()
+	checker := mocks.NewAccessChecker()
+	first := f.ace(t, f.playerA)
+	f.ace(t, f.playerA)
+	_, err := f.service.UndoLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+
+	// Undone shots aren't part of the match
+	last, err := f.service.GetLastShot(f.ctx, f.matchUp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, last.ID)
+	shots, err := f.service.GetMatchUpShots(f.ctx, f.matchUp.ID, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, shots, 1)
+	assert.Equal(t, first.ID, shots[0].ID)
+	game, err := f.service.GetShotsByGame(f.ctx, f.matchUp.ID, 1, 1)
+	require.NoError(t, err)
+	assert.Len(t, game, 1)
+	game, err = f.service.GetShotsByGame(f.ctx, f.matchUp.ID, 1, 2)
+	require.NoError(t, err)
+	assert.Empty(t, game)
+
+	// The match's players see its shots, nobody else sees a private match's
+	matchShots := func(ctx context.Context) (interface{}, error) {
+		return f.service.GetMatchUpShots(ctx, f.matchUp.ID, nil, nil)
+	}
+	byMatch := map[string]interface{}{"matchUpId": f.matchUp.ID}
+	res, err := guarded(f.viewer(checker, f.playerB), byMatch, map[string]interface{}{"entityArg": "matchUpId"}, matchShots)
+	require.NoError(t, err)
+	assert.Len(t, res, 1)
+	_, err = guarded(f.viewer(checker, stranger), byMatch, map[string]interface{}{"entityArg": "matchUpId"}, matchShots)
+	assert.ErrorIs(t, err, access.ErrAccessDenied)
+
+	shotByID := func(ctx context.Context) (interface{}, error) {
+		return f.service.GetShotByID(ctx, first.ID)
+	}
+	res, err = guarded(f.viewer(checker, stranger), nil, map[string]interface{}{"redactWithNull": true}, shotByID)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestTournamentDrawsFollowTournamentVisibility(t *testing.T) {
+	f := newScheduledFixture(t)
+	player, friend, stranger := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	tournament := f.newTournament(t, player, primitive.NewObjectID())
+	draw, err := f.service.CreateDraw(f.ctx, model.CreateDrawInput{
+		TournamentID: tournament.ID,
+		Name:         "Men's Singles",
+		DrawType:     model.DrawTypeSingleElimination,
+	})
+	require.NoError(t, err)
+	checker := mocks.NewAccessChecker()
+	checker.Grant(tournament.Owner, friend, access.AccessLevelFriendsAndCoaches)
+
+	// The draw shows how the tournament's matches went, so it is only shown
+	// to its players and to viewers the organiser shares its matches with.
+	// Tournaments are private unless the organiser says otherwise.
+	drawSheet := func(ctx context.Context) (interface{}, error) {
+		return f.service.GetTournamentDraw(ctx, tournament.ID, draw.ID)
+	}
+	byTournament := map[string]interface{}{"tournamentId": tournament.ID}
+	args := map[string]interface{}{"entityArg": "tournamentId", "redactWithNull": true}
+	assert.Equal(t, model.MatchUpVisibilityPrivate, tournament.Visibility)
+	res, err := guarded(f.viewer(checker, friend), byTournament, args, drawSheet)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	// Tournaments stored before visibility could be chosen stay private
+	assert.Equal(t, access.AccessLevelPrivate, (&model.Tournament{}).AccessLevel())
+
+	stored, err := f.service.GetTournament(f.ctx, tournament.ID)
+	require.NoError(t, err)
+	stored.Visibility = model.MatchUpVisibilityFriendsAndCoaches
+	_, err = f.tournaments.Update(f.ctx, stored)
+	require.NoError(t, err)
+	for _, viewer := range []primitive.ObjectID{player, friend} {
+		res, err := guarded(f.viewer(checker, viewer), byTournament, args, drawSheet)
+		require.NoError(t, err)
+		assert.Equal(t, draw.ID, res.(*model.Draw).ID)
+	}
+	res, err = guarded(f.viewer(checker, stranger), byTournament, args, drawSheet)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	// and so is the tournament itself
+	byID := func(ctx context.Context) (interface{}, error) {
+		return f.service.GetTournament(ctx, tournament.ID)
+	}
+	res, err = guarded(f.viewer(checker, stranger), nil, map[string]interface{}{"redactWithNull": true}, byID)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestCareerStatsAndRatingHistoryFollowMatchVisibility(t *testing.T) {
+	f := newFixture(t)
+	friend, stranger := primitive.NewObjectID(), primitive.NewObjectID()
+	checker := mocks.NewAccessChecker()
+	checker.Grant(f.playerA, friend, access.AccessLevelFriends)
+	_, err := f.event(model.MatchEventTypeDefault, &f.playerB, nil)
+	require.NoError(t, err)
+
+	// A private match counts for its players only
+	for viewer, played := range map[primitive.ObjectID]int{f.playerB: 1, stranger: 0} {
+		career, err := f.service.GetCareerStats(f.viewer(checker, viewer), f.playerA, nil)
+		require.NoError(t, err)
+		assert.Equal(t, played, career.MatchesPlayed)
+		history, err := f.service.GetRatingHistory(f.viewer(checker, viewer), f.playerA, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, history, played)
+	}
+
+	// Shared with friends, it counts for them too
+	_, err = f.service.UpdateMatchUpVisibility(f.ctx, f.matchUp.ID, model.MatchUpVisibilityFriends)
+	require.NoError(t, err)
+	career, err := f.service.GetCareerStats(f.viewer(checker, friend), f.playerA, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, career.MatchesPlayed)
+	history, err := f.service.GetRatingHistory(f.viewer(checker, friend), f.playerA, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
@@ -62,6 +62,7 @@ type Query struct {
 // Represents a tennis court in MongoDB.
 // Fields that always come from Google Nearby Search or Place Details (place_id, name, geometry) are marked non-null.
 // Others are optional because you may not have done a full Place Details fetch yet.
+// Other services refer to a court by its id, e.g. where a matchup is played.
 type TennisCourt struct {
 	// Internal DB identifier.
 	ID primitive.ObjectID `json:"id" bson:"_id"`
@@ -101,6 +102,8 @@ type TennisCourt struct {
 	LastUpdated *time.Time `json:"lastUpdated,omitempty" bson:"lastUpdated,omitempty"`
 }
 
+func (TennisCourt) IsEntity() {}
+
 // Minimal tennis-court data for search results + some extras like city, country, rating, openNow.
 type TennisCourtSearchResult struct {
 	ID            primitive.ObjectID `json:"id" bson:"_id"`
@@ -0,0 +1,23 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.61
+
+import (
+	"context"
+
+	"github.com/CourtIQ/courtiq-backend/search-service/graph"
+	"github.com/CourtIQ/courtiq-backend/search-service/graph/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FindTennisCourtByID is the resolver for the findTennisCourtByID field.
+func (r *entityResolver) FindTennisCourtByID(ctx context.Context, id primitive.ObjectID) (*model.TennisCourt, error) {
+	return r.SearchService.GetTennisCourt(ctx, id)
+}
+
+// Entity returns graph.EntityResolver implementation.
+func (r *Resolver) Entity() graph.EntityResolver { return &entityResolver{r} }
+
+type entityResolver struct{ *Resolver }
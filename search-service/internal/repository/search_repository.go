@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -11,6 +12,7 @@ import (
 	"os"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
@@ -25,6 +27,7 @@ import (
 type SearchRepository interface {
 	SearchUsers(ctx context.Context, query string, excludeUserID primitive.ObjectID, limit, offset int) ([]*model.UserSearchResult, error)
 	SearchTennisCourts(ctx context.Context, query string, lat, lng float64, radius float64, limit, offset int) ([]*model.TennisCourtSearchResult, error)
+	GetTennisCourtByID(ctx context.Context, id primitive.ObjectID) (*model.TennisCourt, error)
 }
 
 type searchRepository struct {
@@ -71,6 +74,23 @@ func (r *searchRepository) SearchUsers(
 	return results, nil
 }
 
+// --------------------------
+// GET TENNIS COURT
+// --------------------------
+// GetTennisCourtByID loads a stored court. Other services reference courts by
+// ID, so a missing court is returned as nil rather than an error.
+func (r *searchRepository) GetTennisCourtByID(ctx context.Context, id primitive.ObjectID) (*model.TennisCourt, error) {
+	var court model.TennisCourt
+	err := r.tennisCourtsCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&court)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find court error: %w", err)
+	}
+	return &court, nil
+}
+
 // --------------------------
 // SEARCH TENNIS COURTS
 // --------------------------
@@ -11,6 +11,7 @@ import (
 	"github.com/CourtIQ/courtiq-backend/search-service/internal/repository"
 	"github.com/CourtIQ/courtiq-backend/shared/pkg/middleware"
 	"github.com/CourtIQ/courtiq-backend/shared/pkg/scalar"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // SearchService is an interface to keep your code testable & decoupled.
@@ -21,6 +22,8 @@ type SearchService interface {
 	SearchUsers(ctx context.Context, query string, limit *int, offset *int) ([]*model.UserSearchResult, error)
 
 	SearchTennisCourts(ctx context.Context, query string, limit *int, offset *int, near *scalar.GeoPoint) ([]*model.TennisCourtSearchResult, error)
+
+	GetTennisCourt(ctx context.Context, id primitive.ObjectID) (*model.TennisCourt, error)
 }
 
 type searchService struct {
@@ -182,3 +185,9 @@ func (s *searchService) SearchTennisCourts(
 	return courts, nil
 
 }
+
+// GetTennisCourt resolves a court referenced by another service, such as the
+// court a matchup is played on. Unknown courts resolve to nil.
+func (s *searchService) GetTennisCourt(ctx context.Context, id primitive.ObjectID) (*model.TennisCourt, error) {
+	return s.searchRepo.GetTennisCourtByID(ctx, id)
+}
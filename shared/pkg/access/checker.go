@@ -82,6 +82,12 @@ type Checker interface {
 	// GetRoles gets all roles a user has in relation to an entity
 	GetRoles(ctx context.Context, userID string, entityID string) ([]Role, error)
 
+	// GrantRole gives a user a role in relation to an entity
+	GrantRole(ctx context.Context, userID string, entityID string, role Role, grantedBy string) error
+
+	// RevokeRole takes a role in relation to an entity away from a user
+	RevokeRole(ctx context.Context, userID string, entityID string, role Role) error
+
 	// ClearCache clears cached access results
 	ClearCache(userIDs ...string)
 }
\ No newline at end of file
@@ -8,8 +8,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// entityRoleType is the relationship type that holds the roles a user was
+// granted on an entity rather than on another user
+const entityRoleType = "ENTITY_ROLE"
+
 // RelationshipInfo contains details about a relationship between users
 type RelationshipInfo struct {
 	ID           primitive.ObjectID `bson:"_id"`
@@ -368,6 +373,97 @@ func (c *RelationshipChecker) GetRoles(
 	return roles, nil
 }
 
+// GrantRole records a role for a user in relation to an entity, such as a
+// tracker of a match. The role is kept on an entity relationship between the
+// two, which HasRole and GetRoles read back.
+func (c *RelationshipChecker) GrantRole(
+	ctx context.Context,
+	userID string,
+	entityID string,
+	role Role,
+	grantedBy string,
+) error {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrInvalidViewerID
+	}
+
+	entityObjID, err := primitive.ObjectIDFromHex(entityID)
+	if err != nil {
+		return ErrEntityNotFound
+	}
+
+	grantedByObjID, err := primitive.ObjectIDFromHex(grantedBy)
+	if err != nil {
+		return ErrInvalidOwnerID
+	}
+
+	// Granting again replaces the earlier assignment
+	if err := c.RevokeRole(ctx, userID, entityID, role); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	filter := bson.M{
+		"type":        entityRoleType,
+		"initiatorId": entityObjID,
+		"targetId":    userObjID,
+	}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"status":    "ACTIVE",
+			"createdAt": now,
+		},
+		"$set": bson.M{"updatedAt": now},
+		"$push": bson.M{"roles": RoleAssignment{
+			UserID:    userObjID,
+			Role:      string(role),
+			GrantedAt: now,
+			GrantedBy: grantedByObjID,
+		}},
+	}
+	if _, err := c.relationships().UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	c.cache.Clear(userID)
+	return nil
+}
+
+// RevokeRole removes a role a user was granted in relation to an entity
+func (c *RelationshipChecker) RevokeRole(
+	ctx context.Context,
+	userID string,
+	entityID string,
+	role Role,
+) error {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrInvalidViewerID
+	}
+
+	entityObjID, err := primitive.ObjectIDFromHex(entityID)
+	if err != nil {
+		return ErrEntityNotFound
+	}
+
+	filter := bson.M{
+		"type":        entityRoleType,
+		"initiatorId": entityObjID,
+		"targetId":    userObjID,
+	}
+	update := bson.M{
+		"$set":  bson.M{"updatedAt": time.Now()},
+		"$pull": bson.M{"roles": bson.M{"userId": userObjID, "role": string(role)}},
+	}
+	if _, err := c.relationships().UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	c.cache.Clear(userID)
+	return nil
+}
+
 // ClearCache clears cached access results
 func (c *RelationshipChecker) ClearCache(userIDs ...string) {
 	c.cache.Clear(userIDs...)
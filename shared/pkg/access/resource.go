@@ -0,0 +1,60 @@
+package access
+
+import (
+	"context"
+	"errors"
+)
+
+// ResourceLoaderContextKey is the context key for the service's ResourceLoader
+const ResourceLoaderContextKey contextKey = "accessResourceLoader"
+
+// ErrNoResourceLoader is returned when a resource has to be loaded but the
+// service didn't register a loader
+var ErrNoResourceLoader = errors.New("no resource loader registered")
+
+// Resource is an entity that carries its own access level, such as a match
+// whose owner chooses who can see it
+type Resource interface {
+	// AccessOwner returns the ID of the user whose relationships decide access
+	AccessOwner() string
+	// AccessLevel returns the level a viewer needs with the owner
+	AccessLevel() AccessLevel
+	// AccessMember reports whether a user always has access, like a player in a match
+	AccessMember(userID string) bool
+	// AccessEntity returns the entity's ID and type for entity-specific checks
+	AccessEntity() (id string, entityType string)
+}
+
+// ResourceRef is implemented by data whose access follows another resource,
+// such as the shots of a match
+type ResourceRef interface {
+	AccessResourceID() string
+}
+
+// ResourceLoader loads the resource with the given ID
+type ResourceLoader func(ctx context.Context, id string) (Resource, error)
+
+// CheckResource checks whether a viewer can see a resource. Its owner and
+// members always can, nobody else can see a private resource, and anyone else
+// needs access to the owner at the resource's level.
+func CheckResource(ctx context.Context, checker Checker, resource Resource, viewerID string) (bool, error) {
+	if resource.AccessOwner() == viewerID || resource.AccessMember(viewerID) {
+		return true, nil
+	}
+
+	level := resource.AccessLevel()
+	if level == AccessLevelPrivate {
+		return false, nil
+	}
+
+	entityID, entityType := resource.AccessEntity()
+	result, err := checker.CheckAccess(ctx, resource.AccessOwner(), viewerID, CheckConfig{
+		RequiredLevel: level,
+		EntityID:      entityID,
+		EntityType:    entityType,
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.HasAccess, nil
+}
@@ -2,16 +2,20 @@
 package db
 
 const (
-	DatabaseName                  = "courtiq-db"
-	UsersCollection               = "users"
-	RelationshipsCollection       = "relationships"
-	FriendshipsCollection         = "friendships"
-	CoachshipsCollection          = "coachships"
-	TennisRacketsCollection       = "tennis_rackets"
-	TennisStringsCollection       = "tennis_strings"
-	TennisCourtsCollection        = "tennis_courts"
-	TennisMatchupsCollection      = "tennis_matchups"
-	TennisMatchupsShotsCollection = "tennis_matchups_shots"
-	MessagesCollection            = "messages"
-	ChatsCollection               = "chats"
+	DatabaseName                   = "courtiq-db"
+	UsersCollection                = "users"
+	RelationshipsCollection        = "relationships"
+	FriendshipsCollection          = "friendships"
+	CoachshipsCollection           = "coachships"
+	TennisRacketsCollection        = "tennis_rackets"
+	TennisStringsCollection        = "tennis_strings"
+	TennisCourtsCollection         = "tennis_courts"
+	TennisMatchupsCollection       = "tennis_matchups"
+	TennisMatchupsShotsCollection  = "tennis_matchups_shots"
+	TennisMatchupFormatsCollection = "tennis_matchup_formats"
+	TennisMatchupClaimsCollection  = "tennis_matchup_claims"
+	TennisRatingHistoryCollection  = "tennis_rating_history"
+	TennisTournamentsCollection    = "tennis_tournaments"
+	MessagesCollection             = "messages"
+	ChatsCollection                = "chats"
 )
@@ -30,6 +30,12 @@ func GetAccessCheckerOrPanic(ctx context.Context) access.Checker {
 	return checker
 }
 
+// GetResourceLoader retrieves the service's resource loader from the context
+func GetResourceLoader(ctx context.Context) (access.ResourceLoader, bool) {
+	loader, ok := ctx.Value(access.ResourceLoaderContextKey).(access.ResourceLoader)
+	return loader, ok
+}
+
 // CheckOwnerAccess checks if the current user is the owner of a resource
 func CheckOwnerAccess(ctx context.Context, ownerID string) bool {
 	currentUserID, err := GetMongoIDFromContext(ctx)
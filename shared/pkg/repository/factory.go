@@ -1,10 +1,18 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/CourtIQ/courtiq-backend/shared/pkg/db"
+	sharedErrors "github.com/CourtIQ/courtiq-backend/shared/pkg/errors"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// Transactor runs a unit of work in a database transaction
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // RepositoryFactory provides methods for creating repositories
 type RepositoryFactory struct {
 	db *db.MongoDB
@@ -32,3 +40,26 @@ func (factory *RepositoryFactory) CreateRepository(collectionName string) interf
 func (factory *RepositoryFactory) GetCollection(name string) *mongo.Collection {
 	return factory.db.GetCollection(name)
 }
+
+// WithTransaction runs fn in a MongoDB multi-document transaction, committing
+// when fn returns nil and aborting otherwise. Repositories take part in the
+// transaction when they are called with the context passed to fn. A call made
+// while a transaction is already open joins it instead of starting another.
+// Transient transaction errors are retried by the driver, so fn may run more
+// than once and should not have side effects outside the database.
+func (factory *RepositoryFactory) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := factory.db.GetClient().StartSession()
+	if err != nil {
+		return sharedErrors.WrapError(err, "failed to start session")
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessionCtx)
+	})
+	return err
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/CourtIQ/courtiq-backend/shared/pkg/access"
@@ -26,7 +28,11 @@ func CreateFieldDirectiveMiddleware(directiveName string, fn DirectiveFunc) grap
 		// Convert directive arguments to map
 		args := make(map[string]interface{})
 		for _, arg := range directive.Arguments {
-			args[arg.Name] = arg.Value.Value
+			value, err := arg.Value.Value(nil)
+			if err != nil {
+				return nil, err
+			}
+			args[arg.Name] = value
 		}
 
 		return fn(ctx, fieldCtx.Object, next, args)
@@ -40,7 +46,12 @@ func GetDefaultDirectives() DirectivesMap {
 	}
 }
 
-// AccessControlDirective implements the @accessControl directive
+// AccessControlDirective implements the @accessControl directive. Besides the
+// requiredLevel checked against the ownerField of the parent object, a field
+// can be guarded by resources that carry their own access level: the one whose
+// ID is in the argument named by entityArg, and every access.Resource or
+// access.ResourceRef the field returns. Resources the viewer can't see are
+// left out of a returned list.
 func AccessControlDirective(ctx context.Context, obj interface{}, next graphql.Resolver, args map[string]interface{}) (interface{}, error) {
 	// Get access checker from context
 	checker, ok := middleware.GetAccessChecker(ctx)
@@ -49,6 +60,14 @@ func AccessControlDirective(ctx context.Context, obj interface{}, next graphql.R
 		return next(ctx)
 	}
 
+	redactWithNull, _ := args["redactWithNull"].(bool)
+	deny := func() (interface{}, error) {
+		if redactWithNull {
+			return nil, nil
+		}
+		return nil, access.ErrAccessDenied
+	}
+
 	// Get required level from directive args
 	requiredLevel, ok := args["requiredLevel"].(string)
 	if !ok {
@@ -74,11 +93,7 @@ func AccessControlDirective(ctx context.Context, obj interface{}, next graphql.R
 	currentUserID, err := middleware.GetMongoIDFromContext(ctx)
 	if err != nil {
 		// No user in context - default to no access
-		redactWithNull, _ := args["redactWithNull"].(bool)
-		if redactWithNull {
-			return nil, nil
-		}
-		return nil, access.ErrAccessDenied
+		return deny()
 	}
 
 	// Check if user has access
@@ -90,13 +105,112 @@ func AccessControlDirective(ctx context.Context, obj interface{}, next graphql.R
 	result, err := checker.CheckAccess(ctx, ownerID, currentUserID.Hex(), config)
 	if err != nil || !result.HasAccess {
 		// Access denied
-		redactWithNull, _ := args["redactWithNull"].(bool)
-		if redactWithNull {
-			return nil, nil
+		return deny()
+	}
+
+	guard := &resourceGuard{checker: checker, viewerID: currentUserID.Hex(), visible: make(map[string]bool)}
+
+	// Check the resource named by the field's arguments before resolving it
+	if entityArg, ok := args["entityArg"].(string); ok {
+		visible, err := guard.canSeeID(ctx, argumentID(graphql.GetFieldContext(ctx).Args[entityArg]))
+		if err != nil {
+			return nil, err
 		}
-		return nil, access.ErrAccessDenied
+		if !visible {
+			return deny()
+		}
+	}
+
+	res, err := next(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	// Check the resources the field returned
+	res, visible, err := guard.filter(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return deny()
 	}
+	return res, nil
+}
+
+// resourceGuard checks a viewer's access to resources for one field,
+// loading each referenced resource once
+type resourceGuard struct {
+	checker  access.Checker
+	viewerID string
+	visible  map[string]bool
+}
+
+// filter checks a resolved value. A list keeps the items the viewer can see,
+// anything else reports whether the viewer can see it.
+func (g *resourceGuard) filter(ctx context.Context, res interface{}) (interface{}, bool, error) {
+	value := reflect.ValueOf(res)
+	switch value.Kind() {
+	case reflect.Invalid:
+		return res, true, nil
+	case reflect.Ptr:
+		if value.IsNil() {
+			return res, true, nil
+		}
+	case reflect.Slice:
+		kept := reflect.MakeSlice(value.Type(), 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			visible, err := g.canSee(ctx, value.Index(i).Interface())
+			if err != nil {
+				return nil, false, err
+			}
+			if visible {
+				kept = reflect.Append(kept, value.Index(i))
+			}
+		}
+		return kept.Interface(), true, nil
+	}
+
+	visible, err := g.canSee(ctx, res)
+	return res, visible, err
+}
+
+// canSee checks a single value. Values that aren't resources are visible.
+func (g *resourceGuard) canSee(ctx context.Context, value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case access.Resource:
+		return access.CheckResource(ctx, g.checker, v, g.viewerID)
+	case access.ResourceRef:
+		return g.canSeeID(ctx, v.AccessResourceID())
+	}
+	return true, nil
+}
 
-	// Access granted
-	return next(ctx)
-}
\ No newline at end of file
+// canSeeID loads a resource through the context's loader and checks it
+func (g *resourceGuard) canSeeID(ctx context.Context, id string) (bool, error) {
+	if visible, ok := g.visible[id]; ok {
+		return visible, nil
+	}
+
+	loader, ok := middleware.GetResourceLoader(ctx)
+	if !ok {
+		return false, access.ErrNoResourceLoader
+	}
+	resource, err := loader(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	visible, err := access.CheckResource(ctx, g.checker, resource, g.viewerID)
+	if err != nil {
+		return false, err
+	}
+	g.visible[id] = visible
+	return visible, nil
+}
+
+// argumentID turns an ID argument, an ObjectID or a string, into its string form
+func argumentID(arg interface{}) string {
+	if id, ok := arg.(interface{ Hex() string }); ok {
+		return id.Hex()
+	}
+	return fmt.Sprint(arg)
+}
@@ -189,6 +189,15 @@ func (s *Server) RegisterDirectives(directives DirectivesMap) {
 	}
 }
 
+// RegisterResourceLoader lets @accessControl load the resources named by
+// its entityArg and by ResourceRef results
+func (s *Server) RegisterResourceLoader(loader access.ResourceLoader) {
+	s.GraphQLHandler.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		ctx = context.WithValue(ctx, access.ResourceLoaderContextKey, loader)
+		return next(ctx)
+	})
+}
+
 // Close cleans up server resources
 func (s *Server) Close(ctx context.Context) error {
 	if s.MongoDB != nil {
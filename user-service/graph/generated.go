@@ -91,7 +91,6 @@ type ComplexityRoot struct {
 		LastUpdated    func(childComplexity int) int
 		Location       func(childComplexity int) int
 		ProfilePicture func(childComplexity int) int
-		Rating         func(childComplexity int) int
 		Username       func(childComplexity int) int
 	}
 
@@ -338,13 +337,6 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.User.ProfilePicture(childComplexity), true
 
-	case "User.rating":
-		if e.complexity.User.Rating == nil {
-			break
-		}
-
-		return e.complexity.User.Rating(childComplexity), true
-
 	case "User.username":
 		if e.complexity.User.Username == nil {
 			break
@@ -828,8 +820,6 @@ func (ec *executionContext) fieldContext_Entity_findUserByID(ctx context.Context
 				return ec.fieldContext_User_bio(ctx, field)
 			case "location":
 				return ec.fieldContext_User_location(ctx, field)
-			case "rating":
-				return ec.fieldContext_User_rating(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_User_createdAt(ctx, field)
 			case "lastUpdated":
@@ -1119,8 +1109,6 @@ func (ec *executionContext) fieldContext_Mutation_updateUser(ctx context.Context
 				return ec.fieldContext_User_bio(ctx, field)
 			case "location":
 				return ec.fieldContext_User_location(ctx, field)
-			case "rating":
-				return ec.fieldContext_User_rating(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_User_createdAt(ctx, field)
 			case "lastUpdated":
@@ -1205,8 +1193,6 @@ func (ec *executionContext) fieldContext_Query_me(_ context.Context, field graph
 				return ec.fieldContext_User_bio(ctx, field)
 			case "location":
 				return ec.fieldContext_User_location(ctx, field)
-			case "rating":
-				return ec.fieldContext_User_rating(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_User_createdAt(ctx, field)
 			case "lastUpdated":
@@ -1280,8 +1266,6 @@ func (ec *executionContext) fieldContext_Query_getUser(ctx context.Context, fiel
 				return ec.fieldContext_User_bio(ctx, field)
 			case "location":
 				return ec.fieldContext_User_location(ctx, field)
-			case "rating":
-				return ec.fieldContext_User_rating(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_User_createdAt(ctx, field)
 			case "lastUpdated":
@@ -2106,47 +2090,6 @@ func (ec *executionContext) fieldContext_User_location(_ context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _User_rating(ctx context.Context, field graphql.CollectedField, obj *model.User) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_User_rating(ctx, field)
-	if err != nil {
-		return graphql.Null
-	}
-	ctx = graphql.WithFieldContext(ctx, fc)
-	defer func() {
-		if r := recover(); r != nil {
-			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = graphql.Null
-		}
-	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
-		ctx = rctx // use context from middleware stack in children
-		return obj.Rating, nil
-	})
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	if resTmp == nil {
-		return graphql.Null
-	}
-	res := resTmp.(*int)
-	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
-}
-
-func (ec *executionContext) fieldContext_User_rating(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
-	fc = &graphql.FieldContext{
-		Object:     "User",
-		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
-		},
-	}
-	return fc, nil
-}
-
 func (ec *executionContext) _User_createdAt(ctx context.Context, field graphql.CollectedField, obj *model.User) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_User_createdAt(ctx, field)
 	if err != nil {
@@ -4591,8 +4534,6 @@ func (ec *executionContext) _User(ctx context.Context, sel ast.SelectionSet, obj
 			out.Values[i] = ec._User_bio(ctx, field, obj)
 		case "location":
 			out.Values[i] = ec._User_location(ctx, field, obj)
-		case "rating":
-			out.Values[i] = ec._User_rating(ctx, field, obj)
 		case "createdAt":
 			out.Values[i] = ec._User_createdAt(ctx, field, obj)
 		case "lastUpdated":
@@ -5643,22 +5584,6 @@ func (ec *executionContext) marshalOGender2ᚖgithubᚗcomᚋCourtIQᚋcourtiq
 	return v
 }
 
-func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v any) (*int, error) {
-	if v == nil {
-		return nil, nil
-	}
-	res, err := graphql.UnmarshalInt(v)
-	return &res, graphql.ErrorOnPath(ctx, err)
-}
-
-func (ec *executionContext) marshalOInt2ᚖint(ctx context.Context, sel ast.SelectionSet, v *int) graphql.Marshaler {
-	if v == nil {
-		return graphql.Null
-	}
-	res := graphql.MarshalInt(*v)
-	return res
-}
-
 func (ec *executionContext) marshalOLocation2ᚖgithubᚗcomᚋCourtIQᚋcourtiqᚑbackendᚋuserᚑserviceᚋgraphᚋmodelᚐLocation(ctx context.Context, sel ast.SelectionSet, v *model.Location) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -61,7 +61,6 @@ type User struct {
 	DateOfBirth    *time.Time         `json:"dateOfBirth,omitempty" bson:"dateOfBirth,omitempty"`
 	Bio            *string            `json:"bio,omitempty" bson:"bio,omitempty"`
 	Location       *Location          `json:"location,omitempty" bson:"location,omitempty"`
-	Rating         *int               `json:"rating,omitempty" bson:"rating,omitempty"`
 	CreatedAt      *time.Time         `json:"createdAt,omitempty" bson:"createdAt,omitempty"`
 	LastUpdated    *time.Time         `json:"lastUpdated,omitempty" bson:"lastUpdated,omitempty"`
 	FcmTokens      []*string          `json:"fcmTokens,omitempty" bson:"fcmTokens,omitempty"`